@@ -73,3 +73,41 @@ skip_if_no_aws_tests() {
     dolt push origin master:another-branch
     dolt push origin :another-branch
 }
+
+skip_if_no_s3_tests() {
+    if [ -z "$DOLT_BATS_S3_BUCKET" ]; then
+      skip "skipping s3 tests; set DOLT_BATS_S3_BUCKET, and optionally DOLT_BATS_S3_ENDPOINT, to run"
+    fi
+}
+
+@test "can add remote with s3 url" {
+    dolt remote add --aws-endpoint http://localhost:9000 origin 's3://s3_bucket/repo_name'
+    run dolt remote -v
+    [[ "$output" =~ "s3://s3_bucket/repo_name" ]] || false
+}
+
+@test "aws params are not valid for non aws remotes" {
+    run dolt remote add --aws-endpoint http://localhost:9000 origin 'http://localhost:50051/org/repo_name'
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "only valid for aws and s3 remotes" ]] || false
+}
+
+@test "can push to and clone an s3 remote" {
+    skip_if_no_s3_tests
+    random_repo=`openssl rand -hex 32`
+    endpoint_params=
+    if [ -n "$DOLT_BATS_S3_ENDPOINT" ]; then
+      endpoint_params="--aws-endpoint $DOLT_BATS_S3_ENDPOINT"
+    fi
+    dolt remote add $endpoint_params origin 's3://'"$DOLT_BATS_S3_BUCKET"'/'"$random_repo"
+    dolt sql -q "CREATE TABLE test (pk BIGINT PRIMARY KEY)"
+    dolt add test
+    dolt commit -m "created table"
+    dolt push origin master
+
+    cd dolt-repo-clones
+    dolt clone $endpoint_params 's3://'"$DOLT_BATS_S3_BUCKET"'/'"$random_repo" repo
+    cd repo
+    run dolt ls
+    [[ "$output" =~ "test" ]] || false
+}
@@ -0,0 +1,119 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql -q "CREATE TABLE files (pk BIGINT PRIMARY KEY, name TEXT, contents LONGBLOB, INDEX (name))"
+    dolt sql -q "INSERT INTO files VALUES (1, 'readme', 'hello world'), (2, 'notes', NULL), (3, 'big', REPEAT('abcdefgh', 250000))"
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "blob: TEXT and BLOB values round trip" {
+    run dolt schema show files
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '`name` text' ]] || false
+    [[ "$output" =~ '`contents` longblob' ]] || false
+
+    run dolt sql -q "SELECT pk, name, LENGTH(contents) FROM files ORDER BY pk" -r csv
+    [ "$status" -eq 0 ]
+    [[ "${lines[1]}" = "1,readme,11" ]] || false
+    [[ "${lines[2]}" = "2,notes," ]] || false
+    [[ "${lines[3]}" = "3,big,2000000" ]] || false
+
+    run dolt sql -q "SELECT contents FROM files WHERE pk = 1" -r csv
+    [ "$status" -eq 0 ]
+    [[ "${lines[1]}" = "hello world" ]] || false
+
+    run dolt sql -q "CREATE TABLE small (pk BIGINT PRIMARY KEY, t TINYTEXT)"
+    [ "$status" -eq 0 ]
+    run dolt sql -q "INSERT INTO small VALUES (1, REPEAT('a', 256))"
+    [ "$status" -eq 1 ]
+}
+
+@test "blob: TEXT columns are stored as strings when used in keys" {
+    run dolt sql -q "SELECT pk FROM files WHERE name >= 'c' ORDER BY name" -r csv
+    [ "$status" -eq 0 ]
+    [[ "${lines[1]}" = "2" ]] || false
+    [[ "${lines[2]}" = "1" ]] || false
+
+    dolt sql -q "CREATE TABLE notes (pk BIGINT PRIMARY KEY, title TEXT, body LONGTEXT)"
+    dolt sql -q "INSERT INTO notes VALUES (1, 'zz', 'a'), (2, 'a', 'b'), (3, 'ab', NULL)"
+    dolt sql -q "CREATE INDEX idx_title ON notes (title)"
+    run dolt sql -q "SELECT pk FROM notes WHERE title >= 'ab' ORDER BY title" -r csv
+    [ "$status" -eq 0 ]
+    [[ "${lines[1]}" = "3" ]] || false
+    [[ "${lines[2]}" = "1" ]] || false
+
+    dolt sql -q "CREATE TABLE textkeys (pk TEXT PRIMARY KEY, v BIGINT)"
+    dolt sql -q "INSERT INTO textkeys VALUES ('zz', 1), ('a', 2), ('ab', 3)"
+    run dolt sql -q "SELECT pk, v FROM textkeys WHERE pk >= 'ab' ORDER BY pk" -r csv
+    [ "$status" -eq 0 ]
+    [[ "${lines[1]}" = "ab,3" ]] || false
+    [[ "${lines[2]}" = "zz,1" ]] || false
+    dolt sql -q "ALTER TABLE textkeys RENAME COLUMN pk TO id"
+    run dolt sql -q "SELECT id, v FROM textkeys WHERE id = 'a'" -r csv
+    [ "$status" -eq 0 ]
+    [[ "${lines[1]}" = "a,2" ]] || false
+}
+
+@test "blob: BLOB columns cannot be used in keys" {
+    run dolt sql -q "CREATE INDEX idx_contents ON files (contents)"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ 'BLOB column `contents` cannot be used in a primary key or index' ]] || false
+
+    run dolt sql -q "CREATE TABLE blobkeys (pk BLOB PRIMARY KEY, v BIGINT)"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ 'BLOB column `pk` cannot be used in a primary key or index' ]] || false
+}
+
+@test "blob: diff summarizes bytes changed in large values" {
+    dolt add files
+    dolt commit -m "added files"
+    dolt sql -q "UPDATE files SET contents = CONCAT('1234', contents) WHERE pk = 3"
+
+    run dolt diff --summary
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1 Row Modified (33.33%)" ]] || false
+    [[ "$output" =~ "4 Bytes Added, 0 Bytes Deleted in BLOB/TEXT Cells" ]] || false
+
+    run dolt diff -r sql
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "UPDATE \`files\` SET" ]] || false
+    [[ "$output" =~ "\`contents\`='1234abcdefgh" ]] || false
+}
+
+@test "blob: changes to different columns of a row merge" {
+    dolt add files
+    dolt commit -m "added files"
+    dolt checkout -b other
+    dolt sql -q "UPDATE files SET name = 'readme.md' WHERE pk = 1"
+    dolt add files
+    dolt commit -m "renamed readme"
+    dolt checkout master
+    dolt sql -q "UPDATE files SET contents = 'goodbye' WHERE pk = 1"
+    dolt add files
+    dolt commit -m "changed readme"
+
+    run dolt merge other
+    [ "$status" -eq 0 ]
+    run dolt sql -q "SELECT name, contents FROM files WHERE pk = 1" -r csv
+    [ "$status" -eq 0 ]
+    [[ "${lines[1]}" = "readme.md,goodbye" ]] || false
+}
+
+@test "blob: import and export values" {
+    run dolt table export files export.csv
+    [ "$status" -eq 0 ]
+    run head -n 3 export.csv
+    [[ "${lines[1]}" = "1,readme,hello world" ]] || false
+    [[ "${lines[2]}" = "2,notes," ]] || false
+
+    dolt add files
+    dolt commit -m "added files"
+    run dolt table import -u files export.csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Had No Effect: 3" ]] || false
+}
@@ -0,0 +1,150 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql <<SQL
+CREATE TABLE parent (
+  pk BIGINT PRIMARY KEY,
+  v1 BIGINT,
+  INDEX (v1)
+);
+CREATE TABLE child (
+  pk BIGINT PRIMARY KEY,
+  parent_v1 BIGINT,
+  CONSTRAINT child_parent FOREIGN KEY (parent_v1) REFERENCES parent (v1)
+);
+INSERT INTO parent VALUES (1,1), (2,2);
+INSERT INTO child VALUES (1,1);
+SQL
+    dolt add .
+    dolt commit -m "created tables"
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "branch-protection: dolt_branch_protection is empty until a rule is declared" {
+    run dolt sql -q "SELECT * FROM dolt_branch_protection" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "branch,merge_only,no_force_push,verify_constraints,message_pattern" ]] || false
+    [ "${#lines[@]}" -eq 1 ]
+
+    dolt sql -q "INSERT INTO dolt_branch_protection (branch, no_force_push) VALUES ('master', true)"
+    run dolt sql -q "SELECT branch FROM dolt_branch_protection WHERE no_force_push" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "master" ]] || false
+
+    run dolt status
+    [[ "$output" =~ "dolt_branch_protection" ]] || false
+}
+
+@test "branch-protection: commit messages must match the pattern" {
+    dolt sql -q "INSERT INTO dolt_branch_protection (branch, message_pattern) VALUES ('master', '^ISSUE-[0-9]+: ')"
+    dolt add .
+    dolt commit -m "rules only apply once committed"
+
+    dolt sql -q "INSERT INTO parent VALUES (3,3)"
+    dolt add .
+    run dolt commit -m "no issue"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "branch 'master' is protected" ]] || false
+    [[ "$output" =~ "does not match the pattern" ]] || false
+
+    run dolt sql -q "SELECT DOLT_COMMIT('-m', 'no issue')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "branch 'master' is protected" ]] || false
+
+    dolt commit -m "ISSUE-1: add a parent"
+
+    # other branches are not protected
+    dolt checkout -b feature
+    dolt sql -q "INSERT INTO parent VALUES (4,4)"
+    dolt add .
+    dolt commit -m "no issue"
+}
+
+@test "branch-protection: merge only branches only accept merge commits" {
+    dolt checkout -b feature
+    dolt sql -q "INSERT INTO parent VALUES (3,3)"
+    dolt add .
+    dolt commit -m "feature change"
+    dolt checkout master
+
+    dolt sql -q "INSERT INTO dolt_branch_protection (branch, merge_only) VALUES ('master', true)"
+    dolt add .
+    dolt commit -m "protect master"
+
+    dolt sql -q "INSERT INTO parent VALUES (4,4)"
+    dolt add .
+    run dolt commit -m "direct change"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "only merge commits are allowed" ]] || false
+
+    dolt reset --hard
+    dolt merge feature
+    dolt commit -m "merge feature"
+    run dolt sql -q "SELECT pk FROM parent WHERE pk = 3" -r csv
+    [[ "$output" =~ "3" ]] || false
+}
+
+@test "branch-protection: fast forward merges are checked" {
+    dolt sql -q "INSERT INTO dolt_branch_protection (branch, message_pattern) VALUES ('master', '^ISSUE-[0-9]+: ')"
+    dolt add .
+    dolt commit -m "ISSUE-1: protect master"
+
+    dolt checkout -b feature
+    dolt sql -q "INSERT INTO parent VALUES (3,3)"
+    dolt add .
+    dolt commit -m "no issue"
+    dolt checkout master
+
+    run dolt merge feature
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "does not match the pattern" ]] || false
+    run dolt sql -q "SELECT pk FROM parent WHERE pk = 3" -r csv
+    [[ ! "$output" =~ "3" ]] || false
+}
+
+@test "branch-protection: constraints must be satisfied" {
+    dolt sql -q "INSERT INTO dolt_branch_protection (branch, verify_constraints) VALUES ('master', true)"
+    dolt add .
+    dolt commit -m "protect master"
+
+    dolt sql <<SQL
+SET foreign_key_checks=0;
+DELETE FROM parent WHERE pk = 1;
+SET foreign_key_checks=1;
+SQL
+    dolt add .
+    run dolt commit --force -m "break constraint"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "constraints are not satisfied" ]] || false
+    [[ "$output" =~ "child_parent" ]] || false
+}
+
+@test "branch-protection: force pushes are rejected" {
+    mkdir remote
+    dolt remote add origin file://remote
+    dolt sql -q "INSERT INTO dolt_branch_protection (branch, no_force_push) VALUES ('master', true)"
+    dolt add .
+    dolt commit -m "protect master"
+    dolt push origin master
+
+    dolt sql -q "INSERT INTO parent VALUES (3,3)"
+    dolt add .
+    dolt commit -m "new parent"
+    dolt push origin master
+
+    dolt reset --hard HEAD~1
+    dolt sql -q "INSERT INTO parent VALUES (4,4)"
+    dolt add .
+    dolt commit -m "diverged"
+    run dolt push --force origin master
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "force pushes are not allowed" ]] || false
+
+    run dolt push origin master
+    [ "$status" -eq 1 ]
+}
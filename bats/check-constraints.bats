@@ -0,0 +1,131 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql -q "CREATE TABLE products (pk BIGINT PRIMARY KEY, price BIGINT, name VARCHAR(20))"
+    dolt sql -q "INSERT INTO products VALUES (1, 10, 'apple'), (2, NULL, 'pear')"
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "check-constraints: add a check and enforce it on insert and update" {
+    run dolt schema add-check products chk_price "price > 0"
+    [ "$status" -eq 0 ]
+
+    run dolt schema show products
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ 'CONSTRAINT `chk_price` CHECK (price > 0)' ]] || false
+
+    run dolt sql -q "INSERT INTO products VALUES (3, -1, 'plum')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ 'check constraint `chk_price` is violated: price > 0' ]] || false
+
+    run dolt sql -q "UPDATE products SET price = 0 WHERE pk = 1"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ 'check constraint `chk_price` is violated' ]] || false
+
+    run dolt sql -q "INSERT INTO products VALUES (3, NULL, 'plum')"
+    [ "$status" -eq 0 ]
+    run dolt sql -q "INSERT INTO products VALUES (4, 3, 'kiwi')"
+    [ "$status" -eq 0 ]
+
+    run dolt sql -q "SELECT COUNT(*) FROM products" -r csv
+    [[ "$output" =~ "4" ]] || false
+}
+
+@test "check-constraints: existing rows must satisfy a new check" {
+    run dolt schema add-check products chk_price "price > 10"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ 'violated by 1 row(s)' ]] || false
+
+    run dolt schema add-check products chk_col "missing > 10"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ 'unknown column `missing`' ]] || false
+
+    run dolt schema show products
+    [[ ! "$output" =~ "CHECK" ]] || false
+}
+
+@test "check-constraints: drop a check" {
+    dolt schema add-check products chk_price "price > 0"
+    run dolt schema add-check products CHK_PRICE "price > 1"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "already exists" ]] || false
+
+    run dolt schema drop-check products chk_price
+    [ "$status" -eq 0 ]
+    run dolt sql -q "INSERT INTO products VALUES (3, -1, 'plum')"
+    [ "$status" -eq 0 ]
+
+    run dolt schema drop-check products chk_price
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "does not exist" ]] || false
+}
+
+@test "check-constraints: columns used in a check cannot be dropped or renamed" {
+    dolt schema add-check products chk_price "price > 0"
+    run dolt sql -q "ALTER TABLE products DROP COLUMN price"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ 'cannot drop column `price` as it is used in CHECK constraint `chk_price`' ]] || false
+
+    run dolt sql -q "ALTER TABLE products RENAME COLUMN price TO cost"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ 'cannot rename column `price`' ]] || false
+
+    run dolt sql -q "ALTER TABLE products DROP COLUMN name"
+    [ "$status" -eq 0 ]
+    run dolt schema show products
+    [[ "$output" =~ 'CONSTRAINT `chk_price` CHECK (price > 0)' ]] || false
+}
+
+@test "check-constraints: merged rows must satisfy checks" {
+    dolt add .
+    dolt commit -m "created products"
+    dolt checkout -b other
+    dolt sql -q "INSERT INTO products VALUES (3, 500, 'melon')"
+    dolt commit -am "inserted melon"
+    dolt checkout master
+    dolt schema add-check products chk_price "price < 100"
+    dolt commit -am "added check"
+
+    run dolt merge other
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ 'check constraint `chk_price` is violated' ]] || false
+}
+
+@test "check-constraints: merging checks" {
+    dolt add .
+    dolt commit -m "created products"
+    dolt checkout -b other
+    dolt schema add-check products chk_name "LENGTH(name) < 10"
+    dolt commit -am "added chk_name"
+    dolt checkout master
+    dolt schema add-check products chk_price "price < 100"
+    dolt commit -am "added chk_price"
+
+    run dolt merge other
+    [ "$status" -eq 0 ]
+    run dolt schema show products
+    [[ "$output" =~ "chk_price" ]] || false
+    [[ "$output" =~ "chk_name" ]] || false
+    dolt commit -m "merged"
+
+    dolt checkout other
+    dolt sql -q "ALTER TABLE products DROP COLUMN price"
+    dolt commit -am "dropped price"
+    dolt checkout master
+    run dolt merge other
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "CONFLICT (schema)" ]] || false
+    run dolt sql -q "SELECT description FROM dolt_schema_conflicts" -r csv
+    [[ "$output" =~ "CHECK constraint 'chk_price' references a column which is not in the merged schema" ]] || false
+}
+
+@test "check-constraints: verify-constraints reports violated checks" {
+    dolt schema add-check products chk_price "price > 0"
+    run dolt verify-constraints products
+    [ "$status" -eq 0 ]
+}
@@ -0,0 +1,98 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql <<SQL
+CREATE TABLE test (
+  pk BIGINT NOT NULL,
+  c1 BIGINT,
+  PRIMARY KEY (pk)
+);
+SQL
+    dolt add .
+    dolt commit -m "added table"
+
+    dolt checkout -b branch1
+    dolt sql -q "INSERT INTO test VALUES (1,1)"
+    dolt commit -am "add pk 1"
+    dolt sql -q "INSERT INTO test VALUES (2,2)"
+    dolt commit -am "add pk 2"
+    dolt checkout master
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "cherry-pick: applies a single commit" {
+    run dolt cherry-pick branch1
+    [ "$status" -eq 0 ]
+
+    run dolt sql -q "SELECT pk FROM test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+    [[ ! "$output" =~ "1" ]] || false
+
+    run dolt log -n 1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "add pk 2" ]] || false
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+}
+
+@test "cherry-pick: --no-commit stages the changes" {
+    run dolt cherry-pick --no-commit branch1~1
+    [ "$status" -eq 0 ]
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Changes to be committed" ]] || false
+    [[ "$output" =~ "test" ]] || false
+
+    run dolt log -n 1
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "add pk 1" ]] || false
+}
+
+@test "cherry-pick: refuses to run with uncommitted changes" {
+    dolt sql -q "INSERT INTO test VALUES (3,3)"
+    run dolt cherry-pick branch1
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "local changes would be overwritten" ]] || false
+}
+
+@test "cherry-pick: conflicts are left in the working set" {
+    dolt sql -q "INSERT INTO test VALUES (2,20)"
+    dolt commit -am "add conflicting pk 2"
+
+    run dolt cherry-pick branch1
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "CONFLICT" ]] || false
+
+    run dolt sql -q "SELECT count(*) FROM dolt_conflicts_test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1" ]] || false
+}
+
+@test "cherry-pick: fails on a commit without a parent" {
+    run dolt cherry-pick master~1
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "has no parent" ]] || false
+}
+
+@test "cherry-pick: DOLT_CHERRY_PICK applies changes to the working set" {
+    run dolt sql -q "SELECT DOLT_CHERRY_PICK('branch1~1')"
+    [ "$status" -eq 0 ]
+
+    run dolt sql -q "SELECT pk FROM test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1" ]] || false
+    [[ ! "$output" =~ "2" ]] || false
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "modified" ]] || false
+}
@@ -0,0 +1,91 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql <<SQL
+CREATE TABLE accounts (
+  id BIGINT PRIMARY KEY,
+  balance BIGINT
+);
+INSERT INTO accounts VALUES (1, 10);
+INSERT INTO dolt_hooks VALUES ('no_negative_balance', 'pre-commit', 'SELECT * FROM accounts WHERE balance < 0');
+SQL
+    dolt add .
+    dolt commit -m "created tables"
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "hooks: pre-commit sql hooks stop commits" {
+    run dolt sql -q "SELECT name, event FROM dolt_hooks" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "no_negative_balance,pre-commit" ]] || false
+
+    dolt sql -q "INSERT INTO accounts VALUES (2, -5)"
+    dolt add .
+    run dolt commit -m "negative balance"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "pre-commit hook 'no_negative_balance' failed" ]] || false
+
+    run dolt sql -q "SELECT DOLT_COMMIT('-m', 'negative balance')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "pre-commit hook 'no_negative_balance' failed" ]] || false
+
+    dolt sql -q "UPDATE accounts SET balance = 5 WHERE id = 2"
+    dolt add .
+    dolt commit -m "positive balance"
+}
+
+@test "hooks: unknown events are rejected" {
+    dolt sql -q "INSERT INTO dolt_hooks VALUES ('bad', 'pre-push', 'SELECT 1')"
+    dolt add .
+    run dolt commit -m "bad hook"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "unknown event 'pre-push'" ]] || false
+}
+
+@test "hooks: executables get the commit hash and changed tables" {
+    printf '#!/bin/sh\necho "hook $DOLT_HOOK $@"\n' > post-commit.sh
+    chmod +x post-commit.sh
+    dolt config --local --add hooks.post-commit "$PWD/post-commit.sh"
+
+    dolt sql -q "INSERT INTO accounts VALUES (2, 5)"
+    dolt add .
+    run dolt commit -m "new account"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "hook post-commit" ]] || false
+    [[ "$output" =~ "accounts" ]] || false
+
+    # failures of post-commit hooks do not undo the commit
+    dolt config --local --add hooks.post-commit false
+    dolt sql -q "INSERT INTO accounts VALUES (3, 5)"
+    dolt add .
+    run dolt commit -m "another account"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "warning: post-commit hook 'false' failed" ]] || false
+    run dolt log
+    [[ "$output" =~ "another account" ]] || false
+}
+
+@test "hooks: pre-merge hooks stop merges" {
+    dolt checkout -b feature
+    dolt sql -q "INSERT INTO accounts VALUES (2, 5)"
+    dolt add .
+    dolt commit -m "feature change"
+    dolt checkout master
+
+    dolt config --local --add hooks.pre-merge false
+    run dolt merge feature
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "pre-merge hook 'false' failed" ]] || false
+    run dolt sql -q "SELECT * FROM accounts WHERE id = 2" -r csv
+    [ "${#lines[@]}" -eq 1 ]
+
+    dolt config --local --add hooks.pre-merge true
+    dolt merge feature
+    run dolt sql -q "SELECT * FROM accounts WHERE id = 2" -r csv
+    [[ "$output" =~ "2,5" ]] || false
+}
@@ -0,0 +1,138 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql -q "CREATE TABLE docs (pk BIGINT PRIMARY KEY, j JSON)"
+    dolt sql -q "INSERT INTO docs VALUES (1, '{\"b\": 2, \"a\": {\"x\": [1, 2, 3]}}'), (2, '[1, \"two\", null]'), (3, NULL)"
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "json: documents are validated and stored in canonical form" {
+    run dolt schema show docs
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '`j` json' ]] || false
+
+    run dolt sql -q "SELECT j FROM docs WHERE pk = 1" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '{"a":{"x":[1,2,3]},"b":2}' ]] || false
+
+    run dolt sql -q "INSERT INTO docs VALUES (4, 'not json')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "invalid JSON text" ]] || false
+
+    run dolt sql -q "INSERT INTO docs VALUES (4, '{\"a\": 1')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "invalid JSON text" ]] || false
+}
+
+@test "json: JSON_EXTRACT reads values from documents" {
+    run dolt sql -q "SELECT JSON_EXTRACT(j, '$.a.x') FROM docs WHERE pk = 1" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "[1,2,3]" ]] || false
+
+    run dolt sql -q "SELECT JSON_EXTRACT(j, '$.b') FROM docs WHERE pk = 1" -r csv
+    [ "$status" -eq 0 ]
+    [[ "${lines[1]}" = "2" ]] || false
+}
+
+@test "json: JSON columns cannot be indexed" {
+    run dolt sql -q "CREATE INDEX idx ON docs (j)"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ 'JSON column `j` cannot be used in a primary key or index' ]] || false
+
+    run dolt sql -q "ALTER TABLE docs ADD INDEX idx (j)"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ 'JSON column `j` cannot be used in a primary key or index' ]] || false
+
+    run dolt sql -q "CREATE TABLE bad (j JSON PRIMARY KEY)"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ 'JSON column `j` cannot be used in a primary key or index' ]] || false
+
+    cat <<SQL > bad.sql
+CREATE TABLE bad (j JSON, v INT, PRIMARY KEY (j));
+SQL
+    echo 'j,v' > bad.csv
+    run dolt table import -c -s bad.sql bad bad.csv
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ 'JSON column `j` cannot be used in a primary key or index' ]] || false
+}
+
+@test "json: diffs render documents" {
+    dolt add docs
+    dolt commit -m "added docs"
+    dolt sql -q "UPDATE docs SET j = '{\"b\": 3, \"a\": {\"x\": [1, 2, 3]}}' WHERE pk = 1"
+
+    run dolt diff
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '<  | 1  | {"a":{"x":[1,2,3]},"b":2}' ]] || false
+    [[ "$output" =~ '>  | 1  | {"a":{"x":[1,2,3]},"b":3}' ]] || false
+
+    run dolt diff -r sql
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ 'UPDATE `docs` SET `j`=' ]] || false
+
+    dolt commit -am "changed b"
+    run dolt sql -q "SELECT to_j, from_j FROM dolt_diff_docs WHERE diff_type = 'modified'" -r json
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '"to_j":{"a":{"x":[1,2,3]},"b":3}' ]] || false
+    [[ "$output" =~ '"from_j":{"a":{"x":[1,2,3]},"b":2}' ]] || false
+}
+
+@test "json: changes to different keys of a document merge" {
+    dolt add docs
+    dolt commit -m "added docs"
+    dolt checkout -b other
+    dolt sql -q "UPDATE docs SET j = '{\"b\": 3, \"a\": {\"x\": [1, 2, 3]}}' WHERE pk = 1"
+    dolt commit -am "changed b"
+    dolt checkout master
+    dolt sql -q "UPDATE docs SET j = '{\"b\": 2, \"a\": {\"x\": [1, 2, 3], \"y\": true}}' WHERE pk = 1"
+    dolt commit -am "added a.y"
+
+    run dolt merge other
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "CONFLICT" ]] || false
+
+    run dolt sql -q "SELECT j FROM docs WHERE pk = 1" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '{"a":{"x":[1,2,3],"y":true},"b":3}' ]] || false
+}
+
+@test "json: changes to the same key of a document conflict" {
+    dolt add docs
+    dolt commit -m "added docs"
+    dolt checkout -b other
+    dolt sql -q "UPDATE docs SET j = '{\"b\": 3}' WHERE pk = 1"
+    dolt commit -am "b is 3"
+    dolt checkout master
+    dolt sql -q "UPDATE docs SET j = '{\"b\": 4}' WHERE pk = 1"
+    dolt commit -am "b is 4"
+
+    run dolt merge other
+    [[ "$output" =~ "CONFLICT" ]] || false
+
+    run dolt sql -q "SELECT our_j, their_j, conflict_columns FROM dolt_conflicts_docs" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '{"b":4}' ]] || false
+    [[ "$output" =~ '{"b":3}' ]] || false
+}
+
+@test "json: import and export documents" {
+    cat <<JSON > docs.json
+{"rows": [{"pk": 10, "j": {"name": "x", "tags": ["a", "b"]}}, {"pk": 11, "j": "plain"}]}
+JSON
+    run dolt table import -u docs docs.json
+    [ "$status" -eq 0 ]
+
+    run dolt sql -q "SELECT j FROM docs WHERE pk = 10" -r csv
+    [[ "$output" =~ '{"name":"x","tags":["a","b"]}' ]] || false
+
+    run dolt table export docs export.json
+    [ "$status" -eq 0 ]
+    run cat export.json
+    [[ "$output" =~ '"j":{"name":"x","tags":["a","b"]}' ]] || false
+    [[ "$output" =~ '"j":"plain"' ]] || false
+}
@@ -0,0 +1,213 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql <<SQL
+CREATE TABLE test (
+  pk INT NOT NULL,
+  a INT,
+  b VARCHAR(10),
+  c INT,
+  PRIMARY KEY (pk)
+);
+INSERT INTO test VALUES (1, 1, 'x', 1), (2, 2, 'y', 2);
+SQL
+    dolt add .
+    dolt commit -m "created table"
+    dolt branch other
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "merge-schema-changes: columns and indexes changed on both branches are merged" {
+    dolt sql <<SQL
+ALTER TABLE test MODIFY a BIGINT;
+ALTER TABLE test ADD COLUMN d INT DEFAULT 7;
+CREATE INDEX b_idx ON test(b);
+INSERT INTO test (pk, a, b, c) VALUES (3, 3, 'z', 3);
+SQL
+    dolt commit -am "changes on master"
+
+    dolt checkout other
+    dolt sql <<SQL
+ALTER TABLE test MODIFY b VARCHAR(100);
+ALTER TABLE test RENAME COLUMN c TO cc;
+ALTER TABLE test ADD COLUMN e INT NOT NULL DEFAULT 5;
+CREATE INDEX cc_idx ON test(cc);
+UPDATE test SET a = 20 WHERE pk = 2;
+SQL
+    dolt commit -am "changes on other"
+
+    dolt checkout master
+    run dolt merge other
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "CONFLICT" ]] || false
+
+    run dolt schema show test
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "\`a\` bigint" ]] || false
+    [[ "$output" =~ "\`b\` varchar(100)" ]] || false
+    [[ "$output" =~ "\`cc\` int" ]] || false
+    [[ ! "$output" =~ "\`c\` int" ]] || false
+    [[ "$output" =~ "\`d\` int DEFAULT 7" ]] || false
+    [[ "$output" =~ "\`e\` int NOT NULL DEFAULT 5" ]] || false
+    [[ "$output" =~ "KEY \`b_idx\` (\`b\`)" ]] || false
+    [[ "$output" =~ "KEY \`cc_idx\` (\`cc\`)" ]] || false
+
+    run dolt sql -q "SELECT * FROM test ORDER BY pk" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1,1,x,1,7,5" ]] || false
+    [[ "$output" =~ "2,20,y,2,7,5" ]] || false
+    [[ "$output" =~ "3,3,z,3,7,5" ]] || false
+
+    # the index added on the other branch covers the rows added on master
+    run dolt sql -q "SELECT pk FROM test WHERE cc = 3" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "3" ]] || false
+
+    dolt commit -m "merged"
+}
+
+@test "merge-schema-changes: a column widened on both branches takes the wider type" {
+    dolt sql -q "ALTER TABLE test MODIFY b VARCHAR(50)"
+    dolt commit -am "widen on master"
+
+    dolt checkout other
+    dolt sql -q "ALTER TABLE test MODIFY b VARCHAR(20)"
+    dolt commit -am "widen on other"
+
+    dolt checkout master
+    run dolt merge other
+    [ "$status" -eq 0 ]
+
+    run dolt schema show test
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "\`b\` varchar(50)" ]] || false
+}
+
+@test "merge-schema-changes: narrowing a column type is not supported" {
+    run dolt sql -q "ALTER TABLE test MODIFY a TINYINT"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "unsupported feature: column types cannot be changed" ]] || false
+}
+
+@test "merge-schema-changes: schema conflicts are listed in dolt_schema_conflicts" {
+    run dolt sql -q "SELECT * FROM dolt_schema_conflicts" -r csv
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 1 ]
+
+    dolt sql -q "ALTER TABLE test RENAME COLUMN c TO c1"
+    dolt sql -q "INSERT INTO test VALUES (3, 3, 'z', 3)"
+    dolt commit -am "rename on master"
+
+    dolt checkout other
+    dolt sql -q "ALTER TABLE test RENAME COLUMN c TO c2"
+    dolt sql -q "INSERT INTO test VALUES (4, 4, 'w', 4)"
+    dolt commit -am "rename on other"
+
+    dolt checkout master
+    run dolt merge other
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "CONFLICT (schema): Merge conflict in test" ]] || false
+
+    run dolt sql -q "SELECT table_name, description FROM dolt_schema_conflicts" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "test,different column definitions for our column c1 and their column c2" ]] || false
+
+    run dolt sql -q "SELECT their_schema FROM dolt_schema_conflicts" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "\`c2\` INT" ]] || false
+
+    # the table keeps our schema and rows
+    run dolt sql -q "SELECT * FROM test ORDER BY pk" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "pk,a,b,c1" ]] || false
+    [[ "$output" =~ "3,3,z,3" ]] || false
+    [[ ! "$output" =~ "4,4,w,4" ]] || false
+
+    run dolt status
+    [[ "$output" =~ "both modified:  test" ]] || false
+
+    run dolt commit -am "merged"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "unresolved conflicts" ]] || false
+
+    dolt sql -q "DELETE FROM dolt_schema_conflicts WHERE table_name = 'test'"
+    run dolt sql -q "SELECT * FROM dolt_schema_conflicts" -r csv
+    [ "${#lines[@]}" -eq 1 ]
+
+    dolt commit -am "merged"
+}
+
+@test "merge-schema-changes: schema conflicts can be resolved with their table" {
+    dolt sql -q "ALTER TABLE test RENAME COLUMN c TO c1"
+    dolt sql -q "INSERT INTO test VALUES (3, 3, 'z', 3)"
+    dolt commit -am "rename on master"
+
+    dolt checkout other
+    dolt sql -q "ALTER TABLE test RENAME COLUMN c TO c2"
+    dolt sql -q "INSERT INTO test VALUES (4, 4, 'w', 4)"
+    dolt commit -am "rename on other"
+
+    dolt checkout master
+    dolt merge other
+
+    dolt conflicts resolve --theirs test
+    run dolt sql -q "SELECT * FROM dolt_schema_conflicts" -r csv
+    [ "${#lines[@]}" -eq 1 ]
+
+    run dolt sql -q "SELECT * FROM test ORDER BY pk" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "pk,a,b,c2" ]] || false
+    [[ "$output" =~ "4,4,w,4" ]] || false
+    [[ ! "$output" =~ "3,3,z,3" ]] || false
+
+    dolt commit -am "merged"
+}
+
+@test "merge-schema-changes: DOLT_RESOLVE_SCHEMA_CONFLICTS picks a side" {
+    dolt sql -q "ALTER TABLE test RENAME COLUMN c TO c1"
+    dolt commit -am "rename on master"
+
+    dolt checkout other
+    dolt sql -q "ALTER TABLE test RENAME COLUMN c TO c2"
+    dolt commit -am "rename on other"
+
+    dolt checkout master
+    dolt merge other
+
+    run dolt sql -q "SELECT DOLT_RESOLVE_SCHEMA_CONFLICTS('--mine', 'test')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "expected --ours or --theirs" ]] || false
+
+    dolt sql -q "SELECT DOLT_RESOLVE_SCHEMA_CONFLICTS('--theirs', 'test')"
+    run dolt sql -q "SELECT * FROM dolt_schema_conflicts" -r csv
+    [ "${#lines[@]}" -eq 1 ]
+
+    run dolt sql -q "SELECT * FROM test ORDER BY pk" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "pk,a,b,c2" ]] || false
+}
+
+@test "merge-schema-changes: dolt merge --abort clears schema conflicts" {
+    dolt sql -q "ALTER TABLE test RENAME COLUMN c TO c1"
+    dolt commit -am "rename on master"
+
+    dolt checkout other
+    dolt sql -q "ALTER TABLE test RENAME COLUMN c TO c2"
+    dolt commit -am "rename on other"
+
+    dolt checkout master
+    dolt merge other
+
+    dolt merge --abort
+    run dolt sql -q "SELECT * FROM dolt_schema_conflicts" -r csv
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 1 ]
+
+    run dolt status
+    [[ "$output" =~ "nothing to commit" ]] || false
+}
@@ -0,0 +1,165 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql <<SQL
+CREATE TABLE test (
+  pk BIGINT NOT NULL,
+  cnt BIGINT,
+  name VARCHAR(20),
+  score DOUBLE,
+  note VARCHAR(20),
+  PRIMARY KEY (pk)
+);
+INSERT INTO test VALUES (1, 10, 'a', 1.5, 'x'), (2, 0, 'b', 0, 'y');
+SQL
+    dolt add .
+    dolt commit -m "created table"
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "merge-strategies: dolt_merge_strategies is empty until a strategy is declared" {
+    run dolt sql -q "SELECT * FROM dolt_merge_strategies" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "table_name,column_name,strategy,expression" ]] || false
+    [ "${#lines[@]}" -eq 1 ]
+
+    run dolt ls --system
+    [[ ! "$output" =~ "dolt_merge_strategies" ]] || false
+
+    dolt sql -q "INSERT INTO dolt_merge_strategies VALUES ('test', 'cnt', 'sum', NULL)"
+    run dolt sql -q "SELECT * FROM dolt_merge_strategies" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "test,cnt,sum," ]] || false
+
+    run dolt status
+    [[ "$output" =~ "dolt_merge_strategies" ]] || false
+}
+
+@test "merge-strategies: column strategies resolve conflicting cells" {
+    dolt sql <<SQL
+INSERT INTO dolt_merge_strategies VALUES
+  ('test', 'cnt', 'sum', NULL),
+  ('test', 'name', 'theirs', NULL),
+  ('test', 'score', 'max', NULL),
+  ('test', 'note', 'sql', 'CONCAT(:our, "+", :their)');
+SQL
+    dolt add .
+    dolt commit -m "declared merge strategies"
+
+    dolt checkout -b other
+    dolt sql -q "UPDATE test SET cnt = cnt + 5, name = 'other', score = 3, note = 'o'"
+    dolt add .
+    dolt commit -m "changed other"
+    dolt checkout master
+    dolt sql -q "UPDATE test SET cnt = cnt + 2, name = 'master', score = 2, note = 'm'"
+    dolt add .
+    dolt commit -m "changed master"
+
+    run dolt merge other
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Resolved 2 conflicts in test using merge strategies" ]] || false
+    [[ ! "$output" =~ "CONFLICT" ]] || false
+
+    run dolt sql -q "SELECT * FROM test ORDER BY pk" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1,17,other,3,m+o" ]] || false
+    [[ "$output" =~ "2,7,other,3,m+o" ]] || false
+}
+
+@test "merge-strategies: cells without a strategy remain in conflict" {
+    dolt sql -q "INSERT INTO dolt_merge_strategies VALUES ('test', 'cnt', 'sum', NULL)"
+    dolt add .
+    dolt commit -m "declared merge strategies"
+
+    dolt checkout -b other
+    dolt sql -q "UPDATE test SET cnt = cnt + 5, name = 'other' WHERE pk = 1"
+    dolt add .
+    dolt commit -m "changed other"
+    dolt checkout master
+    dolt sql -q "UPDATE test SET cnt = cnt + 2, name = 'master' WHERE pk = 1"
+    dolt add .
+    dolt commit -m "changed master"
+
+    run dolt merge other
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "CONFLICT" ]] || false
+
+    # the cells with a strategy are resolved in the working row
+    run dolt sql -q "SELECT cnt, name FROM test WHERE pk = 1" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "17,master" ]] || false
+
+    run dolt sql -q "SELECT our_pk, our_cnt, their_cnt FROM dolt_conflicts_test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1,12,15" ]] || false
+}
+
+@test "merge-strategies: table strategies resolve deleted rows" {
+    dolt sql -q "INSERT INTO dolt_merge_strategies VALUES ('test', '*', 'theirs', NULL)"
+    dolt add .
+    dolt commit -m "declared merge strategies"
+
+    dolt checkout -b other
+    dolt sql -q "DELETE FROM test WHERE pk = 1"
+    dolt add .
+    dolt commit -m "changed other"
+    dolt checkout master
+    dolt sql -q "UPDATE test SET name = 'master' WHERE pk = 1"
+    dolt add .
+    dolt commit -m "changed master"
+
+    run dolt merge other
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "CONFLICT" ]] || false
+
+    run dolt sql -q "SELECT count(*) FROM test WHERE pk = 1" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "0" ]] || false
+}
+
+@test "merge-strategies: latest strategy takes the most recent commit" {
+    dolt sql -q "INSERT INTO dolt_merge_strategies VALUES ('*', '*', 'latest', NULL)"
+    dolt add .
+    dolt commit -m "declared merge strategies"
+
+    dolt checkout -b other
+    dolt sql -q "UPDATE test SET name = 'older' WHERE pk = 1"
+    dolt add .
+    dolt commit -m "changed other"
+    dolt checkout master
+    dolt sql -q "UPDATE test SET name = 'newer' WHERE pk = 1"
+    dolt add .
+    dolt commit -m "changed master"
+
+    run dolt merge other
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "CONFLICT" ]] || false
+
+    run dolt sql -q "SELECT name FROM test WHERE pk = 1" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "newer" ]] || false
+}
+
+@test "merge-strategies: unknown strategies fail the merge" {
+    dolt sql -q "INSERT INTO dolt_merge_strategies VALUES ('test', 'name', 'loudest', NULL)"
+    dolt add .
+    dolt commit -m "declared merge strategies"
+
+    dolt checkout -b other
+    dolt sql -q "UPDATE test SET name = 'other' WHERE pk = 1"
+    dolt add .
+    dolt commit -m "changed other"
+    dolt checkout master
+    dolt sql -q "UPDATE test SET name = 'master' WHERE pk = 1"
+    dolt add .
+    dolt commit -m "changed master"
+
+    run dolt merge other
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "unknown merge strategy 'loudest'" ]] || false
+}
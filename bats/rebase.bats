@@ -0,0 +1,235 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql <<SQL
+CREATE TABLE test (
+  pk BIGINT NOT NULL,
+  c1 BIGINT,
+  PRIMARY KEY (pk)
+);
+SQL
+    dolt add .
+    dolt commit -m "added table"
+
+    dolt checkout -b feature
+    dolt sql -q "INSERT INTO test VALUES (1,1)"
+    dolt commit -am "add pk 1"
+    dolt sql -q "INSERT INTO test VALUES (2,2)"
+    dolt commit -am "add pk 2"
+
+    dolt checkout master
+    dolt sql -q "INSERT INTO test VALUES (10,10)"
+    dolt commit -am "add pk 10"
+    dolt checkout feature
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "rebase: replays branch commits onto upstream" {
+    run dolt rebase master
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Successfully rebased and updated refs/heads/feature" ]] || false
+
+    run dolt log
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "add pk 2".*"add pk 1".*"add pk 10".*"added table" ]] || false
+
+    run dolt sql -q "SELECT count(*) FROM test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "3" ]] || false
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+}
+
+@test "rebase: up to date branch" {
+    dolt rebase master
+    run dolt rebase master
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Current branch feature is up to date" ]] || false
+}
+
+@test "rebase: refuses to run with uncommitted changes" {
+    dolt sql -q "INSERT INTO test VALUES (3,3)"
+    run dolt rebase master
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "local changes would be overwritten" ]] || false
+}
+
+@test "rebase: --continue and --abort require a rebase in progress" {
+    run dolt rebase --continue
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "no rebase in progress" ]] || false
+
+    run dolt rebase --abort
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "no rebase in progress" ]] || false
+}
+
+@test "rebase: stops on conflicts and continues after they are resolved" {
+    dolt checkout master
+    dolt sql -q "INSERT INTO test VALUES (1,100)"
+    dolt commit -am "add conflicting pk 1"
+    dolt checkout feature
+
+    run dolt rebase master
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "CONFLICT" ]] || false
+    [[ "$output" =~ "dolt rebase --continue" ]] || false
+
+    run dolt rebase master
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "rebase is already in progress" ]] || false
+
+    run dolt rebase --continue
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "resolve all conflicts" ]] || false
+
+    dolt conflicts resolve --theirs test
+    dolt add test
+    run dolt rebase --continue
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Successfully rebased" ]] || false
+
+    run dolt sql -q "SELECT c1 FROM test WHERE pk = 1" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1" ]] || false
+    [[ ! "$output" =~ "100" ]] || false
+
+    run dolt log
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "add pk 2".*"add pk 1".*"add conflicting pk 1" ]] || false
+}
+
+@test "rebase: --abort restores the original branch" {
+    dolt checkout master
+    dolt sql -q "INSERT INTO test VALUES (1,100)"
+    dolt commit -am "add conflicting pk 1"
+    dolt checkout feature
+
+    run dolt rebase master
+    [ "$status" -eq 1 ]
+
+    run dolt rebase --abort
+    [ "$status" -eq 0 ]
+
+    run dolt log
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "add pk 10" ]] || false
+    [[ "$output" =~ "add pk 2" ]] || false
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+
+    run dolt sql -q "SELECT count(*) FROM test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+}
+
+commit_hash() {
+    dolt sql -q "SELECT commit_hash FROM dolt_log WHERE message = '$1'" -r csv | tail -n 1
+}
+
+@test "rebase: --plan squashes, drops, reorders and rewords commits" {
+    dolt sql -q "INSERT INTO test VALUES (3,3)"
+    dolt commit -am "add pk 3"
+    dolt sql -q "INSERT INTO test VALUES (4,4)"
+    dolt commit -am "add pk 4"
+
+    cat > plan.txt <<PLAN
+# clean up the feature branch
+pick $(commit_hash "add pk 1") add pk 1
+squash $(commit_hash "add pk 2")
+drop $(commit_hash "add pk 3")
+reword $(commit_hash "add pk 4") add pk four
+PLAN
+
+    run dolt rebase --plan plan.txt master
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Successfully rebased" ]] || false
+
+    run dolt sql -q "SELECT message FROM dolt_log" -r csv
+    [ "$status" -eq 0 ]
+    [[ "${lines[1]}" =~ "add pk four" ]] || false
+    [[ "${lines[2]}" =~ "add pk 1" ]] || false
+    [[ "$output" =~ "add pk 2" ]] || false
+    [[ ! "$output" =~ "add pk 3" ]] || false
+    [[ "$output" =~ "add pk 10" ]] || false
+
+    run dolt sql -q "SELECT pk FROM test ORDER BY pk" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "1" ]
+    [ "${lines[2]}" = "2" ]
+    [ "${lines[3]}" = "4" ]
+    [ "${lines[4]}" = "10" ]
+}
+
+@test "rebase: --plan fixup keeps the message of the previous commit" {
+    cat > plan.txt <<PLAN
+pick $(commit_hash "add pk 2")
+fixup $(commit_hash "add pk 1")
+PLAN
+
+    run dolt rebase --plan plan.txt HEAD~2
+    [ "$status" -eq 0 ]
+
+    run dolt sql -q "SELECT message FROM dolt_log" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "add pk 2" ]
+    [ "${lines[2]}" = "added table" ]
+
+    run dolt sql -q "SELECT count(*) FROM test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+}
+
+@test "rebase: --plan squash after a skipped pick does not amend upstream" {
+    dolt checkout master
+    dolt sql -q "INSERT INTO test VALUES (1,1)"
+    dolt commit -am "add pk 1 upstream"
+    dolt checkout feature
+
+    cat > plan.txt <<PLAN
+pick $(commit_hash "add pk 1")
+squash $(commit_hash "add pk 2")
+PLAN
+
+    run dolt rebase --plan plan.txt master
+    [ "$status" -eq 0 ]
+
+    run dolt sql -q "SELECT message FROM dolt_log" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "add pk 2" ]
+    [ "${lines[2]}" = "add pk 1 upstream" ]
+    [ "${lines[3]}" = "add pk 10" ]
+
+    run dolt sql -q "SELECT count(*) FROM test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "3" ]] || false
+}
+
+@test "rebase: invalid plans are rejected" {
+    echo "squash $(commit_hash "add pk 1")" > plan.txt
+    run dolt rebase --plan plan.txt master
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "without a previous commit" ]] || false
+
+    echo "edit $(commit_hash "add pk 1")" > plan.txt
+    run dolt rebase --plan plan.txt master
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "unknown rebase action" ]] || false
+
+    run dolt rebase --plan missing.txt master
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "failed to read the rebase plan" ]] || false
+
+    run dolt log
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "add pk 10" ]] || false
+}
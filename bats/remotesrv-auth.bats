@@ -0,0 +1,113 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+remotesrv_pid=
+setup() {
+    setup_common
+    writer_key=`dolt creds new | grep "pub key:" | cut -d' ' -f3`
+    reader_key=`dolt creds new | grep "pub key:" | cut -d' ' -f3`
+
+    cd $BATS_TMPDIR
+    mkdir remotes-$$
+    cat > remotes-$$/auth.yaml <<EOF
+users:
+  - name: writer
+    email: writer@email.fake
+    public_keys: [$writer_key]
+  - name: reader
+    public_keys: [$reader_key]
+repos:
+  - name: test-org/*
+    read: [reader]
+    write: [writer]
+  - name: test-org/public
+    read: ["*"]
+    write: [writer]
+EOF
+    echo remotesrv log available here $BATS_TMPDIR/remotes-$$/remotesrv.log
+    remotesrv --http-port 1234 --dir ./remotes-$$ --auth-config ./remotes-$$/auth.yaml &> ./remotes-$$/remotesrv.log 3>&- &
+    remotesrv_pid=$!
+    cd dolt-repo-$$
+
+    dolt sql -q "CREATE TABLE test (pk BIGINT PRIMARY KEY)"
+    dolt add test
+    dolt commit -m "created table"
+    dolt remote add origin http://localhost:50051/test-org/test-repo
+    dolt remote add public http://localhost:50051/test-org/public
+    dolt creds use $writer_key
+}
+
+teardown() {
+    teardown_common
+    kill $remotesrv_pid
+    rm -rf $BATS_TMPDIR/remotes-$$
+}
+
+@test "remotesrv-auth: WhoAmI returns the user of the credentials" {
+    run dolt creds check --insecure --endpoint localhost:50051
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "User: writer" ]] || false
+    [[ "$output" =~ "Email: writer@email.fake" ]] || false
+}
+
+@test "remotesrv-auth: writers create repos on first push" {
+    run dolt push origin master
+    [ "$status" -eq 0 ]
+    [ -d "$BATS_TMPDIR/remotes-$$/test-org/test-repo" ]
+
+    cd dolt-repo-clones
+    run dolt clone http://localhost:50051/test-org/missing
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "not found" ]] || false
+    [ ! -d "$BATS_TMPDIR/remotes-$$/test-org/missing" ]
+}
+
+@test "remotesrv-auth: readers can clone but not push" {
+    dolt push origin master
+    dolt creds use $reader_key
+
+    run dolt push origin master
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "PermissionDenied" ]] || false
+
+    cd dolt-repo-clones
+    dolt clone http://localhost:50051/test-org/test-repo
+    cd test-repo
+    run dolt ls
+    [[ "$output" =~ "test" ]] || false
+
+    dolt sql -q "INSERT INTO test VALUES (1)"
+    dolt add test
+    dolt commit -m "reader change"
+    run dolt push origin master
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "user 'reader' can not write test-org/test-repo" ]] || false
+}
+
+@test "remotesrv-auth: clients without credentials only read public repos" {
+    dolt push origin master
+    dolt push public master
+    dolt config --global --unset user.creds
+
+    cd dolt-repo-clones
+    run dolt clone http://localhost:50051/test-org/test-repo
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "credentials are required" ]] || false
+
+    dolt clone http://localhost:50051/test-org/public
+    cd public
+    dolt sql -q "INSERT INTO test VALUES (1)"
+    dolt add test
+    dolt commit -m "anonymous change"
+    run dolt push origin master
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "credentials are required" ]] || false
+}
+
+@test "remotesrv-auth: unknown credentials are rejected" {
+    unknown_key=`dolt creds new | grep "pub key:" | cut -d' ' -f3`
+    dolt creds use $unknown_key
+    run dolt push origin master
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "unknown credentials" ]] || false
+}
@@ -0,0 +1,100 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql <<SQL
+CREATE TABLE test (
+  pk BIGINT NOT NULL,
+  c1 BIGINT,
+  PRIMARY KEY (pk)
+);
+SQL
+    dolt add .
+    dolt commit -m "added table"
+
+    dolt sql -q "INSERT INTO test VALUES (1,1)"
+    dolt commit -am "add pk 1"
+    dolt sql -q "INSERT INTO test VALUES (2,2)"
+    dolt commit -am "add pk 2"
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "revert: reverts the head commit" {
+    run dolt revert HEAD
+    [ "$status" -eq 0 ]
+
+    run dolt sql -q "SELECT pk FROM test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1" ]] || false
+    [[ ! "$output" =~ "2" ]] || false
+
+    run dolt log -n 1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ 'Revert "add pk 2"' ]] || false
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+}
+
+@test "revert: reverts multiple commits" {
+    run dolt revert HEAD HEAD~1
+    [ "$status" -eq 0 ]
+
+    run dolt sql -q "SELECT count(*) FROM test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "0" ]] || false
+
+    run dolt log -n 2
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ 'Revert "add pk 2"' ]] || false
+    [[ "$output" =~ 'Revert "add pk 1"' ]] || false
+}
+
+@test "revert: reverting the creation of a table drops it" {
+    run dolt revert HEAD~2
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "can't be merged" ]] || false
+
+    dolt revert HEAD HEAD~1 HEAD~2
+    run dolt ls
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "test" ]] || false
+}
+
+@test "revert: --no-commit stages the changes" {
+    run dolt revert --no-commit HEAD
+    [ "$status" -eq 0 ]
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Changes to be committed" ]] || false
+
+    run dolt log -n 1
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "Revert" ]] || false
+}
+
+@test "revert: refuses to run with uncommitted changes" {
+    dolt sql -q "INSERT INTO test VALUES (3,3)"
+    run dolt revert HEAD
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "local changes would be overwritten" ]] || false
+}
+
+@test "revert: conflicts are left in the working set" {
+    dolt sql -q "UPDATE test SET c1 = 20 WHERE pk = 2"
+    dolt commit -am "update pk 2"
+
+    run dolt revert HEAD~1
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "CONFLICT" ]] || false
+
+    run dolt sql -q "SELECT count(*) FROM dolt_conflicts_test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1" ]] || false
+}
@@ -0,0 +1,138 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    cd $BATS_TMPDIR
+    cd dolt-repo-$$
+    mkdir "dolt-repo-clones"
+    mkdir remote
+    dolt remote add origin file://remote/
+
+    dolt sql -q "CREATE TABLE test (pk BIGINT PRIMARY KEY, c1 BIGINT)"
+    dolt add test
+    dolt commit -m "created table"
+    for i in 1 2 3 4; do
+        dolt sql -q "INSERT INTO test VALUES ($i, $i)"
+        dolt add test
+        dolt commit -m "inserted $i"
+    done
+    dolt branch other
+    dolt push origin master
+    dolt push origin other
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "shallow-clone: clone with depth only fetches the latest commits" {
+    cd dolt-repo-clones
+    run dolt clone --depth 2 file://../remote repo
+    [ "$status" -eq 0 ]
+    cd repo
+
+    run dolt sql -q "SELECT COUNT(*) FROM test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "4" ]] || false
+
+    run dolt log -n 2
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "inserted 4" ]] || false
+    [[ "$output" =~ "inserted 3" ]] || false
+
+    run dolt log
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "inserted 3" ]] || false
+    [[ ! "$output" =~ "inserted 2" ]] || false
+    [[ "$output" =~ "beyond the shallow boundary" ]] || false
+
+    run dolt branch -a
+    [[ ! "$output" =~ "other" ]] || false
+}
+
+@test "shallow-clone: dolt_history tables error at the shallow boundary" {
+    cd dolt-repo-clones
+    dolt clone --depth 1 file://../remote repo
+    cd repo
+
+    run dolt sql -q "SELECT * FROM dolt_history_test"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "dolt fetch --unshallow" ]] || false
+
+    run dolt sql -q "SELECT * FROM dolt_log"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "dolt fetch --unshallow" ]] || false
+}
+
+@test "shallow-clone: fetch --unshallow fetches the rest of the history" {
+    dolt sql -q "INSERT INTO test VALUES (5, 5)"
+    dolt add test
+    dolt commit -m "inserted 5"
+    dolt push origin master
+
+    cd dolt-repo-clones
+    dolt clone --depth 1 file://../remote repo
+    cd repo
+
+    run dolt fetch --unshallow
+    [ "$status" -eq 0 ]
+
+    run dolt log
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "created table" ]] || false
+
+    run dolt sql -q "SELECT COUNT(*) FROM dolt_history_test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "15" ]] || false
+
+    run dolt fetch --unshallow
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "complete repository" ]] || false
+}
+
+@test "shallow-clone: pull into a shallow clone" {
+    cd dolt-repo-clones
+    dolt clone --depth 1 file://../remote repo
+
+    cd ../
+    dolt sql -q "INSERT INTO test VALUES (5, 5)"
+    dolt add test
+    dolt commit -m "inserted 5"
+    dolt push origin master
+
+    cd dolt-repo-clones/repo
+    dolt pull
+    run dolt log -n 2
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "inserted 5" ]] || false
+    [[ "$output" =~ "inserted 4" ]] || false
+}
+
+@test "shallow-clone: single branch clone only tracks one branch" {
+    cd dolt-repo-clones
+    dolt clone --single-branch -b other file://../remote repo
+    cd repo
+
+    run dolt branch -a
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "* other" ]] || false
+    [[ "$output" =~ "remotes/origin/other" ]] || false
+    [[ ! "$output" =~ "master" ]] || false
+
+    run dolt log
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "created table" ]] || false
+}
+
+@test "shallow-clone: invalid depth and missing branch" {
+    cd dolt-repo-clones
+    run dolt clone --depth 0 file://../remote repo
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "depth must be a positive number" ]] || false
+
+    run dolt clone --depth 1 -b missing file://../remote repo
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "remote branch 'missing' not found" ]] || false
+    [ ! -d repo ]
+}
@@ -0,0 +1,124 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    cd $BATS_TMPDIR
+    cd dolt-repo-$$
+    mkdir "dolt-repo-clones"
+    mkdir remote
+    dolt remote add origin file://remote/
+
+    dolt sql -q "CREATE TABLE a (pk BIGINT PRIMARY KEY, c1 BIGINT)"
+    dolt sql -q "CREATE TABLE b (pk BIGINT PRIMARY KEY, c1 BIGINT)"
+    dolt sql -q "CREATE TABLE c (pk BIGINT PRIMARY KEY, c1 BIGINT)"
+    dolt add .
+    dolt commit -m "created tables"
+    dolt sql -q "INSERT INTO a VALUES (1, 1), (2, 2)"
+    dolt sql -q "INSERT INTO b VALUES (1, 1), (2, 2), (3, 3)"
+    dolt sql -q "INSERT INTO c VALUES (1, 1)"
+    dolt add .
+    dolt commit -m "inserted rows"
+    dolt branch other
+    dolt push origin master
+    dolt push origin other
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "sparse-clone: clone with tables reads the other tables from the remote" {
+    cd dolt-repo-clones
+    run dolt clone --tables a file://../remote repo
+    [ "$status" -eq 0 ]
+    cd repo
+
+    run dolt sql -q "SELECT COUNT(*) FROM a" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+
+    run dolt sql -q "SELECT COUNT(*) FROM b" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "3" ]] || false
+
+    run dolt branch -a
+    [[ ! "$output" =~ "other" ]] || false
+
+    run dolt log
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "created tables" ]] || false
+}
+
+@test "sparse-clone: only the listed tables are readable without the remote" {
+    cd dolt-repo-clones
+    dolt clone --tables a file://../remote repo
+    mv ../remote ../remote-moved
+    cd repo
+
+    run dolt sql -q "SELECT COUNT(*) FROM a" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+
+    run dolt sql -q "SELECT COUNT(*) FROM b" -r csv
+    [ "$status" -eq 1 ]
+}
+
+@test "sparse-clone: commit and pull in a sparse clone" {
+    cd dolt-repo-clones
+    dolt clone --tables a file://../remote repo
+    cd repo
+    dolt sql -q "INSERT INTO c VALUES (2, 2)"
+    dolt add c
+    dolt commit -m "inserted into c"
+
+    cd ../../
+    dolt sql -q "INSERT INTO a VALUES (3, 3)"
+    dolt sql -q "INSERT INTO b VALUES (4, 4)"
+    dolt add .
+    dolt commit -m "inserted more rows"
+    dolt push origin master
+
+    cd dolt-repo-clones/repo
+    run dolt pull
+    [ "$status" -eq 0 ]
+
+    run dolt sql -q "SELECT COUNT(*) FROM a" -r csv
+    [[ "$output" =~ "3" ]] || false
+    run dolt sql -q "SELECT COUNT(*) FROM b" -r csv
+    [[ "$output" =~ "4" ]] || false
+    run dolt sql -q "SELECT COUNT(*) FROM c" -r csv
+    [[ "$output" =~ "2" ]] || false
+}
+
+@test "sparse-clone: fetch with tables fetches the data of more tables" {
+    cd dolt-repo-clones
+    dolt clone --tables a file://../remote repo
+    cd repo
+
+    run dolt fetch --tables b
+    [ "$status" -eq 0 ]
+    run cat .dolt/repo_state.json
+    [[ "$output" =~ '"b"' ]] || false
+
+    mv ../../remote ../../remote-moved
+    run dolt sql -q "SELECT COUNT(*) FROM b" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "3" ]] || false
+}
+
+@test "sparse-clone: clone and fetch with missing tables" {
+    cd dolt-repo-clones
+    run dolt clone --tables a,missing file://../remote repo
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "table 'missing' not found" ]] || false
+    [ ! -d repo ]
+
+    dolt clone --tables a file://../remote repo
+    cd repo
+    run dolt fetch --tables missing
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "table 'missing' not found" ]] || false
+    run cat .dolt/repo_state.json
+    [[ ! "$output" =~ "missing" ]] || false
+}
@@ -216,4 +216,89 @@ teardown() {
   run dolt sql -r csv -q "SELECT * FROM dolt_conflicts"
   [ "$status" -eq 0 ]
   [[ "$output" =~ "$EXPECTED" ]] || false
-}
\ No newline at end of file
+}
+@test "conflicts are recorded per cell" {
+  dolt SQL -q "INSERT INTO one_pk (pk1,c1,c2) VALUES (0,0,0),(1,0,0)"
+  dolt add .
+  dolt commit -m "initial values"
+  dolt branch feature_branch master
+  dolt SQL -q "UPDATE one_pk SET c1=1 WHERE pk1=0"
+  dolt SQL -q "UPDATE one_pk SET c1=1 WHERE pk1=1"
+  dolt add .
+  dolt commit -m "changed master"
+  dolt checkout feature_branch
+  dolt SQL -q "UPDATE one_pk SET c1=2,c2=2 WHERE pk1=0"
+  dolt SQL -q "UPDATE one_pk SET c2=2 WHERE pk1=1"
+  dolt add .
+  dolt commit -m "changed feature_branch"
+  dolt checkout master
+  dolt merge feature_branch
+
+  run dolt sql -r csv -q "SELECT our_pk1,base_c1,our_c1,their_c1,conflict_columns FROM dolt_conflicts_one_pk"
+  [ "$status" -eq 0 ]
+  [[ "$output" =~ "0,0,1,2,c1" ]] || false
+  [ "${#lines[@]}" -eq 2 ]
+
+  # our side of the conflict is the row in the table
+  run dolt sql -r csv -q "SELECT our_c1,our_c2 FROM dolt_conflicts_one_pk"
+  [ "$status" -eq 0 ]
+  [[ "$output" =~ "1,2" ]] || false
+
+  # the cells which are not in conflict are merged
+  run dolt sql -r csv -q "SELECT * FROM one_pk ORDER BY pk1"
+  [ "$status" -eq 0 ]
+  [[ "$output" =~ "0,1,2" ]] || false
+  [[ "$output" =~ "1,1,2" ]] || false
+
+  dolt conflicts resolve --ours one_pk
+  run dolt sql -r csv -q "SELECT * FROM one_pk WHERE pk1=0"
+  [ "$status" -eq 0 ]
+  [[ "$output" =~ "0,1,2" ]] || false
+}
+
+@test "resolving with theirs only takes their conflicting cells" {
+  dolt SQL -q "INSERT INTO one_pk (pk1,c1,c2) VALUES (0,0,0)"
+  dolt add .
+  dolt commit -m "initial values"
+  dolt branch feature_branch master
+  dolt SQL -q "UPDATE one_pk SET c1=1,c2=1 WHERE pk1=0"
+  dolt add .
+  dolt commit -m "changed master"
+  dolt checkout feature_branch
+  dolt SQL -q "UPDATE one_pk SET c1=2 WHERE pk1=0"
+  dolt add .
+  dolt commit -m "changed feature_branch"
+  dolt checkout master
+  dolt merge feature_branch
+
+  # c1 is in conflict, our change to c2 is kept
+  dolt conflicts resolve --theirs one_pk
+  run dolt sql -r csv -q "SELECT pk1,c1,c2 FROM one_pk WHERE pk1=0"
+  [ "$status" -eq 0 ]
+  [[ "$output" =~ "0,2,1" ]] || false
+}
+
+@test "cell level merge with a column added on one side" {
+  dolt SQL -q "INSERT INTO one_pk (pk1,c1,c2) VALUES (0,0,0)"
+  dolt add .
+  dolt commit -m "initial values"
+  dolt branch feature_branch master
+  dolt SQL -q "ALTER TABLE one_pk ADD COLUMN c3 BIGINT"
+  dolt SQL -q "UPDATE one_pk SET c1=1,c3=1 WHERE pk1=0"
+  dolt add .
+  dolt commit -m "changed master"
+  dolt checkout feature_branch
+  dolt SQL -q "UPDATE one_pk SET c1=2,c2=2 WHERE pk1=0"
+  dolt add .
+  dolt commit -m "changed feature_branch"
+  dolt checkout master
+  dolt merge feature_branch
+
+  run dolt sql -r csv -q "SELECT our_pk1,our_c3,conflict_columns FROM dolt_conflicts_one_pk"
+  [ "$status" -eq 0 ]
+  [[ "$output" =~ "0,1,c1" ]] || false
+
+  run dolt sql -r csv -q "SELECT pk1,c1,c2,c3 FROM one_pk"
+  [ "$status" -eq 0 ]
+  [[ "$output" =~ "0,1,2,1" ]] || false
+}
@@ -438,4 +438,92 @@ SQL
 
     server_query 1 "SELECT * FROM repo1.r1_one_pk" "pk,c1,c2\n1,1,1\n2,2,2\n3,3,3"
     server_query 1 "SELECT * FROM repo2.r2_one_pk" "pk,c3,c4\n1,1,1\n2,2,2\n3,3,3"
-}
\ No newline at end of file
+}
+
+@test "test USE db/branch selects a branch per connection" {
+    skiponwindows "Has dependencies that are missing on the Jenkins Windows installation."
+
+    cd repo1
+    dolt sql -q "CREATE TABLE one_pk (
+        pk BIGINT NOT NULL COMMENT 'tag:0',
+        c1 BIGINT COMMENT 'tag:1',
+        PRIMARY KEY (pk)
+    )"
+    dolt sql -q "INSERT INTO one_pk (pk,c1) VALUES (0,0),(1,1)"
+    dolt add .
+    dolt commit -m "created one_pk"
+    dolt branch feature
+
+    start_sql_server repo1
+
+    # write to the feature branch without checking it out
+    multi_query 1 "
+    USE \`repo1/feature\`;
+    INSERT INTO one_pk (pk,c1) VALUES (2,2);
+    DELETE FROM one_pk WHERE pk = 0;
+    SELECT DOLT_COMMIT('-a', '-m', 'changed feature')"
+
+    # commits made on a branch database move that branch, and new connections to it start at its head
+    server_query 1 "USE \`repo1/feature\`;SELECT * FROM one_pk ORDER BY pk" ";pk,c1\n1,1\n2,2"
+    server_query 1 "SELECT * FROM one_pk ORDER BY pk" "pk,c1\n0,0\n1,1"
+    server_query 1 "USE \`repo1/master\`;SELECT * FROM one_pk ORDER BY pk" ";pk,c1\n0,0\n1,1"
+    server_query 1 "SELECT * FROM one_pk AS OF 'feature' ORDER BY pk" "pk,c1\n1,1\n2,2"
+    server_query 1 "SELECT latest_commit_message FROM dolt_branches WHERE name = 'feature'" "latest_commit_message\nchanged feature"
+}
+
+@test "test transactions with autocommit on" {
+    skiponwindows "Has dependencies that are missing on the Jenkins Windows installation."
+
+    cd repo1
+    dolt sql -q "CREATE TABLE one_pk (
+        pk BIGINT NOT NULL COMMENT 'tag:0',
+        c1 BIGINT COMMENT 'tag:1',
+        PRIMARY KEY (pk)
+    )"
+    start_sql_server repo1
+
+    # statements in a transaction are not committed until COMMIT
+    multi_query 1 "
+    BEGIN;
+    INSERT INTO one_pk (pk,c1) VALUES (0,0);
+    INSERT INTO one_pk (pk,c1) VALUES (1,1);
+    ROLLBACK;"
+    server_query 1 "SELECT COUNT(*) FROM one_pk" "COUNT(*)\n0"
+
+    multi_query 1 "
+    BEGIN;
+    INSERT INTO one_pk (pk,c1) VALUES (0,0);
+    INSERT INTO one_pk (pk,c1) VALUES (1,1);
+    COMMIT;"
+    server_query 1 "SELECT * FROM one_pk ORDER BY pk" "pk,c1\n0,0\n1,1"
+
+    run dolt sql -q "SELECT COUNT(*) FROM one_pk" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+}
+
+@test "test TLS with require_secure_transport" {
+    skiponwindows "Has dependencies that are missing on the Jenkins Windows installation."
+
+    cd repo1
+    openssl req -x509 -newkey rsa:2048 -nodes -days 1 -subj "/CN=localhost" -keyout key.pem -out cert.pem
+
+    let PORT="$$ % (65536-1024) + 1024"
+    echo "
+user:
+  name: dolt
+
+listener:
+  host: 0.0.0.0
+  port: $PORT
+  tls_key: key.pem
+  tls_cert: cert.pem
+  require_secure_transport: true
+" > tls-config.yaml
+    DEFAULT_DB=repo1
+    dolt sql-server --config tls-config.yaml &
+    SERVER_PID=$!
+    wait_for_connection $PORT 5000
+
+    server_query 1 "SELECT 1 AS one" "one\n1"
+}
@@ -0,0 +1,156 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql <<SQL
+CREATE TABLE test (
+  pk BIGINT NOT NULL,
+  c1 BIGINT,
+  PRIMARY KEY (pk)
+);
+SQL
+    dolt add .
+    dolt commit -m "added table"
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "stash: stash with no local changes" {
+    run dolt stash
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "No local changes to save" ]] || false
+
+    run dolt stash list
+    [ "$status" -eq 0 ]
+    [ "$output" = "" ]
+}
+
+@test "stash: stash saves working and staged changes and cleans the working set" {
+    dolt sql -q "INSERT INTO test VALUES (1,1)"
+    dolt add test
+    dolt sql -q "INSERT INTO test VALUES (2,2)"
+    dolt sql -q "CREATE TABLE t2 (pk BIGINT PRIMARY KEY)"
+
+    run dolt stash
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Saved working directory and index state WIP on master" ]] || false
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+
+    run dolt stash list
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "stash@{0}: WIP on master" ]] || false
+    [[ "$output" =~ "added table" ]] || false
+}
+
+@test "stash: pop restores working and staged changes" {
+    dolt sql -q "INSERT INTO test VALUES (1,1)"
+    dolt add test
+    dolt sql -q "CREATE TABLE t2 (pk BIGINT PRIMARY KEY)"
+    dolt stash
+
+    run dolt stash pop
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Dropped stash@{0}" ]] || false
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Changes to be committed" ]] || false
+    [[ "$output" =~ "new table:      t2" ]] || false
+
+    run dolt sql -q "SELECT pk FROM test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1" ]] || false
+
+    run dolt stash list
+    [ "$status" -eq 0 ]
+    [ "$output" = "" ]
+}
+
+@test "stash: apply keeps the stash and drop removes it" {
+    dolt sql -q "INSERT INTO test VALUES (1,1)"
+    dolt stash -m "first"
+    dolt sql -q "INSERT INTO test VALUES (2,2)"
+    dolt stash -m "second"
+
+    run dolt stash list
+    [ "$status" -eq 0 ]
+    [ "${lines[0]}" = "stash@{0}: On master: second" ]
+    [ "${lines[1]}" = "stash@{1}: On master: first" ]
+
+    run dolt stash apply stash@{1}
+    [ "$status" -eq 0 ]
+
+    run dolt sql -q "SELECT pk FROM test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1" ]] || false
+    [[ ! "$output" =~ "2" ]] || false
+
+    run dolt stash list
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+
+    run dolt stash drop
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Dropped stash@{0}" ]] || false
+
+    run dolt stash list
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 1 ]
+    [[ "$output" =~ "first" ]] || false
+}
+
+@test "stash: stash can be applied onto another branch" {
+    dolt sql -q "INSERT INTO test VALUES (1,1)"
+    dolt stash
+
+    dolt checkout -b other
+    dolt sql -q "INSERT INTO test VALUES (5,5)"
+    dolt commit -am "add pk 5"
+
+    run dolt stash pop
+    [ "$status" -eq 0 ]
+
+    run dolt sql -q "SELECT pk FROM test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1" ]] || false
+    [[ "$output" =~ "5" ]] || false
+}
+
+@test "stash: conflicts keep the stash when popping" {
+    dolt sql -q "INSERT INTO test VALUES (1,1)"
+    dolt stash
+    dolt sql -q "INSERT INTO test VALUES (1,2)"
+    dolt commit -am "add conflicting pk 1"
+
+    run dolt stash pop
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "CONFLICT" ]] || false
+    [[ "$output" =~ "stash entry is kept" ]] || false
+
+    run dolt stash list
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "stash@{0}" ]] || false
+}
+
+@test "stash: invalid stash references" {
+    run dolt stash pop
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "No stash entries found" ]] || false
+
+    dolt sql -q "INSERT INTO test VALUES (1,1)"
+    dolt stash
+
+    run dolt stash drop stash@{1}
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "does not exist" ]] || false
+
+    run dolt stash drop foo
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "not a valid stash reference" ]] || false
+}
@@ -23,6 +23,7 @@ teardown() {
     run dolt ls --all
     [ $status -eq 0 ]
     [[ "$output" =~ "dolt_log" ]] || false
+    [[ "$output" =~ "dolt_diff" ]] || false
     [[ "$output" =~ "dolt_commits" ]] || false
     [[ "$output" =~ "dolt_commit_ancestors" ]] || false
     [[ "$output" =~ "dolt_conflicts" ]] || false
@@ -215,6 +216,56 @@ teardown() {
     [ ! "$status" -eq 0 ]
 }
 
+@test "query dolt_commit_diff_ system table with commit specs" {
+    dolt sql -q "CREATE TABLE test (pk int PRIMARY KEY, c1 int);"
+    dolt add -A && dolt commit -m "created table"
+    dolt branch other
+    dolt sql -q "INSERT INTO test VALUES (0,0);"
+    dolt add -A && dolt commit -m "inserted 0"
+    dolt sql -q "INSERT INTO test VALUES (1,1);"
+    dolt add test
+    dolt sql -q "INSERT INTO test VALUES (2,2);"
+
+    run dolt sql -q "SELECT to_pk, from_pk, diff_type FROM dolt_commit_diff_test WHERE from_commit = 'HEAD~' AND to_commit = 'HEAD'" -r csv
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [ "${lines[1]}" = "0,,added" ]
+
+    run dolt sql -q "SELECT to_pk, from_pk, diff_type FROM dolt_commit_diff_test WHERE from_commit = 'other' AND to_commit = 'STAGED' ORDER BY to_pk" -r csv
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 3 ]
+    [ "${lines[1]}" = "0,,added" ]
+    [ "${lines[2]}" = "1,,added" ]
+
+    run dolt sql -q "SELECT to_pk, from_pk, diff_type FROM dolt_commit_diff_test WHERE from_commit = 'STAGED' AND to_commit = 'WORKING'" -r csv
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [ "${lines[1]}" = "2,,added" ]
+}
+
+@test "query DOLT_DIFF table function" {
+    dolt sql -q "CREATE TABLE test (pk int PRIMARY KEY, c1 int);"
+    dolt add -A && dolt commit -m "created table"
+    dolt branch other
+    dolt sql -q "INSERT INTO test VALUES (0,0);"
+    dolt add -A && dolt commit -m "inserted 0"
+    dolt sql -q "INSERT INTO test VALUES (1,1);"
+
+    run dolt sql -q "SELECT to_pk, from_pk, diff_type FROM \`DOLT_DIFF('test', 'other', 'WORKING')\` ORDER BY to_pk" -r csv
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 3 ]
+    [ "${lines[1]}" = "0,,added" ]
+    [ "${lines[2]}" = "1,,added" ]
+
+    run dolt sql -q "SELECT d.to_pk FROM test t JOIN \`dolt_diff('test', 'HEAD', 'WORKING')\` d ON t.pk = d.to_pk" -r csv
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [ "${lines[1]}" = "1" ]
+
+    run dolt sql -q "SELECT * FROM \`DOLT_DIFF('test', 'HEAD')\`"
+    [ "$status" -eq 1 ]
+}
+
 @test "query dolt_diff_ system table without committing table" {
     dolt sql -q "create table test (pk int not null primary key);"
     dolt sql -q "insert into test values (0), (1);"
@@ -276,6 +327,42 @@ teardown() {
     [[ "$output" =~ "4" ]] || false
 }
 
+@test "query dolt_diff" {
+    dolt sql -q "CREATE TABLE test (pk int PRIMARY KEY, c1 int);"
+    dolt sql -q "CREATE TABLE other (pk int PRIMARY KEY);"
+    dolt add -A && dolt commit -m "created tables"
+
+    dolt sql -q "INSERT INTO test VALUES (0,0),(1,1);"
+    dolt add -A && dolt commit -m "inserted into test"
+
+    dolt sql -q "UPDATE test SET c1 = 2 WHERE pk = 1;"
+    dolt sql -q "DELETE FROM test WHERE pk = 0;"
+    dolt sql -q "INSERT INTO other VALUES (0);"
+    dolt add -A && dolt commit -m "changed test and other"
+
+    dolt sql -q "ALTER TABLE other ADD COLUMN c1 int;"
+
+    run dolt sql -q "SELECT message, table_name, data_change, schema_change, rows_added, rows_deleted, rows_modified FROM dolt_diff WHERE commit_hash <> 'WORKING';" -r csv
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 6 ]
+    [[ "$output" =~ "created tables,other,false,true,0,0,0" ]] || false
+    [[ "$output" =~ "created tables,test,false,true,0,0,0" ]] || false
+    [[ "$output" =~ "inserted into test,test,true,false,2,0,0" ]] || false
+    [[ "$output" =~ "changed test and other,other,true,false,1,0,0" ]] || false
+    [[ "$output" =~ "changed test and other,test,true,false,0,1,1" ]] || false
+
+    run dolt sql -q "SELECT commit_hash, table_name, data_change, schema_change FROM dolt_diff WHERE commit_hash = 'WORKING';" -r csv
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [ "${lines[1]}" = "WORKING,other,false,true" ]
+
+    run dolt sql -q "SELECT DISTINCT message FROM dolt_diff WHERE table_name = 'other' AND commit_hash <> 'WORKING';" -r csv
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 3 ]
+    [[ "$output" =~ "created tables" ]] || false
+    [[ "$output" =~ "changed test and other" ]] || false
+}
+
 @test "query dolt_ancestor_commits" {
     run dolt sql -q "SELECT count(*) FROM dolt_commit_ancestors;" -r csv
     [ "$status" -eq 0 ]
@@ -122,7 +122,7 @@ func parseTuples(args []string, pkCols *schema.ColCollection) ([]map[uint64]stri
 	return results, nil
 }
 
-func ParseKeyValues(nbf *types.NomsBinFormat, sch schema.Schema, args []string) ([]types.Value, error) {
+func ParseKeyValues(ctx context.Context, vrw types.ValueReadWriter, sch schema.Schema, args []string) ([]types.Value, error) {
 	pkCols := sch.GetPKCols()
 
 	var pkMaps []map[uint64]string
@@ -154,7 +154,9 @@ func ParseKeyValues(nbf *types.NomsBinFormat, sch schema.Schema, args []string)
 				return types.String(*v), nil
 			}
 		} else {
-			convFuncs[tag] = col.TypeInfo.ParseValue
+			convFuncs[tag] = func(v *string) (types.Value, error) {
+				return col.TypeInfo.ParseValue(ctx, vrw, v)
+			}
 		}
 		return false, nil
 	})
@@ -176,7 +178,7 @@ func ParseKeyValues(nbf *types.NomsBinFormat, sch schema.Schema, args []string)
 			taggedVals[k] = val
 		}
 
-		tpl, err := taggedVals.NomsTupleForPKCols(nbf, pkCols).Value(context.TODO())
+		tpl, err := taggedVals.NomsTupleForPKCols(vrw.Format(), pkCols).Value(ctx)
 
 		if err != nil {
 			return nil, err
@@ -0,0 +1,214 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+const (
+	noCommitFlag = "no-commit"
+)
+
+var cherryPickDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Apply the changes introduced by an existing commit",
+	LongDesc: `Applies the changes introduced by the named commit to the current branch and records a new commit with the message and author of the original commit.
+
+The changes are computed by diffing the named commit against its parent, and are applied to the current working set with a three-way merge which uses the parent as the common ancestor. Merge commits and commits without a parent cannot be cherry-picked.
+
+If the changes conflict with changes on the current branch, the conflicts are left in the working set and no commit is made. Resolve them using {{.EmphasisLeft}}dolt conflicts{{.EmphasisRight}}, then {{.EmphasisLeft}}dolt add{{.EmphasisRight}} the affected tables and {{.EmphasisLeft}}dolt commit{{.EmphasisRight}} the result.
+
+The working set must not contain any uncommitted changes when running this command.
+`,
+	Synopsis: []string{
+		"[--no-commit] {{.LessThan}}commit{{.GreaterThan}}",
+	},
+}
+
+type CherryPickCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd CherryPickCmd) Name() string {
+	return "cherry-pick"
+}
+
+// Description returns a description of the command
+func (cmd CherryPickCmd) Description() string {
+	return "Apply the changes introduced by an existing commit."
+}
+
+// CreateMarkdown creates a markdown file containing the helptext for the command at the given path
+func (cmd CherryPickCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) error {
+	ap := cmd.createArgParser()
+	return CreateMarkdown(fs, path, cli.GetCommandDocumentation(commandStr, cherryPickDocs, ap))
+}
+
+func (cmd CherryPickCmd) createArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"commit", "The commit whose changes should be applied to the current branch."})
+	ap.SupportsFlag(noCommitFlag, "n", "Apply the changes to the working set and stage them without creating a commit.")
+	return ap
+}
+
+// Exec executes the command
+func (cmd CherryPickCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cmd.createArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, cherryPickDocs, ap))
+	apr := cli.ParseArgs(ap, args, help)
+
+	if apr.NArg() != 1 {
+		usage()
+		return 1
+	}
+
+	verr := checkCanApplyCommit(ctx, dEnv, cmd.Name())
+
+	if verr == nil {
+		verr = cherryPick(ctx, dEnv, apr.Arg(0), apr.Contains(noCommitFlag))
+	}
+
+	return HandleVErrAndExitCode(verr, usage)
+}
+
+func cherryPick(ctx context.Context, dEnv *env.DoltEnv, commitSpecStr string, noCommit bool) errhand.VerboseError {
+	cm, verr := ResolveCommitWithVErr(dEnv, commitSpecStr)
+
+	if verr != nil {
+		return verr
+	}
+
+	h, err := cm.HashOf()
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get hash of commit").AddCause(err).Build()
+	}
+
+	root, verr := GetWorkingWithVErr(dEnv)
+
+	if verr != nil {
+		return verr
+	}
+
+	mergedRoot, tblToStats, err := merge.CherryPick(ctx, dEnv.DoltDB, root, cm, merge.MergeOpts{Checks: sqlutil.NewCheckEvaluator})
+
+	if err != nil {
+		switch err {
+		case merge.ErrCommitHasNoParent:
+			return errhand.BuildDError("error: commit %s has no parent and cannot be cherry-picked", h.String()).Build()
+		case merge.ErrCommitIsMerge:
+			return errhand.BuildDError("error: commit %s is a merge and cannot be cherry-picked", h.String()).Build()
+		default:
+			return errhand.BuildDError("error: failed to cherry-pick %s", h.String()).AddCause(err).Build()
+		}
+	}
+
+	meta, err := cm.GetCommitMeta()
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to read the metadata of commit %s", h.String()).AddCause(err).Build()
+	}
+
+	return applyCommitChanges(ctx, dEnv, "cherry-pick", h.String(), mergedRoot, tblToStats, noCommit, actions.CommitStagedProps{
+		Message:          meta.Description,
+		Date:             doltdb.CommitNowFunc(),
+		CheckForeignKeys: true,
+		Name:             meta.Name,
+		Email:            meta.Email,
+	})
+}
+
+// checkCanApplyCommit returns an error if the working set is in a state where the changes of another commit can't be
+// applied to it. This is the case while a merge is active, while there are unresolved conflicts, or when there are
+// uncommitted changes.
+func checkCanApplyCommit(ctx context.Context, dEnv *env.DoltEnv, cmdName string) errhand.VerboseError {
+	verr := checkCanModifyWorkingSet(ctx, dEnv, cmdName)
+
+	if verr != nil {
+		return verr
+	}
+
+	headRoot, err := dEnv.HeadRoot(ctx)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to read the head root").AddCause(err).Build()
+	}
+
+	headHash, err := headRoot.HashOf()
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get hash of the head root").AddCause(err).Build()
+	}
+
+	if dEnv.RepoState.WorkingHash() != headHash || dEnv.RepoState.StagedHash() != headHash {
+		return errhand.BuildDError("error: your local changes would be overwritten by %s.", cmdName).
+			AddDetails("Please commit your changes before you %s.", cmdName).Build()
+	}
+
+	return nil
+}
+
+// applyCommitChanges moves |mergedRoot| into the working set. If the merge completed without conflicts the changes are
+// also staged and, unless |noCommit| is set, committed using |props|.
+func applyCommitChanges(ctx context.Context, dEnv *env.DoltEnv, cmdName, cmHashStr string, mergedRoot *doltdb.RootValue, tblToStats map[string]*merge.MergeStats, noCommit bool, props actions.CommitStagedProps) errhand.VerboseError {
+	verr := UpdateWorkingWithVErr(dEnv, mergedRoot)
+
+	if verr != nil {
+		return verr
+	}
+
+	err := actions.SaveTrackedDocsFromWorking(ctx, dEnv)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to update docs to the new working root").AddCause(err).Build()
+	}
+
+	if printSuccessStats(tblToStats) {
+		cli.Println("hint: after resolving the conflicts, mark the corrected tables")
+		cli.Println("hint: with 'dolt add <table>' and commit the result with 'dolt commit'")
+		return errhand.BuildDError("error: could not %s %s", cmdName, cmHashStr).Build()
+	}
+
+	verr = UpdateStagedWithVErr(dEnv, mergedRoot)
+
+	if verr != nil || noCommit {
+		return verr
+	}
+
+	_, err = actions.CommitStaged(ctx, dEnv.DbData(), props)
+
+	if err != nil {
+		if actions.IsNothingStaged(err) {
+			return errhand.BuildDError("error: %s of %s resulted in an empty commit", cmdName, cmHashStr).Build()
+		}
+
+		return errhand.BuildDError("error: Failed to commit changes.").AddCause(err).Build()
+	}
+
+	if (LogCmd{}).Exec(ctx, "log", []string{"-n=1"}, dEnv) != 0 {
+		return errhand.BuildDError("error: failed to print the new commit").Build()
+	}
+
+	return nil
+}
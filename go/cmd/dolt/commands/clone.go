@@ -21,6 +21,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"strings"
 	"sync"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
@@ -38,12 +39,16 @@ import (
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 	"github.com/dolthub/dolt/go/libraries/utils/strhelp"
 	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
 const (
-	remoteParam = "remote"
-	branchParam = "branch"
+	remoteParam       = "remote"
+	branchParam       = "branch"
+	depthParam        = "depth"
+	singleBranchParam = "single-branch"
+	tablesParam       = "tables"
 )
 
 var cloneDocs = cli.CommandDocumentationContent{
@@ -53,9 +58,15 @@ var cloneDocs = cli.CommandDocumentationContent{
 After the clone, a plain {{.EmphasisLeft}}dolt fetch{{.EmphasisRight}} without arguments will update all the remote-tracking branches, and a {{.EmphasisLeft}}dolt pull{{.EmphasisRight}} without arguments will in addition merge the remote branch into the current branch.
 
 This default configuration is achieved by creating references to the remote branch heads under {{.LessThan}}refs/remotes/origin{{.GreaterThan}}  and by creating a remote named 'origin'.
+
+A clone with {{.EmphasisLeft}}--single-branch{{.EmphasisRight}} only fetches the branch given by {{.EmphasisLeft}}--branch{{.EmphasisRight}}, or the remote's master branch, and configures the remote so that later fetches only update that branch.
+
+A shallow clone, created with {{.EmphasisLeft}}--depth{{.EmphasisRight}}, is a single branch clone which only fetches the latest {{.LessThan}}depth{{.GreaterThan}} commits of the branch and their data. Commands which need the history behind those commits, such as {{.EmphasisLeft}}dolt log{{.EmphasisRight}} or queries of the {{.EmphasisLeft}}dolt_history{{.EmphasisRight}} tables, fail with an error when they reach it. Run {{.EmphasisLeft}}dolt fetch --unshallow{{.EmphasisRight}} to fetch the rest of the history.
+
+A sparse clone, created with {{.EmphasisLeft}}--tables{{.EmphasisRight}}, is a single branch clone which only fetches the data of the tables given, along with the commit history and the dolt system tables. The data of the other tables is read from the remote when it is accessed, so they remain usable as long as the remote can be reached. Run {{.EmphasisLeft}}dolt fetch --tables{{.EmphasisRight}} to fetch the data of more tables.
 `,
 	Synopsis: []string{
-		"[-remote {{.LessThan}}remote{{.GreaterThan}}] [-branch {{.LessThan}}branch{{.GreaterThan}}]  [--aws-region {{.LessThan}}region{{.GreaterThan}}] [--aws-creds-type {{.LessThan}}creds-type{{.GreaterThan}}] [--aws-creds-file {{.LessThan}}file{{.GreaterThan}}] [--aws-creds-profile {{.LessThan}}profile{{.GreaterThan}}] {{.LessThan}}remote-url{{.GreaterThan}} {{.LessThan}}new-dir{{.GreaterThan}}",
+		"[-remote {{.LessThan}}remote{{.GreaterThan}}] [-branch {{.LessThan}}branch{{.GreaterThan}}] [--single-branch] [--depth {{.LessThan}}depth{{.GreaterThan}}] [--tables {{.LessThan}}table{{.GreaterThan}}[,{{.LessThan}}table{{.GreaterThan}}...]] [--aws-region {{.LessThan}}region{{.GreaterThan}}] [--aws-creds-type {{.LessThan}}creds-type{{.GreaterThan}}] [--aws-creds-file {{.LessThan}}file{{.GreaterThan}}] [--aws-creds-profile {{.LessThan}}profile{{.GreaterThan}}] [--aws-endpoint {{.LessThan}}endpoint{{.GreaterThan}}] {{.LessThan}}remote-url{{.GreaterThan}} {{.LessThan}}new-dir{{.GreaterThan}}",
 	},
 }
 
@@ -87,10 +98,14 @@ func (cmd CloneCmd) createArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParser()
 	ap.SupportsString(remoteParam, "", "name", "Name of the remote to be added. Default will be 'origin'.")
 	ap.SupportsString(branchParam, "b", "branch", "The branch to be cloned.  If not specified all branches will be cloned.")
+	ap.SupportsFlag(singleBranchParam, "", "Clone only the history of the branch given by --branch, or of master if no branch is given.")
+	ap.SupportsInt(depthParam, "", "depth", "Create a shallow, single branch clone with the history truncated to the latest <depth> commits.")
+	ap.SupportsString(tablesParam, "", "tables", "Create a sparse, single branch clone which only fetches the data of the comma separated list of tables given.")
 	ap.SupportsString(dbfactory.AWSRegionParam, "", "region", "")
 	ap.SupportsValidatedString(dbfactory.AWSCredsTypeParam, "", "creds-type", "", argparser.ValidatorFromStrList(dbfactory.AWSCredsTypeParam, credTypes))
 	ap.SupportsString(dbfactory.AWSCredsFileParam, "", "file", "AWS credentials file.")
 	ap.SupportsString(dbfactory.AWSCredsProfile, "", "profile", "AWS profile to use.")
+	ap.SupportsString(dbfactory.AWSEndpointParam, "", "endpoint", "Endpoint of an S3 compatible object store.")
 	return ap
 }
 
@@ -107,8 +122,15 @@ func (cmd CloneCmd) Exec(ctx context.Context, commandStr string, args []string,
 
 	remoteName := apr.GetValueOrDefault(remoteParam, "origin")
 	branch := apr.GetValueOrDefault(branchParam, "")
+	depth, hasDepth := apr.GetInt(depthParam)
+	tables := parseTablesList(apr)
+	singleBranch := apr.Contains(singleBranchParam) || hasDepth || tables != nil
 	dir, urlStr, verr := parseArgs(apr)
 
+	if verr == nil && hasDepth && depth < 1 {
+		verr = errhand.BuildDError("error: depth must be a positive number").Build()
+	}
+
 	scheme, remoteUrl, err := getAbsRemoteUrl(dEnv.FS, dEnv.Config, urlStr)
 
 	if err != nil {
@@ -128,7 +150,7 @@ func (cmd CloneCmd) Exec(ctx context.Context, commandStr string, args []string,
 				dEnv, verr = envForClone(ctx, srcDB.ValueReadWriter().Format(), r, dir, dEnv.FS, dEnv.Version)
 
 				if verr == nil {
-					verr = cloneRemote(ctx, srcDB, remoteName, branch, dEnv)
+					verr = cloneRemote(ctx, srcDB, remoteName, branch, singleBranch, depth, tables, dEnv)
 
 					if verr == nil {
 						evt := events.GetEventFromContext(ctx)
@@ -275,32 +297,46 @@ func cloneProg(eventCh <-chan datas.TableFileEvent) {
 	cli.Println()
 }
 
-func cloneRemote(ctx context.Context, srcDB *doltdb.DoltDB, remoteName, branch string, dEnv *env.DoltEnv) errhand.VerboseError {
-	eventCh := make(chan datas.TableFileEvent, 128)
+func cloneRemote(ctx context.Context, srcDB *doltdb.DoltDB, remoteName, branch string, singleBranch bool, depth int, tables []string, dEnv *env.DoltEnv) errhand.VerboseError {
+	var branches []ref.DoltRef
+	var verr errhand.VerboseError
+	if singleBranch {
+		branch, verr = fetchSingleBranch(ctx, srcDB, remoteName, branch, depth, tables, dEnv)
 
-	wg := &sync.WaitGroup{}
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		cloneProg(eventCh)
-	}()
+		if verr != nil {
+			return verr
+		}
 
-	err := actions.Clone(ctx, srcDB, dEnv.DoltDB, eventCh)
-	close(eventCh)
+		if branch != "" {
+			branches = []ref.DoltRef{ref.NewBranchRef(branch)}
+		}
+	} else {
+		eventCh := make(chan datas.TableFileEvent, 128)
 
-	wg.Wait()
+		wg := &sync.WaitGroup{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cloneProg(eventCh)
+		}()
 
-	if err != nil {
-		if err == datas.ErrNoData {
-			err = errors.New("remote at that url contains no Dolt data")
-		}
+		err := actions.Clone(ctx, srcDB, dEnv.DoltDB, eventCh)
+		close(eventCh)
 
-		return errhand.BuildDError("error: clone failed").AddCause(err).Build()
-	}
+		wg.Wait()
 
-	branches, err := dEnv.DoltDB.GetBranches(ctx)
-	if err != nil {
-		return errhand.BuildDError("error: failed to list branches").AddCause(err).Build()
+		if err != nil {
+			if err == datas.ErrNoData {
+				err = errors.New("remote at that url contains no Dolt data")
+			}
+
+			return errhand.BuildDError("error: clone failed").AddCause(err).Build()
+		}
+
+		branches, err = dEnv.DoltDB.GetBranches(ctx)
+		if err != nil {
+			return errhand.BuildDError("error: failed to list branches").AddCause(err).Build()
+		}
 	}
 
 	if branch == "" {
@@ -316,7 +352,7 @@ func cloneRemote(ctx context.Context, srcDB *doltdb.DoltDB, remoteName, branch s
 	// the remote.
 	performPull := true
 	if branch == "" {
-		err = initEmptyClonedRepo(ctx, dEnv)
+		err := initEmptyClonedRepo(ctx, dEnv)
 		if err != nil {
 			return nil
 		}
@@ -392,6 +428,101 @@ func cloneRemote(ctx context.Context, srcDB *doltdb.DoltDB, remoteName, branch s
 	return nil
 }
 
+// fetchSingleBranch fetches the history of a single branch of the remote into a newly cloned repo, creating a local
+// branch for it, and limits the remote's fetch specs to that branch. If depth is positive the history is truncated to
+// the latest depth commits, and the shallow boundary is recorded in the repo state. If tables is not nil only the data
+// of those tables is fetched, and the repo is made to read the data of the other tables from the remote. The name of
+// the fetched branch is returned, which is empty if the remote has no branches.
+func fetchSingleBranch(ctx context.Context, srcDB *doltdb.DoltDB, remoteName, branch string, depth int, tables []string, dEnv *env.DoltEnv) (string, errhand.VerboseError) {
+	srcBranches, err := srcDB.GetBranches(ctx)
+	if err != nil {
+		return "", errhand.BuildDError("error: failed to list branches").AddCause(err).Build()
+	}
+
+	if branch == "" {
+		for _, brnch := range srcBranches {
+			branch = brnch.GetPath()
+			if branch == doltdb.MasterBranch {
+				break
+			}
+		}
+
+		if branch == "" {
+			return "", nil
+		}
+	}
+
+	branchRef := ref.NewBranchRef(branch)
+	cm, err := srcDB.ResolveRef(ctx, branchRef)
+	if err != nil {
+		return "", errhand.BuildDError("error: remote branch '%s' not found", branch).AddCause(err).Build()
+	}
+
+	if tables != nil {
+		dEnv.RepoState.Sparse = &env.SparseState{Remote: remoteName, Tables: tables}
+		dEnv.DoltDB, err = dEnv.WithSparseFallback(dEnv.DoltDB)
+
+		if err != nil {
+			return "", errhand.BuildDError("error: clone failed").AddCause(err).Build()
+		}
+	}
+
+	var shallow []hash.Hash
+	wg, progChan, pullerEventCh := runProgFuncs()
+	if tables != nil {
+		shallow, err = actions.FetchCommitSparse(ctx, dEnv, srcDB, dEnv.DoltDB, cm, depth, tables, pullerEventCh)
+	} else if depth > 0 {
+		shallow, err = actions.FetchCommitShallow(ctx, dEnv, srcDB, dEnv.DoltDB, cm, depth, pullerEventCh)
+	} else {
+		err = actions.FetchCommit(ctx, dEnv, srcDB, dEnv.DoltDB, cm, progChan, pullerEventCh)
+	}
+	stopProgFuncs(wg, progChan, pullerEventCh)
+
+	if err != nil {
+		return "", errhand.BuildDError("error: clone failed").AddCause(err).Build()
+	}
+
+	err = dEnv.DoltDB.SetHeadToCommit(ctx, branchRef, cm)
+	if err != nil {
+		return "", errhand.BuildDError("error: could not create branch " + branch).AddCause(err).Build()
+	}
+
+	r := dEnv.RepoState.Remotes[remoteName]
+	r.FetchSpecs = []string{"refs/heads/" + branch + ":refs/remotes/" + remoteName + "/" + branch}
+	dEnv.RepoState.Remotes[remoteName] = r
+
+	for _, h := range shallow {
+		dEnv.RepoState.Shallow = append(dEnv.RepoState.Shallow, h.String())
+	}
+
+	err = dEnv.DoltDB.SetShallowBoundary(ctx, shallow)
+	if err != nil {
+		return "", errhand.BuildDError("error: clone failed").AddCause(err).Build()
+	}
+
+	return branch, nil
+}
+
+// parseTablesList returns the comma separated list of tables given by --tables, or nil if it was not given.
+func parseTablesList(apr *argparser.ArgParseResults) []string {
+	tablesStr, ok := apr.GetValue(tablesParam)
+
+	if !ok {
+		return nil
+	}
+
+	var tables []string
+	for _, tbl := range strings.Split(tablesStr, ",") {
+		tbl = strings.TrimSpace(tbl)
+
+		if tbl != "" {
+			tables = append(tables, tbl)
+		}
+	}
+
+	return tables
+}
+
 // Inits an empty, newly cloned repo. This would be unnecessary if we properly initialized the storage for a repository
 // when we created it on dolthub. If we do that, this code can be removed.
 func initEmptyClonedRepo(ctx context.Context, dEnv *env.DoltEnv) error {
@@ -37,7 +37,7 @@ When a merge operation finds conflicting changes, the rows with the conflicts ar
 		
 In it's first form {{.EmphasisLeft}}dolt conflicts resolve <table> <key>...{{.EmphasisRight}}, resolve runs in manual merge mode resolving the conflicts whose keys are provided.
 
-In it's second form {{.EmphasisLeft}}dolt conflicts resolve --ours|--theirs <table>...{{.EmphasisRight}}, resolve runs in auto resolve mode. Where conflicts are resolved using a rule to determine which version of a row should be used.
+In it's second form {{.EmphasisLeft}}dolt conflicts resolve --ours|--theirs <table>...{{.EmphasisRight}}, resolve runs in auto resolve mode. Where conflicts are resolved using a rule to determine which version of a row should be used. A table whose schema could not be merged is resolved by keeping our version of the table with {{.EmphasisLeft}}--ours{{.EmphasisRight}}, or by taking their schema and rows with {{.EmphasisLeft}}--theirs{{.EmphasisRight}}.
 `,
 	Synopsis: []string{
 		`{{.LessThan}}table{{.GreaterThan}} [{{.LessThan}}key_definition{{.GreaterThan}}] {{.LessThan}}key{{.GreaterThan}}...`,
@@ -55,6 +55,11 @@ var autoResolvers = map[string]merge.AutoResolver{
 	theirsFlag: merge.Theirs,
 }
 
+var schemaConflictResolvers = map[string]merge.SchemaConflictResolver{
+	oursFlag:   merge.OursSchema,
+	theirsFlag: merge.TheirsSchema,
+}
+
 var autoResolverParams []string
 
 func init() {
@@ -124,13 +129,20 @@ func autoResolve(ctx context.Context, apr *argparser.ArgParseResults, dEnv *env.
 
 	autoResolveFlag := funcFlags.AsSlice()[0]
 	autoResolveFunc := autoResolvers[autoResolveFlag]
+	schemaResolveFunc := schemaConflictResolvers[autoResolveFlag]
 
 	var err error
 	tbls := apr.Args()
 	if len(tbls) == 1 && tbls[0] == "." {
-		err = actions.AutoResolveAll(ctx, dEnv, autoResolveFunc)
+		err = resolveAllSchemaConflicts(ctx, dEnv, schemaResolveFunc)
+		if err == nil {
+			err = actions.AutoResolveAll(ctx, dEnv, autoResolveFunc)
+		}
 	} else {
-		err = actions.AutoResolveTables(ctx, dEnv, autoResolveFunc, tbls)
+		tbls, err = actions.ResolveSchemaConflicts(ctx, dEnv, schemaResolveFunc, tbls)
+		if err == nil && len(tbls) > 0 {
+			err = actions.AutoResolveTables(ctx, dEnv, autoResolveFunc, tbls)
+		}
 	}
 
 	if err != nil {
@@ -145,6 +157,23 @@ func autoResolve(ctx context.Context, apr *argparser.ArgParseResults, dEnv *env.
 	return saveDocsOnResolve(ctx, dEnv)
 }
 
+func resolveAllSchemaConflicts(ctx context.Context, dEnv *env.DoltEnv, resolver merge.SchemaConflictResolver) error {
+	root, err := dEnv.WorkingRoot(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	tbls, err := root.TablesWithSchemaConflicts(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = actions.ResolveSchemaConflicts(ctx, dEnv, resolver, tbls)
+	return err
+}
+
 func manualResolve(ctx context.Context, apr *argparser.ArgParseResults, dEnv *env.DoltEnv) errhand.VerboseError {
 	args := apr.Args()
 
@@ -178,7 +207,7 @@ func manualResolve(ctx context.Context, apr *argparser.ArgParseResults, dEnv *en
 		return errhand.BuildDError("error: failed to get schema").AddCause(err).Build()
 	}
 
-	keysToResolve, err := cli.ParseKeyValues(root.VRW().Format(), sch, args[1:])
+	keysToResolve, err := cli.ParseKeyValues(ctx, root.VRW(), sch, args[1:])
 
 	if err != nil {
 		return errhand.BuildDError("error: parsing command line").AddCause(err).Build()
@@ -29,6 +29,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	dsqle "github.com/dolthub/dolt/go/libraries/doltcore/sqle"
 	"github.com/dolthub/dolt/go/libraries/utils/editor"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 )
@@ -121,6 +122,7 @@ func (cmd CommitCmd) Exec(ctx context.Context, commandStr string, args []string,
 		CheckForeignKeys: !apr.Contains(cli.ForceFlag),
 		Name:             name,
 		Email:            email,
+		Hooks:            newHooks(dEnv),
 	})
 
 	if err == nil {
@@ -131,6 +133,16 @@ func (cmd CommitCmd) Exec(ctx context.Context, commandStr string, args []string,
 	return handleCommitErr(ctx, dEnv, err, usage)
 }
 
+// newHooks returns the hooks run by the commits and merges of the repository of the environment given.
+func newHooks(dEnv *env.DoltEnv) *actions.Hooks {
+	var dbName string
+	for name := range env.DoltEnvAsMultiEnv(dEnv) {
+		dbName = name
+	}
+
+	return actions.NewHooks(dEnv.Config, dsqle.NewHookQueryFunc(dbName, dEnv.DbData()), cli.CliOut)
+}
+
 func handleCommitErr(ctx context.Context, dEnv *env.DoltEnv, err error, usage cli.UsagePrinter) int {
 	if err == nil {
 		return 0
@@ -34,12 +34,12 @@ import (
 
 var checkShortDesc = "Check authenticating with a credential keypair against a doltremoteapi."
 var checkLongDesc = `Tests calling a doltremoteapi with dolt credentials and reports the authentication result.`
-var checkSynopsis = []string{"[--endpoint doltremoteapi.dolthub.com:443] [--creds {{.LessThan}}eak95022q3vskvumn2fcrpibdnheq1dtr8t...{{.GreaterThan}}]"}
+var checkSynopsis = []string{"[--endpoint doltremoteapi.dolthub.com:443] [--insecure] [--creds {{.LessThan}}eak95022q3vskvumn2fcrpibdnheq1dtr8t...{{.GreaterThan}}]"}
 
 var checkDocs = cli.CommandDocumentationContent{
 	ShortDesc: "Check authenticating with a credential keypair against a doltremoteapi.",
 	LongDesc:  `Tests calling a doltremoteapi with dolt credentials and reports the authentication result.`,
-	Synopsis:  []string{"[--endpoint doltremoteapi.dolthub.com:443] [--creds {{.LessThan}}eak95022q3vskvumn2fcrpibdnheq1dtr8t...{{.GreaterThan}}]"},
+	Synopsis:  []string{"[--endpoint doltremoteapi.dolthub.com:443] [--insecure] [--creds {{.LessThan}}eak95022q3vskvumn2fcrpibdnheq1dtr8t...{{.GreaterThan}}]"},
 }
 
 type CheckCmd struct{}
@@ -75,6 +75,7 @@ func (cmd CheckCmd) createArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParser()
 	ap.SupportsString("endpoint", "", "", "API endpoint, otherwise taken from config.")
 	ap.SupportsString("creds", "", "", "Public Key ID or Public Key for credentials, otherwise taken from config.")
+	ap.SupportsFlag("insecure", "", "Connect to the endpoint without TLS, e.g. to check credentials against a local remotesrv.")
 	return ap
 }
 
@@ -91,7 +92,7 @@ func (cmd CheckCmd) Exec(ctx context.Context, commandStr string, args []string,
 		return commands.HandleVErrAndExitCode(verr, usage)
 	}
 
-	verr = checkCredAndPrintSuccess(ctx, dEnv, dc, endpoint)
+	verr = checkCredAndPrintSuccess(ctx, dEnv, dc, endpoint, apr.Contains("insecure"))
 
 	return commands.HandleVErrAndExitCode(verr, usage)
 }
@@ -137,9 +138,10 @@ func loadCred(dEnv *env.DoltEnv, apr *argparser.ArgParseResults) (creds.DoltCred
 	}
 }
 
-func checkCredAndPrintSuccess(ctx context.Context, dEnv *env.DoltEnv, dc creds.DoltCreds, endpoint string) errhand.VerboseError {
+func checkCredAndPrintSuccess(ctx context.Context, dEnv *env.DoltEnv, dc creds.DoltCreds, endpoint string, insecure bool) errhand.VerboseError {
 	endpoint, opts, err := dEnv.GetGRPCDialParams(grpcendpoint.Config{
 		Endpoint: endpoint,
+		Insecure: insecure,
 		Creds:    dc,
 	})
 	if err != nil {
@@ -47,6 +47,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/utils/mathutil"
 	"github.com/dolthub/dolt/go/libraries/utils/set"
 	"github.com/dolthub/dolt/go/store/atomicerr"
+	"github.com/dolthub/dolt/go/store/types"
 )
 
 type diffOutput int
@@ -662,7 +663,13 @@ func diffRows(ctx context.Context, td diff.TableDelta, dArgs *diffArgs) errhand.
 		return errhand.BuildDError("").AddCause(err).Build()
 	}
 
-	unionSch, ds, verr := createSplitter(fromSch, toSch, joiner, dArgs)
+	tbl := td.ToTable
+	if td.IsDrop() {
+		tbl = td.FromTable
+	}
+	vrw := tbl.ValueReadWriter()
+
+	unionSch, ds, verr := createSplitter(ctx, vrw, fromSch, toSch, joiner, dArgs)
 	if verr != nil {
 		return verr
 	}
@@ -712,7 +719,7 @@ func diffRows(ctx context.Context, td diff.TableDelta, dArgs *diffArgs) errhand.
 		return true
 	}
 
-	p, verr := buildPipeline(dArgs, joiner, ds, unionSch, src, sink, badRowCallback)
+	p, verr := buildPipeline(ctx, vrw, dArgs, joiner, ds, unionSch, src, sink, badRowCallback)
 	if verr != nil {
 		return verr
 	}
@@ -756,10 +763,10 @@ func diffRows(ctx context.Context, td diff.TableDelta, dArgs *diffArgs) errhand.
 	return nil
 }
 
-func buildPipeline(dArgs *diffArgs, joiner *rowconv.Joiner, ds *diff.DiffSplitter, untypedUnionSch schema.Schema, src *diff.RowDiffSource, sink DiffSink, badRowCB pipeline.BadRowCallback) (*pipeline.Pipeline, errhand.VerboseError) {
+func buildPipeline(ctx context.Context, vrw types.ValueReadWriter, dArgs *diffArgs, joiner *rowconv.Joiner, ds *diff.DiffSplitter, untypedUnionSch schema.Schema, src *diff.RowDiffSource, sink DiffSink, badRowCB pipeline.BadRowCallback) (*pipeline.Pipeline, errhand.VerboseError) {
 	var where FilterFn
 	var selTrans *SelectTransform
-	where, err := ParseWhere(joiner.GetSchema(), dArgs.where)
+	where, err := ParseWhere(ctx, vrw, joiner.GetSchema(), dArgs.where)
 
 	if err != nil {
 		return nil, errhand.BuildDError("error: failed to parse where clause").AddCause(err).SetPrintUsage().Build()
@@ -822,7 +829,7 @@ func mapTagToColName(sch, untypedUnionSch schema.Schema) (map[uint64]string, err
 	return tagToCol, nil
 }
 
-func createSplitter(fromSch schema.Schema, toSch schema.Schema, joiner *rowconv.Joiner, dArgs *diffArgs) (schema.Schema, *diff.DiffSplitter, errhand.VerboseError) {
+func createSplitter(ctx context.Context, vrw types.ValueReadWriter, fromSch schema.Schema, toSch schema.Schema, joiner *rowconv.Joiner, dArgs *diffArgs) (schema.Schema, *diff.DiffSplitter, errhand.VerboseError) {
 
 	var unionSch schema.Schema
 	if dArgs.diffOutput == TabularDiffOutput {
@@ -855,7 +862,7 @@ func createSplitter(fromSch schema.Schema, toSch schema.Schema, joiner *rowconv.
 			return nil, nil, errhand.BuildDError("Error creating unioned mapping").AddCause(err).Build()
 		}
 
-		newToUnionConv, _ = rowconv.NewRowConverter(newToUnionMapping)
+		newToUnionConv, _ = rowconv.NewRowConverter(ctx, vrw, newToUnionMapping)
 	}
 
 	oldToUnionConv := rowconv.IdentityConverter
@@ -866,7 +873,7 @@ func createSplitter(fromSch schema.Schema, toSch schema.Schema, joiner *rowconv.
 			return nil, nil, errhand.BuildDError("Error creating unioned mapping").AddCause(err).Build()
 		}
 
-		oldToUnionConv, _ = rowconv.NewRowConverter(oldToUnionMapping)
+		oldToUnionConv, _ = rowconv.NewRowConverter(ctx, vrw, oldToUnionMapping)
 	}
 
 	ds := diff.NewDiffSplitter(joiner, oldToUnionConv, newToUnionConv)
@@ -1012,6 +1019,8 @@ func diffSummary(ctx context.Context, td diff.TableDelta, colLen int) errhand.Ve
 		acc.Removes += p.Removes
 		acc.Changes += p.Changes
 		acc.CellChanges += p.CellChanges
+		acc.BytesAdded += p.BytesAdded
+		acc.BytesRemoved += p.BytesRemoved
 		acc.NewSize += p.NewSize
 		acc.OldSize += p.OldSize
 
@@ -1074,6 +1083,11 @@ func printSummary(acc diff.DiffSummaryProgress, colLen int) {
 	cli.Printf("%s (%.2f%%)\n", deletions, safePercent(acc.Removes, acc.OldSize))
 	cli.Printf("%s (%.2f%%)\n", changes, safePercent(acc.Changes, acc.OldSize))
 	cli.Printf("%s (%.2f%%)\n", cellChanges, percentCellsChanged)
+	if acc.BytesAdded+acc.BytesRemoved > 0 {
+		bytesAdded := pluralize("Byte Added", "Bytes Added", acc.BytesAdded)
+		bytesRemoved := pluralize("Byte Deleted", "Bytes Deleted", acc.BytesRemoved)
+		cli.Printf("%s, %s in BLOB/TEXT Cells\n", bytesAdded, bytesRemoved)
+	}
 	cli.Printf("(%s vs %s)\n\n", oldValues, newValues)
 }
 
@@ -28,10 +28,13 @@ import (
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 	"github.com/dolthub/dolt/go/libraries/utils/earl"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/libraries/utils/set"
+	"github.com/dolthub/dolt/go/store/hash"
 )
 
 const (
 	ForceFetchFlag = "force"
+	UnshallowFlag  = "unshallow"
 )
 
 var fetchDocs = cli.CommandDocumentationContent{
@@ -41,10 +44,14 @@ var fetchDocs = cli.CommandDocumentationContent{
 By default dolt will attempt to fetch from a remote named {{.EmphasisLeft}}origin{{.EmphasisRight}}.  The {{.LessThan}}remote{{.GreaterThan}} parameter allows you to specify the name of a different remote you wish to pull from by the remote's name.
 
 When no refspec(s) are specified on the command line, the fetch_specs for the default remote are used.
+
+In a shallow clone, {{.EmphasisLeft}}--unshallow{{.EmphasisRight}} additionally fetches the history which was left out by the clone, after which the repository is no longer shallow.
+
+In a sparse clone, only the data of the tables the clone was created with is fetched. {{.EmphasisLeft}}--tables{{.EmphasisRight}} adds tables to the ones whose data is fetched, and fetches their data. Used outside of a sparse clone, {{.EmphasisLeft}}--tables{{.EmphasisRight}} turns the repository into a sparse clone of the remote, so that later fetches from it only fetch the data of the tables given.
 `,
 
 	Synopsis: []string{
-		"[{{.LessThan}}remote{{.GreaterThan}}] [{{.LessThan}}refspec{{.GreaterThan}} ...]",
+		"[--unshallow] [--tables {{.LessThan}}table{{.GreaterThan}}[,{{.LessThan}}table{{.GreaterThan}}...]] [{{.LessThan}}remote{{.GreaterThan}}] [{{.LessThan}}refspec{{.GreaterThan}} ...]",
 	},
 }
 
@@ -74,6 +81,8 @@ func (cmd FetchCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string)
 func (cmd FetchCmd) createArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParser()
 	ap.SupportsFlag(ForceFetchFlag, "f", "Update refs to remote branches with the current state of the remote, overwriting any conflicting history.")
+	ap.SupportsFlag(UnshallowFlag, "", "Fetch the history missing from a shallow clone.")
+	ap.SupportsString(tablesParam, "", "tables", "Fetch the data of the comma separated list of tables given, in addition to the tables of a sparse clone.")
 	return ap
 }
 
@@ -88,13 +97,97 @@ func (cmd FetchCmd) Exec(ctx context.Context, commandStr string, args []string,
 
 	updateMode := ref.RefUpdateMode{Force: apr.Contains(ForceFetchFlag)}
 
+	if verr == nil && apr.Contains(UnshallowFlag) && len(dEnv.RepoState.Shallow) == 0 {
+		verr = errhand.BuildDError("error: --unshallow on a complete repository does not make sense").Build()
+	}
+
+	if tables := parseTablesList(apr); verr == nil && tables != nil {
+		verr = addSparseTables(dEnv, r, tables)
+	}
+
 	if verr == nil {
 		verr = fetchRefSpecs(ctx, updateMode, dEnv, r, refSpecs)
 	}
 
+	if verr == nil && dEnv.RepoState.Sparse != nil {
+		err := dEnv.RepoState.Save(dEnv.FS)
+
+		if err != nil {
+			verr = errhand.BuildDError("error: failed to write repo state").AddCause(err).Build()
+		}
+	}
+
+	if verr == nil && apr.Contains(UnshallowFlag) {
+		verr = unshallow(ctx, dEnv, r)
+	}
+
 	return HandleVErrAndExitCode(verr, usage)
 }
 
+// unshallow fetches the history behind the shallow boundary of the repo from a remote, and clears the boundary.
+func unshallow(ctx context.Context, dEnv *env.DoltEnv, rem env.Remote) errhand.VerboseError {
+	srcDB, err := rem.GetRemoteDB(ctx, dEnv.DoltDB.ValueReadWriter().Format())
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get remote db").AddCause(err).Build()
+	}
+
+	shallow := make([]hash.Hash, len(dEnv.RepoState.Shallow))
+	for i, hashStr := range dEnv.RepoState.Shallow {
+		shallow[i] = hash.Parse(hashStr)
+	}
+
+	wg, progChan, pullerEventCh := runProgFuncs()
+	err = actions.FetchShallowHistory(ctx, dEnv, srcDB, dEnv.DoltDB, shallow, pullerEventCh)
+	stopProgFuncs(wg, progChan, pullerEventCh)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to fetch the history of the shallow clone").AddCause(err).Build()
+	}
+
+	dEnv.RepoState.Shallow = nil
+	err = dEnv.DoltDB.SetShallowBoundary(ctx, nil)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to clear the shallow boundary").AddCause(err).Build()
+	}
+
+	err = dEnv.RepoState.Save(dEnv.FS)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to write repo state").AddCause(err).Build()
+	}
+
+	return nil
+}
+
+// addSparseTables adds tables to the tables whose data is fetched from the remote of a sparse clone, making the repo a
+// sparse clone of rem if it isn't one. The repo state is saved once the data of the tables has been fetched.
+func addSparseTables(dEnv *env.DoltEnv, rem env.Remote, tables []string) errhand.VerboseError {
+	if dEnv.RepoState.Sparse == nil {
+		dEnv.RepoState.Sparse = &env.SparseState{Remote: rem.Name}
+		ddb, err := dEnv.WithSparseFallback(dEnv.DoltDB)
+
+		if err != nil {
+			return errhand.BuildDError("error: failed to read the database").AddCause(err).Build()
+		}
+
+		dEnv.DoltDB = ddb
+	} else if dEnv.RepoState.Sparse.Remote != rem.Name {
+		return errhand.BuildDError("error: the sparse clone can only fetch tables from '%s'", dEnv.RepoState.Sparse.Remote).Build()
+	}
+
+	sparseTables := set.NewStrSet(dEnv.RepoState.Sparse.Tables)
+	for _, tbl := range tables {
+		if !sparseTables.Contains(tbl) {
+			sparseTables.Add(tbl)
+			dEnv.RepoState.Sparse.Tables = append(dEnv.RepoState.Sparse.Tables, tbl)
+		}
+	}
+
+	return nil
+}
+
 func getRefSpecs(args []string, dEnv *env.DoltEnv, remotes map[string]env.Remote) (env.Remote, []ref.RemoteRefSpec, errhand.VerboseError) {
 	if len(remotes) == 0 {
 		return env.NoRemote, nil, errhand.BuildDError("error: no remotes set").AddDetails("to add a remote run: dolt remote add <remote> <url>").Build()
@@ -250,7 +343,11 @@ func fetchRemoteBranch(ctx context.Context, dEnv *env.DoltEnv, rem env.Remote, s
 		return nil, errhand.BuildDError("error: unable to find '%s' on '%s'", srcRef.GetPath(), rem.Name).Build()
 	} else {
 		wg, progChan, pullerEventCh := runProgFuncs()
-		err = actions.FetchCommit(ctx, dEnv, srcDB, destDB, srcDBCommit, progChan, pullerEventCh)
+		if sparse := dEnv.RepoState.Sparse; sparse != nil && sparse.Remote == rem.Name {
+			_, err = actions.FetchCommitSparse(ctx, dEnv, srcDB, destDB, srcDBCommit, 0, sparse.Tables, pullerEventCh)
+		} else {
+			err = actions.FetchCommit(ctx, dEnv, srcDB, destDB, srcDBCommit, progChan, pullerEventCh)
+		}
 		stopProgFuncs(wg, progChan, pullerEventCh)
 
 		if err != nil {
@@ -273,11 +370,11 @@ func fetchFollowTags(ctx context.Context, dEnv *env.DoltEnv, srcDB, destDB *dolt
 
 		tagHash := stRef.TargetHash()
 
-		tv, err := destDB.ValueReadWriter().ReadValue(ctx, tagHash)
+		tagFetched, err := destDB.HasChunk(ctx, tagHash)
 		if err != nil {
 			return true, err
 		}
-		if tv != nil {
+		if tagFetched {
 			// tag is already fetched
 			return false, nil
 		}
@@ -287,11 +384,11 @@ func fetchFollowTags(ctx context.Context, dEnv *env.DoltEnv, srcDB, destDB *dolt
 			return true, err
 		}
 
-		cv, err := destDB.ValueReadWriter().ReadValue(ctx, cmHash)
+		cmFetched, err := destDB.HasChunk(ctx, cmHash)
 		if err != nil {
 			return true, err
 		}
-		if cv == nil {
+		if !cmFetched {
 			// neither tag nor commit has been fetched
 			return false, nil
 		}
@@ -17,6 +17,7 @@ package commands
 import (
 	"context"
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/fatih/color"
@@ -165,14 +166,27 @@ func logCommits(ctx context.Context, dEnv *env.DoltEnv, cs *doltdb.CommitSpec, l
 		return 1
 	}
 
-	commits, err := commitwalk.GetTopNTopoOrderedCommits(ctx, dEnv.DoltDB, h, numLines)
+	itr, err := commitwalk.GetTopologicalOrderIterator(ctx, dEnv.DoltDB, h)
 
 	if err != nil {
 		cli.PrintErrln("Error retrieving commit.")
 		return 1
 	}
 
-	for _, comm := range commits {
+	for n := 0; numLines < 0 || n < numLines; n++ {
+		_, comm, err := itr.Next(ctx)
+
+		if err == io.EOF {
+			break
+		} else if doltdb.IsShallowBoundary(err) {
+			// the commits in front of the boundary were already printed
+			cli.PrintErrln(color.HiRedString("error: " + err.Error()))
+			return 1
+		} else if err != nil {
+			cli.PrintErrln("Error retrieving commit.")
+			return 1
+		}
+
 		meta, err := comm.GetCommitMeta()
 
 		if err != nil {
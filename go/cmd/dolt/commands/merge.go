@@ -29,6 +29,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 	"github.com/dolthub/dolt/go/store/hash"
@@ -271,6 +272,7 @@ func execNoFFMerge(ctx context.Context, apr *argparser.ArgParseResults, dEnv *en
 		CheckForeignKeys: !apr.Contains(forceFlag),
 		Name:             name,
 		Email:            email,
+		Hooks:            newHooks(dEnv),
 	})
 
 	if err != nil {
@@ -302,6 +304,12 @@ func executeFFMerge(ctx context.Context, squash bool, dEnv *env.DoltEnv, cm2 *do
 		return errhand.BuildDError("error: failed to get root value").AddCause(err).Build()
 	}
 
+	hookArgs, verr := runPreMergeHooks(ctx, dEnv, rv, cm2)
+
+	if verr != nil {
+		return verr
+	}
+
 	stagedHash, err := dEnv.DoltDB.WriteRootValue(ctx, rv)
 	if err != nil {
 		return errhand.BuildDError("Failed to write database").AddCause(err).Build()
@@ -328,6 +336,12 @@ func executeFFMerge(ctx context.Context, squash bool, dEnv *env.DoltEnv, cm2 *do
 	}
 
 	if !squash {
+		err = actions.CheckBranchUpdate(ctx, dEnv.DoltDB, dEnv.RepoState.CWBHeadRef(), cm2)
+
+		if err != nil {
+			return errhand.BuildDError("error: failed to fast forward").AddCause(err).Build()
+		}
+
 		err = dEnv.DoltDB.FastForward(ctx, dEnv.RepoState.CWBHeadRef(), cm2)
 
 		if err != nil {
@@ -355,11 +369,11 @@ and take the hash for your current branch and use it for the value for "staged"
 		return errhand.BuildDError("error: failed to update docs to the new working root").AddCause(err).Build()
 	}
 
-	return nil
+	return hookArgs.runPostMergeHooks(ctx, rv)
 }
 
 func executeMerge(ctx context.Context, squash bool, dEnv *env.DoltEnv, cm1, cm2 *doltdb.Commit, workingDiffs map[string]hash.Hash) errhand.VerboseError {
-	mergedRoot, tblToStats, err := merge.MergeCommits(ctx, cm1, cm2)
+	mergedRoot, tblToStats, err := merge.MergeCommits(ctx, cm1, cm2, merge.MergeOpts{Checks: sqlutil.NewCheckEvaluator})
 
 	if err != nil {
 		switch err {
@@ -376,6 +390,12 @@ func executeMerge(ctx context.Context, squash bool, dEnv *env.DoltEnv, cm1, cm2
 }
 
 func mergedRootToWorking(ctx context.Context, squash bool, dEnv *env.DoltEnv, mergedRoot *doltdb.RootValue, workingDiffs map[string]hash.Hash, cm2 *doltdb.Commit, tblToStats map[string]*merge.MergeStats) errhand.VerboseError {
+	hookArgs, verr := runPreMergeHooks(ctx, dEnv, mergedRoot, cm2)
+
+	if verr != nil {
+		return verr
+	}
+
 	var err error
 
 	workingRoot := mergedRoot
@@ -406,7 +426,7 @@ func mergedRootToWorking(ctx context.Context, squash bool, dEnv *env.DoltEnv, me
 		return errhand.BuildDError("error: failed to determine unstaged docs").AddCause(err).Build()
 	}
 
-	verr := UpdateWorkingWithVErr(dEnv, workingRoot)
+	verr = UpdateWorkingWithVErr(dEnv, workingRoot)
 
 	if verr == nil {
 		hasConflicts := printSuccessStats(tblToStats)
@@ -422,6 +442,8 @@ func mergedRootToWorking(ctx context.Context, squash bool, dEnv *env.DoltEnv, me
 			if verr != nil {
 				// Log a new message here to indicate that merge was successful, only staging failed.
 				cli.Println("Unable to stage changes: add and commit to finish merge")
+			} else {
+				verr = hookArgs.runPostMergeHooks(ctx, mergedRoot)
 			}
 		}
 	}
@@ -429,6 +451,55 @@ func mergedRootToWorking(ctx context.Context, squash bool, dEnv *env.DoltEnv, me
 	return verr
 }
 
+// mergeHookArgs are the arguments of the hooks of a merge: the hooks, the hash of the commit being merged and the
+// tables the merge changes.
+type mergeHookArgs struct {
+	hooks      *actions.Hooks
+	commitHash string
+	tables     []string
+}
+
+// runPreMergeHooks runs the pre-merge hooks against the merged root value, returning the arguments used to run the
+// post-merge hooks once the merge is done.
+func runPreMergeHooks(ctx context.Context, dEnv *env.DoltEnv, mergedRoot *doltdb.RootValue, cm2 *doltdb.Commit) (mergeHookArgs, errhand.VerboseError) {
+	headRoot, err := dEnv.HeadRoot(ctx)
+
+	if err != nil {
+		return mergeHookArgs{}, errhand.BuildDError("error: failed to get head root").AddCause(err).Build()
+	}
+
+	tables, err := actions.ChangedTables(ctx, headRoot, mergedRoot)
+
+	if err != nil {
+		return mergeHookArgs{}, errhand.BuildDError("error: failed to determine the changed tables").AddCause(err).Build()
+	}
+
+	h2, err := cm2.HashOf()
+
+	if err != nil {
+		return mergeHookArgs{}, errhand.BuildDError("error: failed to hash commit").AddCause(err).Build()
+	}
+
+	args := mergeHookArgs{newHooks(dEnv), h2.String(), tables}
+	err = args.hooks.Run(ctx, actions.PreMergeHook, mergedRoot, args.commitHash, args.tables)
+
+	if err != nil {
+		return mergeHookArgs{}, errhand.BuildDError("error: merge aborted").AddCause(err).Build()
+	}
+
+	return args, nil
+}
+
+func (args mergeHookArgs) runPostMergeHooks(ctx context.Context, mergedRoot *doltdb.RootValue) errhand.VerboseError {
+	err := args.hooks.Run(ctx, actions.PostMergeHook, mergedRoot, args.commitHash, args.tables)
+
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+
+	return nil
+}
+
 func printSuccessStats(tblToStats map[string]*merge.MergeStats) bool {
 	printModifications(tblToStats)
 	printAdditions(tblToStats)
@@ -455,7 +526,15 @@ func printDeletions(tblToStats map[string]*merge.MergeStats) {
 func printConflicts(tblToStats map[string]*merge.MergeStats) bool {
 	hasConflicts := false
 	for tblName, stats := range tblToStats {
-		if stats.Operation == merge.TableModified && stats.Conflicts > 0 {
+		if stats.Operation == merge.TableModified && stats.Resolved > 0 {
+			cli.Printf("Resolved %d conflicts in %s using merge strategies\n", stats.Resolved, tblName)
+		}
+
+		if stats.Operation == merge.TableModified && stats.SchemaConflicts > 0 {
+			cli.Println("CONFLICT (schema): Merge conflict in", tblName)
+
+			hasConflicts = true
+		} else if stats.Operation == merge.TableModified && stats.Conflicts > 0 {
 			cli.Println("Auto-merging", tblName)
 			cli.Println("CONFLICT (content): Merge conflict in", tblName)
 
@@ -474,7 +553,7 @@ func printModifications(tblToStats map[string]*merge.MergeStats) {
 	rowsChanged := 0
 	var tbls []string
 	for tblName, stats := range tblToStats {
-		if stats.Operation == merge.TableModified && stats.Conflicts == 0 {
+		if stats.Operation == merge.TableModified && !stats.HasConflicts() {
 			tbls = append(tbls, tblName)
 			nameLen := len(tblName)
 			modCount := stats.Adds + stats.Modifications + stats.Deletes + stats.Conflicts
@@ -0,0 +1,582 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/commitwalk"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/rebase"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+const (
+	continueFlag = "continue"
+	planParam    = "plan"
+)
+
+var rebaseDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Reapply commits on top of another base commit",
+	LongDesc: `Replays the commits of the current branch which are not reachable from {{.LessThan}}upstream{{.GreaterThan}} on top of the head of {{.LessThan}}upstream{{.GreaterThan}}, and moves the current branch to the last replayed commit.
+
+The current branch is first reset to {{.LessThan}}upstream{{.GreaterThan}}. Then the changes of each commit are reapplied in order, as if by {{.EmphasisLeft}}dolt cherry-pick{{.EmphasisRight}}, keeping the message and author of the original commit. Merge commits are not replayed, and commits whose changes are already present in {{.LessThan}}upstream{{.GreaterThan}} are skipped.
+
+If replaying a commit results in conflicts, the rebase stops and leaves the conflicts in the working set. Resolve them using {{.EmphasisLeft}}dolt conflicts{{.EmphasisRight}}, {{.EmphasisLeft}}dolt add{{.EmphasisRight}} the affected tables and run {{.EmphasisLeft}}dolt rebase --continue{{.EmphasisRight}} to commit the result and replay the remaining commits. {{.EmphasisLeft}}dolt rebase --abort{{.EmphasisRight}} can be used at any point to return the branch, the working set and the staged tables to the state they were in before the rebase started.
+
+With {{.EmphasisLeft}}--plan{{.EmphasisRight}}, the commits which are replayed and the way they are replayed are read from {{.LessThan}}file{{.GreaterThan}} instead. Each line of the plan names an action and a commit, and the steps are replayed in the order they are listed:
+
+	pick {{.LessThan}}commit{{.GreaterThan}}
+	  replay the commit.
+	squash {{.LessThan}}commit{{.GreaterThan}}
+	  meld the commit into the previous commit, combining their commit messages.
+	fixup {{.LessThan}}commit{{.GreaterThan}}
+	  meld the commit into the previous commit, keeping the message of the previous commit.
+	drop {{.LessThan}}commit{{.GreaterThan}}
+	  remove the commit.
+	reword {{.LessThan}}commit{{.GreaterThan}} {{.LessThan}}message{{.GreaterThan}}
+	  replay the commit using {{.LessThan}}message{{.GreaterThan}} as its commit message.
+
+Any text following the commit of the other actions is ignored, and empty lines and lines starting with {{.EmphasisLeft}}#{{.EmphasisRight}} are skipped. Commits of the current branch which are not listed in the plan are removed from its history.
+
+The working set must not contain any uncommitted changes when starting a rebase.
+`,
+	Synopsis: []string{
+		"[--plan {{.LessThan}}file{{.GreaterThan}}] {{.LessThan}}upstream{{.GreaterThan}}",
+		"--continue",
+		"--abort",
+	},
+}
+
+type RebaseCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd RebaseCmd) Name() string {
+	return "rebase"
+}
+
+// Description returns a description of the command
+func (cmd RebaseCmd) Description() string {
+	return "Reapply commits on top of another base commit."
+}
+
+// CreateMarkdown creates a markdown file containing the helptext for the command at the given path
+func (cmd RebaseCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) error {
+	ap := cmd.createArgParser()
+	return CreateMarkdown(fs, path, cli.GetCommandDocumentation(commandStr, rebaseDocs, ap))
+}
+
+func (cmd RebaseCmd) createArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"upstream", "The branch or commit the current branch should be rebased onto."})
+	ap.SupportsFlag(continueFlag, "", "Commit the resolved changes of the commit the rebase stopped on, and continue replaying the remaining commits.")
+	ap.SupportsFlag(abortParam, "", "Abort the current rebase and return the branch to the state it was in before the rebase started.")
+	ap.SupportsString(planParam, "", "file", "Replay the commits listed in the rebase plan {{.LessThan}}file{{.GreaterThan}} instead of the commits of the current branch.")
+	return ap
+}
+
+// Exec executes the command
+func (cmd RebaseCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cmd.createArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, rebaseDocs, ap))
+	apr := cli.ParseArgs(ap, args, help)
+
+	var verr errhand.VerboseError
+	if apr.Contains(continueFlag) || apr.Contains(abortParam) {
+		if apr.NArg() != 0 || (apr.Contains(continueFlag) && apr.Contains(abortParam)) || apr.Contains(planParam) {
+			usage()
+			return 1
+		}
+
+		if !dEnv.IsRebaseActive() {
+			verr = errhand.BuildDError("error: no rebase in progress").Build()
+		} else if apr.Contains(abortParam) {
+			verr = abortRebase(ctx, dEnv)
+		} else {
+			verr = continueRebase(ctx, dEnv)
+		}
+	} else {
+		if apr.NArg() != 1 {
+			usage()
+			return 1
+		}
+
+		planFile, _ := apr.GetValue(planParam)
+		verr = startRebase(ctx, dEnv, apr.Arg(0), planFile)
+	}
+
+	return HandleVErrAndExitCode(verr, usage)
+}
+
+func startRebase(ctx context.Context, dEnv *env.DoltEnv, upstreamSpecStr, planFile string) errhand.VerboseError {
+	if dEnv.IsRebaseActive() {
+		return errhand.BuildDError("error: a rebase is already in progress.").
+			AddDetails("Use 'dolt rebase --continue' or 'dolt rebase --abort'.").Build()
+	}
+
+	verr := checkCanApplyCommit(ctx, dEnv, "rebase")
+
+	if verr != nil {
+		return verr
+	}
+
+	upstream, verr := ResolveCommitWithVErr(dEnv, upstreamSpecStr)
+
+	if verr != nil {
+		return verr
+	}
+
+	headRef := dEnv.RepoState.CWBHeadRef()
+	head, err := dEnv.DoltDB.ResolveRef(ctx, headRef)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to resolve the head of %s", headRef.GetPath()).AddCause(err).Build()
+	}
+
+	headHash, err := head.HashOf()
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get hash of commit").AddCause(err).Build()
+	}
+
+	upstreamHash, err := upstream.HashOf()
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get hash of commit").AddCause(err).Build()
+	}
+
+	var todo []env.RebaseStep
+	if planFile != "" {
+		todo, verr = readRebasePlan(dEnv, planFile)
+	} else {
+		todo, verr = getBranchRebaseSteps(ctx, dEnv, head, upstream)
+	}
+
+	if verr != nil {
+		return verr
+	}
+
+	if todo == nil {
+		cli.Printf("Current branch %s is up to date.\n", headRef.GetPath())
+		return nil
+	}
+
+	err = dEnv.RepoState.StartRebase(&env.RebaseState{
+		OrigHead: headHash.String(),
+		Onto:     upstreamHash.String(),
+		Todo:     todo,
+	}, dEnv.FS)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to save the rebase state").AddCause(err).Build()
+	}
+
+	verr = resetBranchTo(ctx, dEnv, upstream)
+
+	if verr != nil {
+		return verr
+	}
+
+	return replayRebaseTodo(ctx, dEnv)
+}
+
+// getBranchRebaseSteps returns a pick step for each commit of the current branch which is not reachable from
+// |upstream|, or nil if |upstream| is already an ancestor of |head|.
+func getBranchRebaseSteps(ctx context.Context, dEnv *env.DoltEnv, head, upstream *doltdb.Commit) ([]env.RebaseStep, errhand.VerboseError) {
+	headHash, err := head.HashOf()
+
+	if err != nil {
+		return nil, errhand.BuildDError("error: failed to get hash of commit").AddCause(err).Build()
+	}
+
+	upstreamHash, err := upstream.HashOf()
+
+	if err != nil {
+		return nil, errhand.BuildDError("error: failed to get hash of commit").AddCause(err).Build()
+	}
+
+	ancestor, err := doltdb.GetCommitAncestor(ctx, head, upstream)
+
+	if err != nil {
+		return nil, errhand.BuildDError("error: failed to find the common ancestor of the current branch and the upstream").AddCause(err).Build()
+	}
+
+	ancHash, err := ancestor.HashOf()
+
+	if err != nil {
+		return nil, errhand.BuildDError("error: failed to get hash of commit").AddCause(err).Build()
+	}
+
+	if ancHash == upstreamHash {
+		return nil, nil
+	}
+
+	// commits are returned newest first and are replayed oldest first
+	commits, err := commitwalk.GetDotDotRevisions(ctx, dEnv.DoltDB, headHash, dEnv.DoltDB, upstreamHash, -1)
+
+	if err != nil {
+		return nil, errhand.BuildDError("error: failed to find the commits to rebase").AddCause(err).Build()
+	}
+
+	todo := []env.RebaseStep{}
+	for i := len(commits) - 1; i >= 0; i-- {
+		numParents, err := commits[i].NumParents()
+
+		if err != nil {
+			return nil, errhand.BuildDError("error: failed to read the parents of a commit").AddCause(err).Build()
+		}
+
+		if numParents > 1 {
+			continue
+		}
+
+		h, err := commits[i].HashOf()
+
+		if err != nil {
+			return nil, errhand.BuildDError("error: failed to get hash of commit").AddCause(err).Build()
+		}
+
+		todo = append(todo, env.RebaseStep{Action: string(rebase.PickAction), Commit: h.String()})
+	}
+
+	return todo, nil
+}
+
+// readRebasePlan reads the rebase plan in |planFile| and resolves the commits of each of its steps.
+func readRebasePlan(dEnv *env.DoltEnv, planFile string) ([]env.RebaseStep, errhand.VerboseError) {
+	data, err := dEnv.FS.ReadFile(planFile)
+
+	if err != nil {
+		return nil, errhand.BuildDError("error: failed to read the rebase plan '%s'", planFile).AddCause(err).Build()
+	}
+
+	steps, err := rebase.ParsePlan(string(data))
+
+	if err != nil {
+		return nil, errhand.BuildDError("error: invalid rebase plan '%s'", planFile).AddCause(err).Build()
+	}
+
+	todo := make([]env.RebaseStep, len(steps))
+	for i, step := range steps {
+		cm, verr := ResolveCommitWithVErr(dEnv, step.CommitSpec)
+
+		if verr != nil {
+			return nil, verr
+		}
+
+		numParents, err := cm.NumParents()
+
+		if err != nil {
+			return nil, errhand.BuildDError("error: failed to read the parents of commit %s", step.CommitSpec).AddCause(err).Build()
+		}
+
+		if numParents != 1 {
+			return nil, errhand.BuildDError("error: commit %s is a merge or has no parent and cannot be rebased", step.CommitSpec).Build()
+		}
+
+		h, err := cm.HashOf()
+
+		if err != nil {
+			return nil, errhand.BuildDError("error: failed to get hash of commit").AddCause(err).Build()
+		}
+
+		todo[i] = env.RebaseStep{Action: string(step.Action), Commit: h.String(), Message: step.Message}
+	}
+
+	return todo, nil
+}
+
+func continueRebase(ctx context.Context, dEnv *env.DoltEnv) errhand.VerboseError {
+	root, verr := GetWorkingWithVErr(dEnv)
+
+	if verr != nil {
+		return verr
+	}
+
+	if has, err := root.HasConflicts(ctx); err != nil {
+		return errhand.BuildDError("error: failed to get conflicts").AddCause(err).Build()
+	} else if has {
+		return errhand.BuildDError("error: you must resolve all conflicts before continuing the rebase.").
+			AddDetails("Resolve the conflicts and mark them as resolved with 'dolt add <table>'.").Build()
+	}
+
+	if dEnv.RepoState.WorkingHash() != dEnv.RepoState.StagedHash() {
+		return errhand.BuildDError("error: you have unstaged changes.").
+			AddDetails("Please stage them with 'dolt add <table>' before continuing the rebase.").Build()
+	}
+
+	rebaseState := dEnv.RepoState.Rebase
+	if rebaseState.Current != nil {
+		cm, verr := ResolveCommitWithVErr(dEnv, rebaseState.Current.Commit)
+
+		if verr != nil {
+			return verr
+		}
+
+		verr = commitRebaseStep(ctx, dEnv, *rebaseState.Current, cm)
+
+		if verr != nil {
+			return verr
+		}
+
+		rebaseState.Current = nil
+		err := dEnv.RepoState.Save(dEnv.FS)
+
+		if err != nil {
+			return errhand.BuildDError("error: failed to save the rebase state").AddCause(err).Build()
+		}
+	}
+
+	return replayRebaseTodo(ctx, dEnv)
+}
+
+func abortRebase(ctx context.Context, dEnv *env.DoltEnv) errhand.VerboseError {
+	origHead, verr := ResolveCommitWithVErr(dEnv, dEnv.RepoState.Rebase.OrigHead)
+
+	if verr != nil {
+		return verr
+	}
+
+	verr = resetBranchTo(ctx, dEnv, origHead)
+
+	if verr != nil {
+		return verr
+	}
+
+	err := dEnv.RepoState.ClearRebase(dEnv.FS)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to clear the rebase state").AddCause(err).Build()
+	}
+
+	return nil
+}
+
+// replayRebaseTodo replays the remaining commits of the active rebase onto the current branch, stopping when the
+// changes of a commit conflict with the changes which have already been replayed.
+func replayRebaseTodo(ctx context.Context, dEnv *env.DoltEnv) errhand.VerboseError {
+	rebaseState := dEnv.RepoState.Rebase
+
+	for len(rebaseState.Todo) > 0 {
+		step := rebaseState.Todo[0]
+		cmHashStr := step.Commit
+		rebaseState.Todo = rebaseState.Todo[1:]
+		rebaseState.Current = &step
+
+		err := dEnv.RepoState.Save(dEnv.FS)
+
+		if err != nil {
+			return errhand.BuildDError("error: failed to save the rebase state").AddCause(err).Build()
+		}
+
+		if step.Action == string(rebase.DropAction) {
+			rebaseState.Current = nil
+			continue
+		}
+
+		cm, verr := ResolveCommitWithVErr(dEnv, cmHashStr)
+
+		if verr != nil {
+			return verr
+		}
+
+		root, verr := GetWorkingWithVErr(dEnv)
+
+		if verr != nil {
+			return verr
+		}
+
+		mergedRoot, tblToStats, err := merge.CherryPick(ctx, dEnv.DoltDB, root, cm, merge.MergeOpts{Checks: sqlutil.NewCheckEvaluator})
+
+		if err != nil {
+			return errhand.BuildDError("error: could not apply %s", cmHashStr).AddCause(err).Build()
+		}
+
+		verr = UpdateWorkingWithVErr(dEnv, mergedRoot)
+
+		if verr != nil {
+			return verr
+		}
+
+		err = actions.SaveTrackedDocsFromWorking(ctx, dEnv)
+
+		if err != nil {
+			return errhand.BuildDError("error: failed to update docs to the new working root").AddCause(err).Build()
+		}
+
+		if printConflicts(tblToStats) {
+			cli.Println("hint: Resolve all conflicts manually, mark them as resolved with 'dolt add <table>',")
+			cli.Println("hint: then run 'dolt rebase --continue'. To abort and get back to the state before")
+			cli.Println("hint: the rebase, run 'dolt rebase --abort'.")
+			return errhand.BuildDError("error: could not apply %s", cmHashStr).Build()
+		}
+
+		verr = UpdateStagedWithVErr(dEnv, mergedRoot)
+
+		if verr != nil {
+			return verr
+		}
+
+		verr = commitRebaseStep(ctx, dEnv, step, cm)
+
+		if verr != nil {
+			return verr
+		}
+
+		rebaseState.Current = nil
+	}
+
+	err := dEnv.RepoState.ClearRebase(dEnv.FS)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to clear the rebase state").AddCause(err).Build()
+	}
+
+	cli.Printf("Successfully rebased and updated %s.\n", dEnv.RepoState.CWBHeadRef().String())
+	return nil
+}
+
+// commitRebaseStep commits the staged changes of a replayed |step|. Picked commits keep the message and author of
+// |cm|, reworded commits use the message of the step, and squashed and fixed up commits amend the previous commit.
+// Picked commits which result in no changes are skipped. Squashed and fixed up commits are picked if every commit
+// before them was skipped, as the head of the branch is still the commit the branch is being rebased onto.
+func commitRebaseStep(ctx context.Context, dEnv *env.DoltEnv, step env.RebaseStep, cm *doltdb.Commit) errhand.VerboseError {
+	meta, err := cm.GetCommitMeta()
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to read the metadata of a commit").AddCause(err).Build()
+	}
+
+	rebaseState := dEnv.RepoState.Rebase
+	switch rebase.PlanAction(step.Action) {
+	case rebase.SquashAction:
+		if rebaseState.Committed {
+			return amendHead(ctx, dEnv, func(prevMsg string) string {
+				return prevMsg + "\n\n" + meta.Description
+			})
+		}
+	case rebase.FixupAction:
+		if rebaseState.Committed {
+			return amendHead(ctx, dEnv, func(prevMsg string) string {
+				return prevMsg
+			})
+		}
+	case rebase.RewordAction:
+		meta.Description = step.Message
+	}
+
+	_, err = actions.CommitStaged(ctx, dEnv.DbData(), actions.CommitStagedProps{
+		Message:          meta.Description,
+		Date:             doltdb.CommitNowFunc(),
+		CheckForeignKeys: true,
+		Name:             meta.Name,
+		Email:            meta.Email,
+	})
+
+	if err != nil {
+		if actions.IsNothingStaged(err) {
+			return nil
+		}
+		return errhand.BuildDError("error: Failed to commit changes.").AddCause(err).Build()
+	}
+
+	rebaseState.Committed = true
+	return nil
+}
+
+// amendHead replaces the head commit of the current branch with a commit of the staged root which has the same parents
+// and author. The message of the new commit is computed from the message of the replaced commit by |msgFn|.
+func amendHead(ctx context.Context, dEnv *env.DoltEnv, msgFn func(prevMsg string) string) errhand.VerboseError {
+	headRef := dEnv.RepoState.CWBHeadRef()
+	head, err := dEnv.DoltDB.ResolveRef(ctx, headRef)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to resolve the head of %s", headRef.GetPath()).AddCause(err).Build()
+	}
+
+	parents, err := dEnv.DoltDB.ResolveAllParents(ctx, head)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to read the parents of a commit").AddCause(err).Build()
+	}
+
+	headMeta, err := head.GetCommitMeta()
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to read the metadata of a commit").AddCause(err).Build()
+	}
+
+	meta, err := doltdb.NewCommitMeta(headMeta.Name, headMeta.Email, msgFn(headMeta.Description))
+
+	if err != nil {
+		return errhand.BuildDError("error: invalid commit message").AddCause(err).Build()
+	}
+
+	amended, err := dEnv.DoltDB.CommitDanglingWithParentCommits(ctx, dEnv.RepoState.StagedHash(), parents, meta)
+
+	if err != nil {
+		return errhand.BuildDError("error: Failed to commit changes.").AddCause(err).Build()
+	}
+
+	err = dEnv.DoltDB.SetHeadToCommit(ctx, headRef, amended)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to update the head of %s", headRef.GetPath()).AddCause(err).Build()
+	}
+
+	return nil
+}
+
+// resetBranchTo points the current branch at |cm| and resets the working set and the staged tables to its root.
+func resetBranchTo(ctx context.Context, dEnv *env.DoltEnv, cm *doltdb.Commit) errhand.VerboseError {
+	err := dEnv.DoltDB.SetHeadToCommit(ctx, dEnv.RepoState.CWBHeadRef(), cm)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to update the head of %s", dEnv.RepoState.CWBHeadRef().GetPath()).AddCause(err).Build()
+	}
+
+	root, err := cm.GetRootValue()
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to read the root value of a commit").AddCause(err).Build()
+	}
+
+	verr := UpdateWorkingWithVErr(dEnv, root)
+
+	if verr != nil {
+		return verr
+	}
+
+	verr = UpdateStagedWithVErr(dEnv, root)
+
+	if verr != nil {
+		return verr
+	}
+
+	err = actions.SaveTrackedDocsFromWorking(ctx, dEnv)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to update docs to the new working root").AddCause(err).Build()
+	}
+
+	return nil
+}
@@ -0,0 +1,161 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+var revertDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Undo the changes introduced by existing commits",
+	LongDesc: `Removes the changes made by the named commits from the current branch, and records a new commit for each of them whose message references the reverted commit.
+
+The changes made by a commit are undone by merging the parent of the commit into the working set, using the commit itself as the common ancestor. The commits are reverted in the order in which they are given. Merge commits and commits without a parent cannot be reverted.
+
+If the inverse changes conflict with changes which have been made on the current branch since, the conflicts are left in the working set and the remaining commits are not reverted. Resolve them using {{.EmphasisLeft}}dolt conflicts{{.EmphasisRight}}, then {{.EmphasisLeft}}dolt add{{.EmphasisRight}} the affected tables and {{.EmphasisLeft}}dolt commit{{.EmphasisRight}} the result.
+
+The working set must not contain any uncommitted changes when running this command.
+`,
+	Synopsis: []string{
+		"[--no-commit] {{.LessThan}}commit{{.GreaterThan}}...",
+	},
+}
+
+type RevertCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd RevertCmd) Name() string {
+	return "revert"
+}
+
+// Description returns a description of the command
+func (cmd RevertCmd) Description() string {
+	return "Undo the changes introduced by existing commits."
+}
+
+// CreateMarkdown creates a markdown file containing the helptext for the command at the given path
+func (cmd RevertCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) error {
+	ap := cmd.createArgParser()
+	return CreateMarkdown(fs, path, cli.GetCommandDocumentation(commandStr, revertDocs, ap))
+}
+
+func (cmd RevertCmd) createArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"commit", "The commits whose changes should be undone."})
+	ap.SupportsFlag(noCommitFlag, "n", "Apply the inverse changes to the working set and stage them without creating commits.")
+	return ap
+}
+
+// Exec executes the command
+func (cmd RevertCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cmd.createArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, revertDocs, ap))
+	apr := cli.ParseArgs(ap, args, help)
+
+	if apr.NArg() == 0 {
+		usage()
+		return 1
+	}
+
+	verr := checkCanApplyCommit(ctx, dEnv, cmd.Name())
+
+	if verr == nil {
+		verr = revertCommits(ctx, dEnv, apr.Args(), apr.Contains(noCommitFlag))
+	}
+
+	return HandleVErrAndExitCode(verr, usage)
+}
+
+func revertCommits(ctx context.Context, dEnv *env.DoltEnv, commitSpecStrs []string, noCommit bool) errhand.VerboseError {
+	// resolve all the commits up front so that a bad commit spec doesn't leave a partially reverted branch
+	commits := make([]*doltdb.Commit, len(commitSpecStrs))
+	for i, commitSpecStr := range commitSpecStrs {
+		cm, verr := ResolveCommitWithVErr(dEnv, commitSpecStr)
+
+		if verr != nil {
+			return verr
+		}
+
+		commits[i] = cm
+	}
+
+	name, email, err := actions.GetNameAndEmail(dEnv.Config)
+
+	if err != nil {
+		return errhand.BuildDError("error: reverting").AddCause(err).Build()
+	}
+
+	for _, cm := range commits {
+		verr := revertCommit(ctx, dEnv, cm, noCommit, name, email)
+
+		if verr != nil {
+			return verr
+		}
+	}
+
+	return nil
+}
+
+func revertCommit(ctx context.Context, dEnv *env.DoltEnv, cm *doltdb.Commit, noCommit bool, name, email string) errhand.VerboseError {
+	h, err := cm.HashOf()
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get hash of commit").AddCause(err).Build()
+	}
+
+	root, verr := GetWorkingWithVErr(dEnv)
+
+	if verr != nil {
+		return verr
+	}
+
+	mergedRoot, tblToStats, err := merge.Revert(ctx, dEnv.DoltDB, root, cm, merge.MergeOpts{Checks: sqlutil.NewCheckEvaluator})
+
+	if err != nil {
+		switch err {
+		case merge.ErrCommitHasNoParent:
+			return errhand.BuildDError("error: commit %s has no parent and cannot be reverted", h.String()).Build()
+		case merge.ErrCommitIsMerge:
+			return errhand.BuildDError("error: commit %s is a merge and cannot be reverted", h.String()).Build()
+		default:
+			return errhand.BuildDError("error: failed to revert %s", h.String()).AddCause(err).Build()
+		}
+	}
+
+	meta, err := cm.GetCommitMeta()
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to read the metadata of commit %s", h.String()).AddCause(err).Build()
+	}
+
+	return applyCommitChanges(ctx, dEnv, "revert", h.String(), mergedRoot, tblToStats, noCommit, actions.CommitStagedProps{
+		Message:          fmt.Sprintf("Revert \"%s\"\n\nThis reverts commit %s.", meta.Description, h.String()),
+		Date:             doltdb.CommitNowFunc(),
+		CheckForeignKeys: true,
+		Name:             name,
+		Email:            email,
+	})
+}
@@ -0,0 +1,159 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schcmds
+
+import (
+	"context"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/alterschema"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+var addCheckDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Adds a CHECK constraint to a table.",
+	LongDesc: `{{.EmphasisLeft}}dolt schema add-check{{.EmphasisRight}} adds a CHECK constraint named {{.LessThan}}name{{.GreaterThan}} to the table {{.LessThan}}table{{.GreaterThan}} in the working set.
+
+The {{.LessThan}}expression{{.GreaterThan}} is a SQL expression that may only reference the columns of the table, such as {{.EmphasisLeft}}price > 0{{.EmphasisRight}}. Every existing row of the table must satisfy the expression, and once added, inserts, updates and merges that would create a row for which the expression is false fail. Rows for which the expression is NULL satisfy the constraint.`,
+	Synopsis: []string{
+		"{{.LessThan}}table{{.GreaterThan}} {{.LessThan}}name{{.GreaterThan}} {{.LessThan}}expression{{.GreaterThan}}",
+	},
+}
+
+type AddCheckCmd struct{}
+
+var _ cli.Command = AddCheckCmd{}
+
+func (cmd AddCheckCmd) Name() string {
+	return "add-check"
+}
+
+func (cmd AddCheckCmd) Description() string {
+	return "Adds a CHECK constraint to a table."
+}
+
+func (cmd AddCheckCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) error {
+	ap := cmd.createArgParser()
+	return commands.CreateMarkdown(fs, path, cli.GetCommandDocumentation(commandStr, addCheckDocs, ap))
+}
+
+func (cmd AddCheckCmd) createArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"table", "The table the CHECK constraint is added to."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"name", "The name of the CHECK constraint."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"expression", "The SQL expression that every row of the table must satisfy."})
+	return ap
+}
+
+func (cmd AddCheckCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cmd.createArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, addCheckDocs, ap))
+	apr := cli.ParseArgs(ap, args, help)
+
+	if apr.NArg() != 3 {
+		usage()
+		return 1
+	}
+
+	tblName, name, expression := apr.Arg(0), apr.Arg(1), apr.Arg(2)
+	verr := updateTable(ctx, dEnv, tblName, func(tblName string, tbl *doltdb.Table) (*doltdb.Table, error) {
+		return alterschema.AddCheck(ctx, tblName, tbl, name, expression)
+	})
+
+	return commands.HandleVErrAndExitCode(verr, usage)
+}
+
+var dropCheckDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Drops a CHECK constraint from a table.",
+	LongDesc:  `{{.EmphasisLeft}}dolt schema drop-check{{.EmphasisRight}} drops the CHECK constraint named {{.LessThan}}name{{.GreaterThan}} from the table {{.LessThan}}table{{.GreaterThan}} in the working set.`,
+	Synopsis: []string{
+		"{{.LessThan}}table{{.GreaterThan}} {{.LessThan}}name{{.GreaterThan}}",
+	},
+}
+
+type DropCheckCmd struct{}
+
+var _ cli.Command = DropCheckCmd{}
+
+func (cmd DropCheckCmd) Name() string {
+	return "drop-check"
+}
+
+func (cmd DropCheckCmd) Description() string {
+	return "Drops a CHECK constraint from a table."
+}
+
+func (cmd DropCheckCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) error {
+	ap := cmd.createArgParser()
+	return commands.CreateMarkdown(fs, path, cli.GetCommandDocumentation(commandStr, dropCheckDocs, ap))
+}
+
+func (cmd DropCheckCmd) createArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"table", "The table the CHECK constraint is dropped from."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"name", "The name of the CHECK constraint."})
+	return ap
+}
+
+func (cmd DropCheckCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cmd.createArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, dropCheckDocs, ap))
+	apr := cli.ParseArgs(ap, args, help)
+
+	if apr.NArg() != 2 {
+		usage()
+		return 1
+	}
+
+	tblName, name := apr.Arg(0), apr.Arg(1)
+	verr := updateTable(ctx, dEnv, tblName, func(tblName string, tbl *doltdb.Table) (*doltdb.Table, error) {
+		return alterschema.DropCheck(ctx, tbl, name)
+	})
+
+	return commands.HandleVErrAndExitCode(verr, usage)
+}
+
+// updateTable applies |update| to the table with the given case-insensitive name in the working set.
+func updateTable(ctx context.Context, dEnv *env.DoltEnv, tblName string, update func(string, *doltdb.Table) (*doltdb.Table, error)) errhand.VerboseError {
+	root, verr := commands.GetWorkingWithVErr(dEnv)
+	if verr != nil {
+		return verr
+	}
+
+	tbl, tblName, ok, err := root.GetTableInsensitive(ctx, tblName)
+	if err != nil {
+		return errhand.BuildDError("Unable to get table %s.", tblName).AddCause(err).Build()
+	}
+	if !ok {
+		return errhand.BuildDError("Table %s does not exist.", tblName).Build()
+	}
+
+	tbl, err = update(tblName, tbl)
+	if err != nil {
+		return errhand.BuildDError("Unable to update table %s.", tblName).AddCause(err).Build()
+	}
+
+	root, err = root.PutTable(ctx, tblName, tbl)
+	if err != nil {
+		return errhand.BuildDError("Unable to update table %s.", tblName).AddCause(err).Build()
+	}
+
+	return commands.UpdateWorkingWithVErr(dEnv, root)
+}
@@ -21,6 +21,8 @@ import (
 )
 
 var Commands = cli.NewSubCommandHandler("schema", "Commands for showing and importing table schemas.", []cli.Command{
+	AddCheckCmd{},
+	DropCheckCmd{},
 	ExportCmd{},
 	ImportCmd{},
 	ShowCmd{},
@@ -16,6 +16,7 @@ package schcmds
 
 import (
 	"context"
+	"strings"
 
 	"github.com/fatih/color"
 
@@ -25,7 +26,9 @@ import (
 	eventsapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	dsqle "github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlfmt"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 )
@@ -150,7 +153,15 @@ func printSchemas(ctx context.Context, apr *argparser.ArgParseResults, dEnv *env
 				if err != nil {
 					return errhand.VerboseErrorFromError(err)
 				}
-				cli.Println(stmt)
+				tbl, _, err := root.GetTable(ctx, tblName)
+				if err != nil {
+					return errhand.BuildDError("unable to get table '%s'", tblName).AddCause(err).Build()
+				}
+				sch, err := tbl.GetSchema(ctx)
+				if err != nil {
+					return errhand.BuildDError("unable to get schema of '%s'", tblName).AddCause(err).Build()
+				}
+				cli.Println(withChecks(stmt, sch))
 				cli.Println()
 			}
 		}
@@ -162,3 +173,19 @@ func printSchemas(ctx context.Context, apr *argparser.ArgParseResults, dEnv *env
 
 	return verr
 }
+
+// withChecks adds the CHECK constraints of |sch| to the definitions of a CREATE TABLE statement, as the SQL engine
+// does not know about them.
+func withChecks(stmt string, sch schema.Schema) string {
+	checks := sch.Checks().AllChecks()
+	end := strings.LastIndex(stmt, "\n)")
+	if len(checks) == 0 || end == -1 {
+		return stmt
+	}
+
+	defs := make([]string, len(checks))
+	for i, check := range checks {
+		defs[i] = ",\n  " + sqlfmt.FmtCheck(check)
+	}
+	return stmt[:end] + strings.Join(defs, "") + stmt[end:]
+}
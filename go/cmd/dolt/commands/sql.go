@@ -224,6 +224,10 @@ func (cmd SqlCmd) Exec(ctx context.Context, commandStr string, args []string, dE
 		}
 	}
 
+	for dbName, dbEnv := range mrEnv {
+		dsess.SetHookConfig(dbName, dbEnv.Config)
+	}
+
 	sqlCtx := sql.NewContext(ctx,
 		sql.WithSession(dsess),
 		sql.WithIndexRegistry(sql.NewIndexRegistry()),
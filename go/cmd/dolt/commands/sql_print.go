@@ -16,6 +16,7 @@ package commands
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"strconv"
@@ -74,6 +75,14 @@ func sqlColToStr(col interface{}) string {
 			return strconv.FormatFloat(float64(typedCol), 'g', -1, 32)
 		case string:
 			return typedCol
+		case []byte:
+			return string(typedCol)
+		case map[string]interface{}, []interface{}:
+			// JSON objects and arrays, such as those extracted from JSON documents
+			str, err := json.Marshal(typedCol)
+			if err == nil {
+				return string(str)
+			}
 		case bool:
 			if typedCol {
 				return "true"
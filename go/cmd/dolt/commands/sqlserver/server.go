@@ -35,6 +35,7 @@ import (
 	dsqle "github.com/dolthub/dolt/go/libraries/doltcore/sqle"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dfunctions"
 	_ "github.com/dolthub/dolt/go/libraries/doltcore/sqle/dfunctions"
+	"github.com/dolthub/dolt/go/libraries/utils/config"
 )
 
 // Serve starts a MySQL-compatible server. Returns any errors that were encountered.
@@ -75,15 +76,15 @@ func Serve(ctx context.Context, version string, serverConfig ServerConfig, serve
 		logrus.SetLevel(level)
 	}
 
-	permissions := auth.AllPermissions
-	if serverConfig.ReadOnly() {
-		permissions = auth.ReadPerm
-	}
-
-	userAuth := auth.NewAudit(auth.NewNativeSingle(serverConfig.User(), serverConfig.Password(), permissions), auth.NewAuditLog(logrus.StandardLogger()))
+	sa := newServerAuth(serverConfig)
+	userAuth := auth.NewAudit(sa, auth.NewAuditLog(logrus.StandardLogger()))
 
 	c := sql.NewCatalog()
-	a := analyzer.NewBuilder(c).WithParallelism(serverConfig.QueryParallelism()).Build()
+	a := analyzer.NewBuilder(c).
+		WithParallelism(serverConfig.QueryParallelism()).
+		AddPreAnalyzeRule(dsqle.ResolveBranchDatabasesRule, dsqle.ResolveBranchDatabases).
+		AddPreAnalyzeRule(dsqle.ResolveTransactionsRule, dsqle.ResolveTransactions).
+		Build()
 	sqlEngine := sqle.New(c, a, nil)
 
 	err := sqlEngine.Catalog.Register(dfunctions.DoltFunctions...)
@@ -117,12 +118,24 @@ func Serve(ctx context.Context, version string, serverConfig ServerConfig, serve
 
 	dbs := commands.CollectDBs(mrEnv, newDatabase)
 
+	// the hooks run by commits to each database are the ones configured for its repository
+	hookCfgs := make(map[string]config.ReadableConfig)
+	for name, dbEnv := range mrEnv {
+		hookCfgs[name] = dbEnv.Config
+	}
+
 	for _, db := range dbs {
 		sqlEngine.AddDatabase(db)
 	}
 
 	sqlEngine.AddDatabase(information_schema.NewInformationSchemaDatabase(sqlEngine.Catalog))
 
+	tlsConfig, startError := LoadTLSConfig(serverConfig)
+	if startError != nil {
+		cli.PrintErr(startError)
+		return
+	}
+
 	hostPort := net.JoinHostPort(serverConfig.Host(), strconv.Itoa(serverConfig.Port()))
 	readTimeout := time.Duration(serverConfig.ReadTimeout()) * time.Millisecond
 	writeTimeout := time.Duration(serverConfig.WriteTimeout()) * time.Millisecond
@@ -138,7 +151,7 @@ func Serve(ctx context.Context, version string, serverConfig ServerConfig, serve
 			// to the value of mysql that we support.
 		},
 		sqlEngine,
-		newSessionBuilder(sqlEngine, username, email, serverConfig.AutoCommit()),
+		newSessionBuilder(sqlEngine, sa, dbs, username, email, hookCfgs, serverConfig.AutoCommit()),
 	)
 
 	if startError != nil {
@@ -146,6 +159,9 @@ func Serve(ctx context.Context, version string, serverConfig ServerConfig, serve
 		return
 	}
 
+	mySQLServer.Listener.TLSConfig = tlsConfig
+	mySQLServer.Listener.RequireSecureTransport = serverConfig.RequireSecureTransport()
+
 	serverController.registerCloseFunction(startError, mySQLServer.Close)
 	closeError = mySQLServer.Start()
 	if closeError != nil {
@@ -155,15 +171,20 @@ func Serve(ctx context.Context, version string, serverConfig ServerConfig, serve
 	return
 }
 
-func newSessionBuilder(sqlEngine *sqle.Engine, username, email string, autocommit bool) server.SessionBuilder {
+func newSessionBuilder(sqlEngine *sqle.Engine, sa *serverAuth, repoDbs []dsqle.Database, username, email string, hookCfgs map[string]config.ReadableConfig, autocommit bool) server.SessionBuilder {
 	return func(ctx context.Context, conn *mysql.Conn, host string) (sql.Session, *sql.IndexRegistry, *sql.ViewRegistry, error) {
 		mysqlSess := sql.NewSession(host, conn.RemoteAddr().String(), conn.User, conn.ConnectionID)
-		doltSess, err := dsqle.NewDoltSession(ctx, mysqlSess, username, email, dbsAsDSQLDBs(sqlEngine.Catalog.AllDatabases())...)
+		doltSess, err := dsqle.NewDoltSession(ctx, mysqlSess, username, email, repoDbs...)
 
 		if err != nil {
 			return nil, nil, nil, err
 		}
 
+		doltSess.SetGrants(sa.grants(conn.User))
+		for repoName, cfg := range hookCfgs {
+			doltSess.SetHookConfig(repoName, cfg)
+		}
+
 		err = doltSess.Set(ctx, sql.AutoCommitSessionVar, sql.Boolean, autocommit)
 
 		if err != nil {
@@ -178,8 +199,7 @@ func newSessionBuilder(sqlEngine *sqle.Engine, username, email string, autocommi
 			sql.WithViewRegistry(vr),
 			sql.WithSession(doltSess))
 
-		dbs := dbsAsDSQLDBs(sqlEngine.Catalog.AllDatabases())
-		for _, db := range dbs {
+		for _, db := range repoDbs {
 			err := db.LoadRootFromRepoState(sqlCtx)
 			if err != nil {
 				return nil, nil, nil, err
@@ -205,17 +225,3 @@ func newSessionBuilder(sqlEngine *sqle.Engine, username, email string, autocommi
 func newDatabase(name string, dEnv *env.DoltEnv) dsqle.Database {
 	return dsqle.NewDatabase(name, dEnv.DbData())
 }
-
-func dbsAsDSQLDBs(dbs []sql.Database) []dsqle.Database {
-	dsqlDBs := make([]dsqle.Database, 0, len(dbs))
-
-	for _, db := range dbs {
-		dsqlDB, ok := db.(dsqle.Database)
-
-		if ok {
-			dsqlDBs = append(dsqlDBs, dsqlDB)
-		}
-	}
-
-	return dsqlDBs
-}
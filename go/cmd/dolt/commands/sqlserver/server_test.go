@@ -15,8 +15,20 @@
 package sqlserver
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"database/sql"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/gocraft/dbr/v2"
@@ -25,6 +37,7 @@ import (
 	"golang.org/x/net/context"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
 )
 
 type testPerson struct {
@@ -215,4 +228,440 @@ func TestServerSelect(t *testing.T) {
 			assert.ElementsMatch(t, peoples, test.expectedRes)
 		})
 	}
+
+	t.Run("DOLT_DIFF", func(t *testing.T) {
+		var count int
+		err := conn.QueryRow("SELECT COUNT(*) FROM `DOLT_DIFF('people', 'HEAD', 'WORKING')` WHERE diff_type = 'added'").Scan(&count)
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+	})
+}
+
+func TestServerBranchDatabases(t *testing.T) {
+	ctx := context.Background()
+	env := dtestutils.CreateEnvWithSeedData(t)
+	serverConfig := DefaultServerConfig().withLogLevel(LogLevel_Fatal).withPort(15301).withMaxConnections(4)
+
+	sc := CreateServerController()
+	defer sc.StopServer()
+	go func() {
+		_, _ = Serve(context.Background(), "", serverConfig, sc, env)
+	}()
+	err := sc.WaitForStart()
+	require.NoError(t, err)
+
+	db, err := sql.Open("mysql", ConnectionString(serverConfig))
+	require.NoError(t, err)
+	defer db.Close()
+
+	newConn := func(dbName string) *sql.Conn {
+		conn, err := db.Conn(ctx)
+		require.NoError(t, err)
+		_, err = conn.ExecContext(ctx, "USE `"+dbName+"`")
+		require.NoError(t, err)
+		return conn
+	}
+
+	countPeople := func(conn *sql.Conn, query string) int {
+		var count int
+		err := conn.QueryRowContext(ctx, query).Scan(&count)
+		require.NoError(t, err)
+		return count
+	}
+
+	master := newConn("dolt")
+	defer master.Close()
+	_, err = master.ExecContext(ctx, "SELECT DOLT_COMMIT('-a', '-m', 'seed data')")
+	require.NoError(t, err)
+
+	// branches created after the server started are available to new connections
+	err = actions.CreateBranch(ctx, env, "feature", "master", false)
+	require.NoError(t, err)
+
+	feature := newConn("dolt/feature")
+	defer feature.Close()
+	_, err = feature.ExecContext(ctx, "DELETE FROM people WHERE age = 21")
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, countPeople(feature, "SELECT COUNT(*) FROM people"))
+	assert.Equal(t, 3, countPeople(master, "SELECT COUNT(*) FROM people"))
+
+	// each connection keeps its own working set of a branch which isn't checked out
+	otherFeature := newConn("dolt/feature")
+	defer otherFeature.Close()
+	assert.Equal(t, 3, countPeople(otherFeature, "SELECT COUNT(*) FROM people"))
+
+	_, err = feature.ExecContext(ctx, "SELECT DOLT_COMMIT('-a', '-m', 'deleted rob')")
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, countPeople(master, "SELECT COUNT(*) FROM people AS OF 'feature'"))
+	assert.Equal(t, 3, countPeople(master, "SELECT COUNT(*) FROM people AS OF 'master'"))
+
+	masterAlias := newConn("dolt/master")
+	defer masterAlias.Close()
+	assert.Equal(t, 3, countPeople(masterAlias, "SELECT COUNT(*) FROM people"))
+}
+
+func TestServerTransactions(t *testing.T) {
+	ctx := context.Background()
+	env := dtestutils.CreateEnvWithSeedData(t)
+	serverConfig := DefaultServerConfig().withLogLevel(LogLevel_Fatal).withPort(15302).withMaxConnections(4)
+
+	sc := CreateServerController()
+	defer sc.StopServer()
+	go func() {
+		_, _ = Serve(context.Background(), "", serverConfig, sc, env)
+	}()
+	err := sc.WaitForStart()
+	require.NoError(t, err)
+
+	db, err := sql.Open("mysql", ConnectionString(serverConfig)+"dolt")
+	require.NoError(t, err)
+	defer db.Close()
+
+	newConn := func() *sql.Conn {
+		conn, err := db.Conn(ctx)
+		require.NoError(t, err)
+		return conn
+	}
+
+	exec := func(conn *sql.Conn, query string) {
+		_, err := conn.ExecContext(ctx, query)
+		require.NoError(t, err)
+	}
+
+	queryInt := func(conn *sql.Conn, query string) int {
+		var n int
+		err := conn.QueryRowContext(ctx, query).Scan(&n)
+		require.NoError(t, err)
+		return n
+	}
+
+	conn1 := newConn()
+	defer conn1.Close()
+	conn2 := newConn()
+	defer conn2.Close()
+
+	// a transaction reads from the snapshot it started with
+	exec(conn1, "BEGIN")
+	assert.Equal(t, 3, queryInt(conn1, "SELECT COUNT(*) FROM people"))
+	exec(conn2, "INSERT INTO people (id, name, age, is_married, title) VALUES ('00000000-0000-0000-0000-000000000010', 'Jane Janeson', 40, true, 'Boss')")
+	assert.Equal(t, 3, queryInt(conn1, "SELECT COUNT(*) FROM people"))
+
+	// committing merges the changes of both clients
+	exec(conn1, "INSERT INTO people (id, name, age, is_married, title) VALUES ('00000000-0000-0000-0000-000000000011', 'Joe Joeson', 50, false, 'Intern')")
+	assert.Equal(t, 4, queryInt(conn2, "SELECT COUNT(*) FROM people"))
+	exec(conn1, "COMMIT")
+	assert.Equal(t, 5, queryInt(conn1, "SELECT COUNT(*) FROM people"))
+	assert.Equal(t, 5, queryInt(conn2, "SELECT COUNT(*) FROM people"))
+
+	// rolled back changes are discarded
+	exec(conn1, "BEGIN")
+	exec(conn1, "DELETE FROM people WHERE age > 30")
+	assert.Equal(t, 2, queryInt(conn1, "SELECT COUNT(*) FROM people"))
+	exec(conn1, "ROLLBACK")
+	assert.Equal(t, 5, queryInt(conn1, "SELECT COUNT(*) FROM people"))
+	assert.Equal(t, 5, queryInt(conn2, "SELECT COUNT(*) FROM people"))
+
+	// edits to different columns of the same row are merged
+	exec(conn1, "BEGIN")
+	exec(conn1, "UPDATE people SET age = 33 WHERE name = 'Bill Billerson'")
+	exec(conn2, "UPDATE people SET title = 'Dufus Emeritus' WHERE name = 'Bill Billerson'")
+	exec(conn1, "COMMIT")
+	var age int
+	var title string
+	err = conn2.QueryRowContext(ctx, "SELECT age, title FROM people WHERE name = 'Bill Billerson'").Scan(&age, &title)
+	require.NoError(t, err)
+	assert.Equal(t, 33, age)
+	assert.Equal(t, "Dufus Emeritus", title)
+
+	// conflicting edits fail to commit
+	exec(conn1, "BEGIN")
+	exec(conn1, "UPDATE people SET age = 26 WHERE name = 'John Johnson'")
+	exec(conn2, "UPDATE people SET age = 27 WHERE name = 'John Johnson'")
+	_, err = conn1.ExecContext(ctx, "COMMIT")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "serialization failure")
+	assert.Equal(t, 27, queryInt(conn1, "SELECT age FROM people WHERE name = 'John Johnson'"))
+
+	// merge strategies don't resolve conflicting edits of concurrent transactions
+	exec(conn1, "INSERT INTO dolt_merge_strategies VALUES ('people', 'age', 'max', NULL)")
+	exec(conn1, "BEGIN")
+	exec(conn1, "UPDATE people SET age = 29 WHERE name = 'John Johnson'")
+	exec(conn2, "UPDATE people SET age = 28 WHERE name = 'John Johnson'")
+	_, err = conn1.ExecContext(ctx, "COMMIT")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "serialization failure")
+	assert.Equal(t, 28, queryInt(conn1, "SELECT age FROM people WHERE name = 'John Johnson'"))
+
+	// without autocommit, changes are committed by COMMIT
+	exec(conn1, "SET autocommit = 0")
+	exec(conn1, "DELETE FROM people WHERE name = 'John Johnson'")
+	assert.Equal(t, 5, queryInt(conn2, "SELECT COUNT(*) FROM people"))
+	exec(conn1, "COMMIT")
+	assert.Equal(t, 4, queryInt(conn2, "SELECT COUNT(*) FROM people"))
+}
+
+func TestServerUserGrants(t *testing.T) {
+	ctx := context.Background()
+	env := dtestutils.CreateEnvWithSeedData(t)
+	serverConfig := DefaultServerConfig().withLogLevel(LogLevel_Fatal).withPort(15303).withMaxConnections(4).withPassword("rootpass").withUsers([]UserConfig{
+		{Name: "analyst", Password: "pass1", Grants: []UserGrant{{Database: "dolt", Branch: "master", Permissions: []string{"read"}}}},
+		{Name: "etl", Password: "pass2", Grants: []UserGrant{{Database: "*", Permissions: []string{"read", "write"}}}},
+		{Name: "dev", Password: "pass3", Grants: []UserGrant{
+			{Database: "dolt", Permissions: []string{"read"}},
+			{Database: "dolt", Branch: "feature", Permissions: []string{"write"}},
+		}},
+	})
+
+	sc := CreateServerController()
+	defer sc.StopServer()
+	go func() {
+		_, _ = Serve(context.Background(), "", serverConfig, sc, env)
+	}()
+	err := sc.WaitForStart()
+	require.NoError(t, err)
+
+	root, err := sql.Open("mysql", ConnectionString(serverConfig)+"dolt")
+	require.NoError(t, err)
+	defer root.Close()
+	_, err = root.ExecContext(ctx, "SELECT DOLT_COMMIT('-a', '-m', 'seed data')")
+	require.NoError(t, err)
+	err = actions.CreateBranch(ctx, env, "feature", "master", false)
+	require.NoError(t, err)
+
+	// a commit only found on feature
+	featureConn, err := root.Conn(ctx)
+	require.NoError(t, err)
+	defer featureConn.Close()
+	_, err = featureConn.ExecContext(ctx, "USE `dolt/feature`")
+	require.NoError(t, err)
+	_, err = featureConn.ExecContext(ctx, "UPDATE people SET title = 'Secret Agent' WHERE age = 21")
+	require.NoError(t, err)
+	var featureHash, masterHash string
+	err = featureConn.QueryRowContext(ctx, "SELECT DOLT_COMMIT('-a', '-m', 'secret')").Scan(&featureHash)
+	require.NoError(t, err)
+	err = featureConn.QueryRowContext(ctx, "SELECT HASHOF('master')").Scan(&masterHash)
+	require.NoError(t, err)
+
+	connect := func(user, password string) *sql.DB {
+		db, err := sql.Open("mysql", user+":"+password+"@tcp(localhost:15303)/")
+		require.NoError(t, err)
+		return db
+	}
+
+	// the default root user without a password can't bypass the grants of the users
+	noPassword := connect("root", "")
+	defer noPassword.Close()
+	assert.Error(t, noPassword.PingContext(ctx))
+
+	tests := []struct {
+		name   string
+		user   string
+		pass   string
+		dbName string
+		query  string
+		errMsg string
+	}{
+		{"analyst reads master", "analyst", "pass1", "dolt", "SELECT COUNT(*) FROM people", ""},
+		{"analyst can't write master", "analyst", "pass1", "dolt", "DELETE FROM people WHERE age = 21", "does not have write permission"},
+		{"analyst can't read feature", "analyst", "pass1", "dolt/feature", "SELECT COUNT(*) FROM people", "does not have read permission"},
+		{"analyst can't read feature as of", "analyst", "pass1", "dolt", "SELECT COUNT(*) FROM people AS OF 'feature'", "does not have read permission"},
+		{"analyst can't read feature commit as of", "analyst", "pass1", "dolt", "SELECT COUNT(*) FROM people AS OF '" + featureHash + "'", "does not have read permission"},
+		{"analyst can't diff feature", "analyst", "pass1", "dolt", "SELECT COUNT(*) FROM `DOLT_DIFF('people', 'feature', 'master')`", "does not have read permission"},
+		{"analyst can't diff feature ancestors", "analyst", "pass1", "dolt", "SELECT COUNT(*) FROM `DOLT_DIFF('people', 'master', 'refs/heads/feature~1')`", "does not have read permission"},
+		{"analyst can't diff feature commit", "analyst", "pass1", "dolt", "SELECT COUNT(*) FROM `DOLT_DIFF('people', '" + featureHash + "', 'master')`", "does not have read permission"},
+		{"analyst can't commit diff feature", "analyst", "pass1", "dolt", "SELECT COUNT(*) FROM dolt_commit_diff_people WHERE from_commit = 'feature' AND to_commit = 'master'", "does not have read permission"},
+		{"analyst can't commit diff feature commit", "analyst", "pass1", "dolt", "SELECT COUNT(*) FROM dolt_commit_diff_people WHERE from_commit = 'master' AND to_commit = '" + featureHash + "'", "does not have read permission"},
+		{"analyst diffs master commit", "analyst", "pass1", "dolt", "SELECT COUNT(*) FROM `DOLT_DIFF('people', '" + masterHash + "', 'WORKING')`", ""},
+		{"analyst commit diffs master", "analyst", "pass1", "dolt", "SELECT COUNT(*) FROM dolt_commit_diff_people WHERE from_commit = 'HEAD' AND to_commit = '" + masterHash + "'", ""},
+		{"wrong password", "analyst", "pass2", "dolt", "SELECT COUNT(*) FROM people", "Access denied"},
+		{"dev reads master", "dev", "pass3", "dolt", "SELECT COUNT(*) FROM people", ""},
+		{"dev can't write master", "dev", "pass3", "dolt", "DELETE FROM people WHERE age = 21", "does not have write permission"},
+		{"dev can't commit master", "dev", "pass3", "dolt", "SELECT DOLT_COMMIT('--allow-empty', '-m', 'empty')", "does not have write permission"},
+		{"dev can't create branches", "dev", "pass3", "dolt", "INSERT INTO dolt_branches (name, hash) VALUES ('other', hashof('master'))", "does not have write permission"},
+		{"dev writes feature", "dev", "pass3", "dolt/feature", "DELETE FROM people WHERE age = 21", ""},
+		{"etl writes master", "etl", "pass2", "dolt", "DELETE FROM people WHERE age = 32", ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db := connect(test.user, test.pass)
+			defer db.Close()
+
+			conn, err := db.Conn(ctx)
+			if err == nil {
+				defer conn.Close()
+				_, err = conn.ExecContext(ctx, "USE `"+test.dbName+"`")
+			}
+			if err == nil {
+				_, err = conn.ExecContext(ctx, test.query)
+			}
+
+			if test.errMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.errMsg)
+			}
+		})
+	}
+
+	db := connect("etl", "pass2")
+	defer db.Close()
+	var count int
+	err = db.QueryRowContext(ctx, "SELECT COUNT(*) FROM dolt.people").Scan(&count)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
+
+func TestServerTLS(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dolt-sql-server-tls")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	keyPath, certPath := writeSelfSignedCert(t, dir)
+
+	env := dtestutils.CreateEnvWithSeedData(t)
+	serverConfig := DefaultServerConfig().withLogLevel(LogLevel_Fatal).withPort(15304).withTLS(keyPath, certPath, true)
+
+	sc := CreateServerController()
+	defer sc.StopServer()
+	go func() {
+		_, _ = Serve(context.Background(), "", serverConfig, sc, env)
+	}()
+	err = sc.WaitForStart()
+	require.NoError(t, err)
+
+	queryCount := func(dsn string) (int, error) {
+		db, err := sql.Open("mysql", dsn)
+		require.NoError(t, err)
+		defer db.Close()
+
+		var count int
+		err = db.QueryRow("SELECT COUNT(*) FROM people").Scan(&count)
+		return count, err
+	}
+
+	count, err := queryCount(ConnectionString(serverConfig) + "dolt?tls=skip-verify")
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	_, err = queryCount(ConnectionString(serverConfig) + "dolt")
+	assert.Error(t, err)
+}
+
+func TestServerBadTLSConfig(t *testing.T) {
+	env := dtestutils.CreateEnvWithSeedData(t)
+
+	tests := []*commandLineServerConfig{
+		DefaultServerConfig().withPort(15305).withTLS("key.pem", "", false),
+		DefaultServerConfig().withPort(15306).withTLS("", "", true),
+		DefaultServerConfig().withPort(15307).withTLS("missing-key.pem", "missing-cert.pem", false),
+	}
+
+	for _, test := range tests {
+		sc := CreateServerController()
+		go func(config ServerConfig, sc *ServerController) {
+			_, _ = Serve(context.Background(), "", config, sc, env)
+		}(test, sc)
+
+		err := sc.WaitForStart()
+		require.Error(t, err)
+		sc.StopServer()
+		err = sc.WaitForClose()
+		assert.NoError(t, err)
+	}
+}
+
+// writeSelfSignedCert writes a self signed certificate for localhost and its key to the directory given, returning
+// their paths.
+func writeSelfSignedCert(t *testing.T, dir string) (keyPath, certPath string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	keyPath = filepath.Join(dir, "key.pem")
+	certPath = filepath.Join(dir, "cert.pem")
+	err = ioutil.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)
+	require.NoError(t, err)
+	err = ioutil.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600)
+	require.NoError(t, err)
+
+	return keyPath, certPath
+}
+
+func TestServerDeletedBranchDatabases(t *testing.T) {
+	ctx := context.Background()
+	env := dtestutils.CreateEnvWithSeedData(t)
+	serverConfig := DefaultServerConfig().withLogLevel(LogLevel_Fatal).withPort(15308).withMaxConnections(4)
+
+	sc := CreateServerController()
+	defer sc.StopServer()
+	go func() {
+		_, _ = Serve(context.Background(), "", serverConfig, sc, env)
+	}()
+	err := sc.WaitForStart()
+	require.NoError(t, err)
+
+	db, err := sql.Open("mysql", ConnectionString(serverConfig))
+	require.NoError(t, err)
+	defer db.Close()
+
+	newConn := func() *sql.Conn {
+		conn, err := db.Conn(ctx)
+		require.NoError(t, err)
+		return conn
+	}
+
+	master := newConn()
+	defer master.Close()
+	_, err = master.ExecContext(ctx, "USE dolt")
+	require.NoError(t, err)
+	_, err = master.ExecContext(ctx, "SELECT DOLT_COMMIT('-a', '-m', 'seed data')")
+	require.NoError(t, err)
+	err = actions.CreateBranch(ctx, env, "feature", "master", false)
+	require.NoError(t, err)
+
+	feature := newConn()
+	defer feature.Close()
+	_, err = feature.ExecContext(ctx, "USE `dolt/feature`")
+	require.NoError(t, err)
+	_, err = feature.ExecContext(ctx, "DELETE FROM people WHERE age = 21")
+	require.NoError(t, err)
+
+	_, err = master.ExecContext(ctx, "DELETE FROM dolt_branches WHERE name = 'feature'")
+	require.NoError(t, err)
+
+	// the database of a deleted branch stays in the catalog, but can't be selected
+	deleted := newConn()
+	defer deleted.Close()
+	_, err = deleted.ExecContext(ctx, "USE `dolt/feature`")
+	assert.Error(t, err)
+
+	// a new branch of the same name starts at its head commit
+	err = actions.CreateBranch(ctx, env, "feature", "master", false)
+	require.NoError(t, err)
+
+	recreated := newConn()
+	defer recreated.Close()
+	_, err = recreated.ExecContext(ctx, "USE `dolt/feature`")
+	require.NoError(t, err)
+
+	var count int
+	err = recreated.QueryRowContext(ctx, "SELECT COUNT(*) FROM people").Scan(&count)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
 }
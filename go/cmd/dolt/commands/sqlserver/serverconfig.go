@@ -15,8 +15,12 @@
 package sqlserver
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/auth"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 )
@@ -66,6 +70,25 @@ func (level LogLevel) String() string {
 	}
 }
 
+// UserGrant gives a user permissions on a database, or on a single branch of a database.
+type UserGrant struct {
+	// Database is the name of the database, or * for every database
+	Database string
+	// Branch is the name of the branch, or * or empty for every branch
+	Branch string
+	// Permissions are the names of the permissions granted, read and write
+	Permissions []string
+}
+
+// UserConfig is an account which connections may use in addition to the one given by User and Password. Its access
+// is limited to the databases and branches it was granted. When any are configured, the account given by User and
+// Password may only be used if it has a password.
+type UserConfig struct {
+	Name     string
+	Password string
+	Grants   []UserGrant
+}
+
 // ServerConfig contains all of the configurable options for the MySQL-compatible server.
 type ServerConfig interface {
 	// Host returns the domain that the server will run on. Accepts an IPv4 or IPv6 address, in addition to localhost.
@@ -94,6 +117,14 @@ type ServerConfig interface {
 	MaxConnections() uint64
 	// QueryParallelism returns the parallelism that should be used by the go-mysql-server analyzer
 	QueryParallelism() int
+	// Users returns the user accounts with limited permissions that clients may use in addition to User.
+	Users() []UserConfig
+	// TLSKey returns a path to the servers PEM-encoded private TLS key. "" if there is none.
+	TLSKey() string
+	// TLSCert returns a path to the servers PEM-encoded TLS certificate chain. "" if there is none.
+	TLSCert() string
+	// RequireSecureTransport is true if the server should reject non-TLS connections.
+	RequireSecureTransport() bool
 }
 
 type commandLineServerConfig struct {
@@ -108,6 +139,10 @@ type commandLineServerConfig struct {
 	autoCommit       bool
 	maxConnections   uint64
 	queryParallelism int
+	users            []UserConfig
+	tlsKey           string
+	tlsCert          string
+	requireSecure    bool
 }
 
 // Host returns the domain that the server will run on. Accepts an IPv4 or IPv6 address, in addition to localhost.
@@ -165,6 +200,26 @@ func (cfg *commandLineServerConfig) QueryParallelism() int {
 	return cfg.queryParallelism
 }
 
+// Users returns the user accounts with limited permissions that clients may use in addition to User.
+func (cfg *commandLineServerConfig) Users() []UserConfig {
+	return cfg.users
+}
+
+// TLSKey returns a path to the servers PEM-encoded private TLS key. "" if there is none.
+func (cfg *commandLineServerConfig) TLSKey() string {
+	return cfg.tlsKey
+}
+
+// TLSCert returns a path to the servers PEM-encoded TLS certificate chain. "" if there is none.
+func (cfg *commandLineServerConfig) TLSCert() string {
+	return cfg.tlsCert
+}
+
+// RequireSecureTransport is true if the server should reject non-TLS connections.
+func (cfg *commandLineServerConfig) RequireSecureTransport() bool {
+	return cfg.requireSecure
+}
+
 // DatabaseNamesAndPaths returns an array of env.EnvNameAndPathObjects corresponding to the databases to be loaded in
 // a multiple db configuration. If nil is returned the server will look for a database in the current directory and
 // give it a name automatically.
@@ -227,6 +282,22 @@ func (cfg *commandLineServerConfig) withQueryParallelism(queryParallelism int) *
 	return cfg
 }
 
+// withUsers updates the additional user accounts and returns the called `*commandLineServerConfig`, which is useful for
+// chaining calls.
+func (cfg *commandLineServerConfig) withUsers(users []UserConfig) *commandLineServerConfig {
+	cfg.users = users
+	return cfg
+}
+
+// withTLS updates the TLS key and certificate paths and whether non-TLS connections are rejected, and returns the
+// called `*commandLineServerConfig`, which is useful for chaining calls.
+func (cfg *commandLineServerConfig) withTLS(keyPath, certPath string, requireSecure bool) *commandLineServerConfig {
+	cfg.tlsKey = keyPath
+	cfg.tlsCert = certPath
+	cfg.requireSecure = requireSecure
+	return cfg
+}
+
 func (cfg *commandLineServerConfig) withDBNamesAndPaths(dbNamesAndPaths []env.EnvNameAndPath) *commandLineServerConfig {
 	cfg.dbNamesAndPaths = dbNamesAndPaths
 	return cfg
@@ -265,9 +336,56 @@ func ValidateConfig(config ServerConfig) error {
 	if config.LogLevel().String() == "unknown" {
 		return fmt.Errorf("loglevel is invalid: %v\n", string(config.LogLevel()))
 	}
+
+	if (config.TLSKey() == "") != (config.TLSCert() == "") {
+		return fmt.Errorf("tls_key and tls_cert must both be set or both be empty")
+	}
+	if config.RequireSecureTransport() && config.TLSKey() == "" {
+		return fmt.Errorf("require_secure_transport requires tls_key and tls_cert to be set")
+	}
+
+	userNames := map[string]bool{}
+	if hasSuperUser(config) {
+		userNames[config.User()] = true
+	}
+	for _, user := range config.Users() {
+		if len(user.Name) == 0 {
+			return fmt.Errorf("user cannot be empty")
+		}
+		if userNames[user.Name] {
+			return fmt.Errorf("duplicate user: %v", user.Name)
+		}
+		userNames[user.Name] = true
+
+		for _, grant := range user.Grants {
+			if len(grant.Database) == 0 {
+				return fmt.Errorf("grant of user %v has no database", user.Name)
+			}
+			for _, perm := range grant.Permissions {
+				if _, ok := auth.PermissionNames[strings.ToLower(perm)]; !ok {
+					return fmt.Errorf("grant of user %v has unknown permission: %v", user.Name, perm)
+				}
+			}
+		}
+	}
+
 	return nil
 }
 
+// LoadTLSConfig loads the certificate chain and private key of the server, returning nil if TLS is not configured.
+func LoadTLSConfig(config ServerConfig) (*tls.Config, error) {
+	if config.TLSKey() == "" {
+		return nil, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(config.TLSCert(), config.TLSKey())
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{Certificates: []tls.Certificate{cert}}, nil
+}
+
 // ConnectionString returns a Data Source Name (DSN) to be used by go clients for connecting to a running server.
 func ConnectionString(config ServerConfig) string {
 	return fmt.Sprintf("%v:%v@tcp(%v:%v)/", config.User(), config.Password(), config.Host(), config.Port())
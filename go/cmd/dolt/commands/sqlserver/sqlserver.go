@@ -68,6 +68,18 @@ SUPPORTED CONFIG FILE FIELDS:
 
 		{{.EmphasisLeft}}user.password{{.EmphasisRight}} - The password that connections should use for authentication.
 
+		{{.EmphasisLeft}}users{{.EmphasisRight}} - a list of additional user accounts, which may only access the databases and branches they are granted. The user of {{.EmphasisLeft}}user.name{{.EmphasisRight}} has access to every database and branch, and when {{.EmphasisLeft}}users{{.EmphasisRight}} is set it may only connect if {{.EmphasisLeft}}user.password{{.EmphasisRight}} is set
+
+		{{.EmphasisLeft}}users[i].name{{.EmphasisRight}} - The username of the account
+
+		{{.EmphasisLeft}}users[i].password{{.EmphasisRight}} - The password of the account
+
+		{{.EmphasisLeft}}users[i].grants[j].database{{.EmphasisRight}} - The name of the database the grant applies to, or {{.EmphasisLeft}}*{{.EmphasisRight}} for every database
+
+		{{.EmphasisLeft}}users[i].grants[j].branch{{.EmphasisRight}} - The name of the branch the grant applies to. If missing or {{.EmphasisLeft}}*{{.EmphasisRight}} the grant applies to every branch
+
+		{{.EmphasisLeft}}users[i].grants[j].permissions{{.EmphasisRight}} - The permissions granted: {{.EmphasisLeft}}read{{.EmphasisRight}}, {{.EmphasisLeft}}write{{.EmphasisRight}} or both. Writes also require the read permission
+
 		{{.EmphasisLeft}}listener.host{{.EmphasisRight}} - The host address that the server will run on.  This may be {{.EmphasisLeft}}localhost{{.EmphasisRight}} or an IPv4 or IPv6 address
 
 		{{.EmphasisLeft}}listener.port{{.EmphasisRight}} - The port that the server should listen on
@@ -78,6 +90,12 @@ SUPPORTED CONFIG FILE FIELDS:
 
 		{{.EmphasisLeft}}listener.write_timeout_millis{{.EmphasisRight}} - The number of milliseconds that the server will wait for a write operation
 
+		{{.EmphasisLeft}}listener.tls_cert{{.EmphasisRight}} - A path to a PEM encoded TLS certificate chain. When set along with {{.EmphasisLeft}}listener.tls_key{{.EmphasisRight}} clients may connect using TLS
+
+		{{.EmphasisLeft}}listener.tls_key{{.EmphasisRight}} - A path to the unencrypted PEM encoded private key of the TLS certificate
+
+		{{.EmphasisLeft}}listener.require_secure_transport{{.EmphasisRight}} - If true connections which don't use TLS are rejected
+
 		{{.EmphasisLeft}}performance.query_parallelism{{.EmphasisRight}} - Amount of go routines spawned to process each query
 
 		{{.EmphasisLeft}}databases{{.EmphasisRight}} - a list of dolt data repositories to make available as SQL databases. If databases is missing or empty then the working directory must be a valid dolt data repository which will be made available as a SQL database
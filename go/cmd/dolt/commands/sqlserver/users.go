@@ -0,0 +1,123 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/auth"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/mysql"
+
+	dsqle "github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+)
+
+type serverUser struct {
+	nativePassword string
+	// grants is nil for the user of the User and Password config, which has access to every database and branch
+	grants dsqle.Grants
+}
+
+// serverAuth authenticates the users of a ServerConfig. The permissions the engine checks for each query are the ones
+// a user was granted on any database, the permissions on each database and branch are enforced by the sessions of the
+// user. A read only server only grants read permissions.
+type serverAuth struct {
+	users    map[string]serverUser
+	readOnly bool
+}
+
+var _ auth.Auth = (*serverAuth)(nil)
+
+func newServerAuth(serverConfig ServerConfig) *serverAuth {
+	users := make(map[string]serverUser)
+
+	if hasSuperUser(serverConfig) {
+		users[serverConfig.User()] = serverUser{nativePassword: auth.NativePassword(serverConfig.Password())}
+	}
+
+	for _, user := range serverConfig.Users() {
+		grants := make(dsqle.Grants, 0, len(user.Grants))
+		for _, grant := range user.Grants {
+			var perms auth.Permission
+			for _, perm := range grant.Permissions {
+				perms |= auth.PermissionNames[strings.ToLower(perm)]
+			}
+
+			grants = append(grants, dsqle.Grant{Database: grant.Database, Branch: grant.Branch, Permissions: perms})
+		}
+
+		users[user.Name] = serverUser{nativePassword: auth.NativePassword(user.Password), grants: grants}
+	}
+
+	return &serverAuth{users: users, readOnly: serverConfig.ReadOnly()}
+}
+
+// hasSuperUser returns whether the user of the User and Password config, which has access to every database and
+// branch, may connect. When user accounts with limited permissions are configured, it may only connect if it was given
+// a password, so that the default root user without a password doesn't bypass their grants.
+func hasSuperUser(serverConfig ServerConfig) bool {
+	return len(serverConfig.Users()) == 0 || serverConfig.Password() != ""
+}
+
+// Mysql implements the auth.Auth interface.
+func (sa *serverAuth) Mysql() mysql.AuthServer {
+	authServer := mysql.NewAuthServerStatic()
+
+	for name, user := range sa.users {
+		authServer.Entries[name] = []*mysql.AuthServerStaticEntry{
+			{
+				MysqlNativePassword: user.nativePassword,
+				Password:            user.nativePassword,
+			},
+		}
+	}
+
+	return authServer
+}
+
+// Allowed implements the auth.Auth interface.
+func (sa *serverAuth) Allowed(ctx *sql.Context, permission auth.Permission) error {
+	user, ok := sa.users[ctx.Client().User]
+
+	if !ok || (sa.readOnly && permission&auth.WritePerm != 0) {
+		return auth.ErrNotAuthorized.Wrap(auth.ErrNoPermission.New(permission))
+	}
+
+	if user.grants == nil {
+		return nil
+	}
+
+	var granted auth.Permission
+	for _, grant := range user.grants {
+		granted |= grant.Permissions
+	}
+
+	if granted&permission != permission {
+		return auth.ErrNotAuthorized.Wrap(auth.ErrNoPermission.New(permission &^ granted))
+	}
+
+	return nil
+}
+
+// grants returns the grants of the user given, which are nil for users with access to every database and branch.
+func (sa *serverAuth) grants(userName string) dsqle.Grants {
+	user, ok := sa.users[userName]
+
+	if !ok {
+		return dsqle.Grants{}
+	}
+
+	return user.grants
+}
@@ -52,6 +52,20 @@ type UserYAMLConfig struct {
 	Password *string
 }
 
+// GrantYAMLConfig gives a user permissions on a database, or on a single branch of a database
+type GrantYAMLConfig struct {
+	Database    string
+	Branch      string
+	Permissions []string
+}
+
+// AccountYAMLConfig contains a user account with permissions limited to the databases and branches it is granted
+type AccountYAMLConfig struct {
+	Name     string
+	Password string
+	Grants   []GrantYAMLConfig
+}
+
 // DatabaseYAMLConfig contains information on a database that this server will provide access to
 type DatabaseYAMLConfig struct {
 	Name string
@@ -65,6 +79,12 @@ type ListenerYAMLConfig struct {
 	MaxConnections     *uint64 `yaml:"max_connections"`
 	ReadTimeoutMillis  *uint64 `yaml:"read_timeout_millis"`
 	WriteTimeoutMillis *uint64 `yaml:"write_timeout_millis"`
+	// TLSKey is a file system path to an unencrypted private TLS key in PEM format.
+	TLSKey *string `yaml:"tls_key"`
+	// TLSCert is a file system path to a TLS certificate chain in PEM format.
+	TLSCert *string `yaml:"tls_cert"`
+	// RequireSecureTransport can enable a mode where non-TLS connections are turned away.
+	RequireSecureTransport *bool `yaml:"require_secure_transport"`
 }
 
 // PerformanceYAMLConfig contains configuration parameters for performance tweaking
@@ -77,6 +97,7 @@ type YAMLConfig struct {
 	LogLevelStr       *string               `yaml:"log_level"`
 	BehaviorConfig    BehaviorYAMLConfig    `yaml:"behavior"`
 	UserConfig        UserYAMLConfig        `yaml:"user"`
+	UsersConfig       []AccountYAMLConfig   `yaml:"users"`
 	ListenerConfig    ListenerYAMLConfig    `yaml:"listener"`
 	DatabaseConfig    []DatabaseYAMLConfig  `yaml:"databases"`
 	PerformanceConfig PerformanceYAMLConfig `yaml:"performance"`
@@ -93,6 +114,9 @@ func serverConfigAsYAMLConfig(cfg ServerConfig) YAMLConfig {
 			uint64Ptr(cfg.MaxConnections()),
 			uint64Ptr(cfg.ReadTimeout()),
 			uint64Ptr(cfg.WriteTimeout()),
+			nil,
+			nil,
+			nil,
 		},
 		DatabaseConfig: nil,
 	}
@@ -223,6 +247,47 @@ func (cfg YAMLConfig) DatabaseNamesAndPaths() []env.EnvNameAndPath {
 	return dbNamesAndPaths
 }
 
+// Users returns the user accounts with limited permissions that clients may use in addition to User.
+func (cfg YAMLConfig) Users() []UserConfig {
+	var users []UserConfig
+	for _, account := range cfg.UsersConfig {
+		user := UserConfig{Name: account.Name, Password: account.Password}
+		for _, grant := range account.Grants {
+			user.Grants = append(user.Grants, UserGrant{grant.Database, grant.Branch, grant.Permissions})
+		}
+		users = append(users, user)
+	}
+
+	return users
+}
+
+// TLSKey returns a path to the servers PEM-encoded private TLS key. "" if there is none.
+func (cfg YAMLConfig) TLSKey() string {
+	if cfg.ListenerConfig.TLSKey == nil {
+		return ""
+	}
+
+	return *cfg.ListenerConfig.TLSKey
+}
+
+// TLSCert returns a path to the servers PEM-encoded TLS certificate chain. "" if there is none.
+func (cfg YAMLConfig) TLSCert() string {
+	if cfg.ListenerConfig.TLSCert == nil {
+		return ""
+	}
+
+	return *cfg.ListenerConfig.TLSCert
+}
+
+// RequireSecureTransport is true if the server should reject non-TLS connections.
+func (cfg YAMLConfig) RequireSecureTransport() bool {
+	if cfg.ListenerConfig.RequireSecureTransport == nil {
+		return false
+	}
+
+	return *cfg.ListenerConfig.RequireSecureTransport
+}
+
 // MaxConnections returns the maximum number of simultaneous connections the server will allow.  The default is 1
 func (cfg YAMLConfig) MaxConnections() uint64 {
 	if cfg.ListenerConfig.MaxConnections == nil {
@@ -83,3 +83,41 @@ func TestYAMLConfigDefaults(t *testing.T) {
 	assert.Equal(t, defaultAutoCommit, cfg.AutoCommit())
 	assert.Equal(t, uint64(defaultMaxConnections), cfg.MaxConnections())
 }
+
+func TestUnmarshallUsers(t *testing.T) {
+	testStr := `
+user:
+    name: root
+    password: ""
+
+users:
+    - name: analyst
+      password: pass
+      grants:
+          - database: irs_soi
+            branch: master
+            permissions: [read]
+    - name: etl
+      password: pass2
+      grants:
+          - database: "*"
+            permissions: [read, write]
+`
+
+	config := YAMLConfig{}
+	err := yaml.Unmarshal([]byte(testStr), &config)
+	require.NoError(t, err)
+
+	expected := []UserConfig{
+		{Name: "analyst", Password: "pass", Grants: []UserGrant{{Database: "irs_soi", Branch: "master", Permissions: []string{"read"}}}},
+		{Name: "etl", Password: "pass2", Grants: []UserGrant{{Database: "*", Permissions: []string{"read", "write"}}}},
+	}
+	assert.Equal(t, expected, config.Users())
+	assert.NoError(t, ValidateConfig(config))
+
+	config.UsersConfig[1].Grants[0].Permissions = []string{"delete"}
+	assert.Error(t, ValidateConfig(config))
+
+	config.UsersConfig[1].Name = "analyst"
+	assert.Error(t, ValidateConfig(config))
+}
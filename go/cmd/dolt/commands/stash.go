@@ -0,0 +1,297 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+var stashDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Stash the changes in a dirty working set away",
+	LongDesc: `Use {{.EmphasisLeft}}dolt stash{{.EmphasisRight}} when you want to record the current state of the working set and the staged tables, but want to go back to a clean working set. The command saves your local modifications away and reverts the working set to match the {{.EmphasisLeft}}HEAD{{.EmphasisRight}} commit.
+
+Stashes are stored in the database, and are referred to by their position in the stash list, {{.EmphasisLeft}}stash@{0}{{.EmphasisRight}} being the most recently created stash. Several subcommands are available to work with the stash list.
+
+{{.EmphasisLeft}}push{{.EmphasisRight}}
+Saves your local modifications to a new stash. This is the default when no subcommand is given. Optionally, a description can be given using the {{.EmphasisLeft}}-m{{.EmphasisRight}} option.
+
+{{.EmphasisLeft}}list{{.EmphasisRight}}
+Lists the stashes that you currently have, from newest to oldest.
+
+{{.EmphasisLeft}}apply{{.EmphasisRight}}
+Applies the changes of the given stash, or of the most recent stash, to the current working set. The changes are applied with a three-way merge which uses the commit the changes were stashed on as the common ancestor, so a stash can be applied on top of a different commit than the one it was created on. Conflicts are left in the working set to be resolved with {{.EmphasisLeft}}dolt conflicts{{.EmphasisRight}}.
+
+{{.EmphasisLeft}}pop{{.EmphasisRight}}
+Like {{.EmphasisLeft}}apply{{.EmphasisRight}}, but also removes the stash from the stash list if it applied without conflicts.
+
+{{.EmphasisLeft}}drop{{.EmphasisRight}}
+Removes the given stash, or the most recent stash, from the stash list.
+`,
+	Synopsis: []string{
+		"[push] [-m {{.LessThan}}message{{.GreaterThan}}]",
+		"list",
+		"apply [{{.LessThan}}stash{{.GreaterThan}}]",
+		"pop [{{.LessThan}}stash{{.GreaterThan}}]",
+		"drop [{{.LessThan}}stash{{.GreaterThan}}]",
+	},
+}
+
+const (
+	pushStashId  = "push"
+	listStashId  = "list"
+	applyStashId = "apply"
+	popStashId   = "pop"
+	dropStashId  = "drop"
+)
+
+var stashIdxRegex = regexp.MustCompile(`^(?:stash@\{(\d+)\}|(\d+))$`)
+
+type StashCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd StashCmd) Name() string {
+	return "stash"
+}
+
+// Description returns a description of the command
+func (cmd StashCmd) Description() string {
+	return "Stash the changes in a dirty working set away."
+}
+
+// CreateMarkdown creates a markdown file containing the helptext for the command at the given path
+func (cmd StashCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) error {
+	ap := cmd.createArgParser()
+	return CreateMarkdown(fs, path, cli.GetCommandDocumentation(commandStr, stashDocs, ap))
+}
+
+func (cmd StashCmd) createArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"stash", "A stash in the form stash@{n}, where n is the position of the stash in the stash list. Defaults to stash@{0}."})
+	ap.SupportsString(cli.CommitMessageArg, "m", "msg", "Use the given {{.LessThan}}msg{{.GreaterThan}} as the description of the stash.")
+	return ap
+}
+
+// Exec executes the command
+func (cmd StashCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cmd.createArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, stashDocs, ap))
+	apr := cli.ParseArgs(ap, args, help)
+
+	var verr errhand.VerboseError
+
+	switch {
+	case apr.NArg() == 0 || apr.Arg(0) == pushStashId:
+		verr = pushStash(ctx, dEnv, apr)
+	case apr.Arg(0) == listStashId:
+		verr = listStashes(ctx, dEnv, apr)
+	case apr.Arg(0) == applyStashId:
+		verr = applyStash(ctx, dEnv, apr, false)
+	case apr.Arg(0) == popStashId:
+		verr = applyStash(ctx, dEnv, apr, true)
+	case apr.Arg(0) == dropStashId:
+		verr = dropStash(ctx, dEnv, apr)
+	default:
+		verr = errhand.BuildDError("").SetPrintUsage().Build()
+	}
+
+	return HandleVErrAndExitCode(verr, usage)
+}
+
+func pushStash(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults) errhand.VerboseError {
+	if apr.NArg() > 1 {
+		return errhand.BuildDError("").SetPrintUsage().Build()
+	}
+
+	verr := checkCanModifyWorkingSet(ctx, dEnv, "stash")
+
+	if verr != nil {
+		return verr
+	}
+
+	msg, _ := apr.GetValue(cli.CommitMessageArg)
+	stash, err := actions.StashChanges(ctx, dEnv, msg)
+
+	if err != nil {
+		if err == actions.ErrNoLocalChanges {
+			cli.Println("No local changes to save")
+			return nil
+		}
+
+		return errhand.BuildDError("error: failed to stash changes").AddCause(err).Build()
+	}
+
+	cli.Println("Saved working directory and index state", stash.Meta.Description)
+	return nil
+}
+
+func listStashes(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults) errhand.VerboseError {
+	if apr.NArg() > 1 {
+		return errhand.BuildDError("").SetPrintUsage().Build()
+	}
+
+	stashes, err := actions.GetStashes(ctx, dEnv.DoltDB)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to read stashes").AddCause(err).Build()
+	}
+
+	for i, stash := range stashes {
+		cli.Printf("stash@{%d}: %s\n", i, stash.Meta.Description)
+	}
+
+	return nil
+}
+
+func applyStash(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults, drop bool) errhand.VerboseError {
+	idx, stash, verr := resolveStash(ctx, dEnv, apr)
+
+	if verr != nil {
+		return verr
+	}
+
+	verr = checkCanModifyWorkingSet(ctx, dEnv, apr.Arg(0))
+
+	if verr != nil {
+		return verr
+	}
+
+	tblToStats, err := actions.ApplyStash(ctx, dEnv, stash)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to apply stash@{%d}", idx).AddCause(err).Build()
+	}
+
+	if printSuccessStats(tblToStats) {
+		if drop {
+			cli.Println("The stash entry is kept in case you need it again.")
+		}
+
+		return errhand.BuildDError("error: conflicts applying stash@{%d}", idx).Build()
+	}
+
+	if drop {
+		return dropResolvedStash(ctx, dEnv, idx, stash)
+	}
+
+	return nil
+}
+
+func dropStash(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults) errhand.VerboseError {
+	idx, stash, verr := resolveStash(ctx, dEnv, apr)
+
+	if verr != nil {
+		return verr
+	}
+
+	return dropResolvedStash(ctx, dEnv, idx, stash)
+}
+
+func dropResolvedStash(ctx context.Context, dEnv *env.DoltEnv, idx int, stash *doltdb.Stash) errhand.VerboseError {
+	h, err := stash.HashOf()
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get hash of stash@{%d}", idx).AddCause(err).Build()
+	}
+
+	err = actions.DropStash(ctx, dEnv.DoltDB, stash)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to drop stash@{%d}", idx).AddCause(err).Build()
+	}
+
+	cli.Printf("Dropped stash@{%d} (%s)\n", idx, h)
+	return nil
+}
+
+// resolveStash returns the position and the stash named by the optional second argument of |apr|, defaulting to the
+// most recent stash.
+func resolveStash(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults) (int, *doltdb.Stash, errhand.VerboseError) {
+	if apr.NArg() > 2 {
+		return 0, nil, errhand.BuildDError("").SetPrintUsage().Build()
+	}
+
+	idx := 0
+	if apr.NArg() == 2 {
+		matches := stashIdxRegex.FindStringSubmatch(apr.Arg(1))
+
+		if matches == nil {
+			return 0, nil, errhand.BuildDError("error: '%s' is not a valid stash reference", apr.Arg(1)).Build()
+		}
+
+		idxStr := matches[1]
+		if idxStr == "" {
+			idxStr = matches[2]
+		}
+
+		var err error
+		idx, err = strconv.Atoi(idxStr)
+
+		if err != nil {
+			return 0, nil, errhand.BuildDError("error: '%s' is not a valid stash reference", apr.Arg(1)).Build()
+		}
+	}
+
+	stashes, err := actions.GetStashes(ctx, dEnv.DoltDB)
+
+	if err != nil {
+		return 0, nil, errhand.BuildDError("error: failed to read stashes").AddCause(err).Build()
+	}
+
+	if len(stashes) == 0 {
+		return 0, nil, errhand.BuildDError("No stash entries found.").Build()
+	}
+
+	if idx >= len(stashes) {
+		return 0, nil, errhand.BuildDError("error: stash@{%d} does not exist", idx).Build()
+	}
+
+	return idx, stashes[idx], nil
+}
+
+// checkCanModifyWorkingSet returns an error if a merge or a rebase is active or the working set has unresolved
+// conflicts.
+func checkCanModifyWorkingSet(ctx context.Context, dEnv *env.DoltEnv, cmdName string) errhand.VerboseError {
+	if dEnv.IsMergeActive() {
+		return errhand.BuildDError("error: %s is not possible because you have not committed an active merge.", cmdName).Build()
+	}
+
+	if dEnv.IsRebaseActive() {
+		return errhand.BuildDError("error: %s is not possible because a rebase is in progress.", cmdName).Build()
+	}
+
+	root, verr := GetWorkingWithVErr(dEnv)
+
+	if verr != nil {
+		return verr
+	}
+
+	if has, err := root.HasConflicts(ctx); err != nil {
+		return errhand.BuildDError("error: failed to get conflicts").AddCause(err).Build()
+	} else if has {
+		return errhand.BuildDError("error: %s is not possible because you have unmerged tables.", cmdName).Build()
+	}
+
+	return nil
+}
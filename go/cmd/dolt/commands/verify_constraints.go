@@ -22,7 +22,7 @@ import (
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
-	"github.com/dolthub/dolt/go/libraries/doltcore/table"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 )
@@ -70,55 +70,21 @@ func (cmd VerifyConstraintsCmd) Exec(ctx context.Context, commandStr string, arg
 	if err != nil {
 		return HandleVErrAndExitCode(errhand.BuildDError("Unable to get working.").AddCause(err).Build(), nil)
 	}
-	fkColl, err := working.GetForeignKeyCollection(ctx)
-	if err != nil {
-		return HandleVErrAndExitCode(errhand.BuildDError("Unable to get foreign keys.").AddCause(err).Build(), nil)
-	}
 
-	var accumulatedConstraintErrors []string
-	for _, givenTableName := range tableNames {
-		tbl, tableName, ok, err := working.GetTableInsensitive(ctx, givenTableName)
+	for i, givenTableName := range tableNames {
+		_, tableName, ok, err := working.GetTableInsensitive(ctx, givenTableName)
 		if err != nil {
 			return HandleVErrAndExitCode(errhand.BuildDError("Unable to get table %s.", givenTableName).AddCause(err).Build(), nil)
 		}
 		if !ok {
 			return HandleVErrAndExitCode(errhand.BuildDError("Table %s does not exist.", givenTableName).Build(), nil)
 		}
-		tblSch, err := tbl.GetSchema(ctx)
-		if err != nil {
-			return HandleVErrAndExitCode(errhand.BuildDError("Unable to get schema for %s.", tableName).AddCause(err).Build(), nil)
-		}
-		fks, _ := fkColl.KeysForTable(tableName)
-
-		for _, fk := range fks {
-			childIdx := tblSch.Indexes().GetByName(fk.TableIndex)
-			childIdxRowData, err := tbl.GetIndexRowData(ctx, fk.TableIndex)
-			if err != nil {
-				return HandleVErrAndExitCode(errhand.BuildDError("Unable to get index data for %s.", fk.TableIndex).AddCause(err).Build(), nil)
-			}
-
-			parentTbl, _, ok, err := working.GetTableInsensitive(ctx, fk.ReferencedTableName)
-			if err != nil {
-				return HandleVErrAndExitCode(errhand.BuildDError("Unable to get table %s.", fk.ReferencedTableName).AddCause(err).Build(), nil)
-			}
-			if !ok {
-				return HandleVErrAndExitCode(errhand.BuildDError("Table %s does not exist.", fk.ReferencedTableName).Build(), nil)
-			}
-			parentTblSch, err := parentTbl.GetSchema(ctx)
-			if err != nil {
-				return HandleVErrAndExitCode(errhand.BuildDError("Unable to get schema for %s.", fk.ReferencedTableName).AddCause(err).Build(), nil)
-			}
-			parentIdx := parentTblSch.Indexes().GetByName(fk.ReferencedTableIndex)
-			parentIdxRowData, err := parentTbl.GetIndexRowData(ctx, fk.ReferencedTableIndex)
-			if err != nil {
-				return HandleVErrAndExitCode(errhand.BuildDError("Unable to get index data for %s.", fk.ReferencedTableIndex).AddCause(err).Build(), nil)
-			}
-
-			err = table.ForeignKeyIsSatisfied(ctx, fk, childIdxRowData, parentIdxRowData, childIdx, parentIdx)
-			if err != nil {
-				accumulatedConstraintErrors = append(accumulatedConstraintErrors, err.Error())
-			}
-		}
+		tableNames[i] = tableName
+	}
+
+	accumulatedConstraintErrors, err := actions.VerifyConstraints(ctx, working, tableNames...)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.BuildDError("Unable to verify constraints.").AddCause(err).Build(), nil)
 	}
 
 	if len(accumulatedConstraintErrors) > 0 {
@@ -61,6 +61,10 @@ var doltCommand = cli.NewSubCommandHandler("dolt", "it's git for data", []cli.Co
 	commands.DiffCmd{},
 	commands.BlameCmd{},
 	commands.MergeCmd{},
+	commands.CherryPickCmd{},
+	commands.RevertCmd{},
+	commands.RebaseCmd{},
+	commands.StashCmd{},
 	commands.BranchCmd{},
 	commands.TagCmd{},
 	commands.CheckoutCmd{},
@@ -42,6 +42,9 @@ const (
 
 	JWTKIDHeader = "kid"
 	JWTAlgHeader = "alg"
+
+	// JWTAudience is the audience of the bearer tokens sent to remote servers
+	JWTAudience = "dolthub-remote-api.liquidata.co"
 )
 
 var B32CredsByteSet = set.NewByteSet([]byte(B32CharEncoding))
@@ -133,7 +136,7 @@ func (dc DoltCreds) toBearerToken() (string, error) {
 	// Shouldn't be hard coded
 	jwtBuilder := jwt.Signed(signer)
 	jwtBuilder = jwtBuilder.Claims(jwt.Claims{
-		Audience: []string{"dolthub-remote-api.liquidata.co"},
+		Audience: []string{JWTAudience},
 		Issuer:   "dolt-client.liquidata.co",
 		Subject:  "doltClientCredentials/" + b32KIDStr,
 		Expiry:   jwt.NewNumericDate(datetime.Now().Add(30 * time.Second)),
@@ -46,6 +46,10 @@ const (
 
 	//AWSCredsProfile is a creation parameter that can be used to specify which AWS profile to use.
 	AWSCredsProfile = "aws-creds-profile"
+
+	// AWSEndpointParam is a creation parameter that can be used to connect to an S3 compatible object store, such as
+	// MinIO, instead of AWS. Buckets are addressed by path at the endpoint given.
+	AWSEndpointParam = "aws-endpoint"
 )
 
 // AWSCredentialSource is an enum type representing the different credential sources (auto, role, env, file, or invalid)
@@ -166,6 +170,10 @@ func awsConfigFromParams(params map[string]string) (session.Options, error) {
 		awsConfig = awsConfig.WithRegion(val)
 	}
 
+	if val, ok := params[AWSEndpointParam]; ok {
+		awsConfig = awsConfig.WithEndpoint(val).WithS3ForcePathStyle(true)
+	}
+
 	awsCredsSource := RoleCS
 	if val, ok := params[AWSCredsTypeParam]; ok {
 		awsCredsSource = AWSCredentialSourceFromStr(val)
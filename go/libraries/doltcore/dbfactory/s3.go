@@ -0,0 +1,60 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbfactory
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	"github.com/dolthub/dolt/go/store/blobstore"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/nbs"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// S3Factory is a DBFactory implementation for creating databases backed by an S3 or S3 compatible bucket, without
+// the DynamoDB table used by AWSFactory. The bucket must have versioning enabled.
+type S3Factory struct {
+}
+
+// CreateDB creates an S3 backed database
+func (fact S3Factory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]string) (datas.Database, error) {
+	var db datas.Database
+	if len(urlObj.Host) == 0 {
+		return nil, errors.New("s3 url has an invalid format. expected s3://bucket/path")
+	}
+
+	opts, err := awsConfigFromParams(params)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sess := session.Must(session.NewSessionWithOptions(opts))
+	bs := blobstore.NewS3Blobstore(s3.New(sess), urlObj.Host, urlObj.Path)
+	s3Store, err := nbs.NewBSStore(ctx, nbf.VersionString(), bs, defaultMemTableSize)
+
+	if err != nil {
+		return nil, err
+	}
+
+	db = datas.NewDatabase(s3Store)
+
+	return db, err
+}
@@ -20,6 +20,8 @@ import (
 	"fmt"
 	"time"
 
+	"golang.org/x/sync/errgroup"
+
 	"github.com/dolthub/dolt/go/libraries/doltcore/row"
 
 	"github.com/dolthub/dolt/go/store/diff"
@@ -28,6 +30,8 @@ import (
 
 type DiffSummaryProgress struct {
 	Adds, Removes, Changes, CellChanges, NewSize, OldSize uint64
+	// BytesAdded and BytesRemoved count the bytes changed within the BLOB and TEXT cells of modified rows
+	BytesAdded, BytesRemoved uint64
 }
 
 type reporter func(ctx context.Context, change *diff.Difference, ch chan<- DiffSummaryProgress) error
@@ -137,7 +141,11 @@ func reportPkChanges(ctx context.Context, change *diff.Difference, ch chan<- Dif
 		if err != nil {
 			return err
 		}
-		summary = DiffSummaryProgress{Changes: 1, CellChanges: cellChanges}
+		bytesAdded, bytesRemoved, err := countBlobByteChanges(ctx, oldTuple, newTuple)
+		if err != nil {
+			return err
+		}
+		summary = DiffSummaryProgress{Changes: 1, CellChanges: cellChanges, BytesAdded: bytesAdded, BytesRemoved: bytesRemoved}
 	default:
 		return errors.New("unknown change type")
 	}
@@ -149,6 +157,67 @@ func reportPkChanges(ctx context.Context, change *diff.Difference, ch chan<- Dif
 	}
 }
 
+// countBlobByteChanges returns the number of bytes added to and removed from the blob cells of a modified row. Blobs
+// are diffed chunk by chunk, so only the chunks of a large value which were edited are compared byte by byte.
+func countBlobByteChanges(ctx context.Context, from, to types.Tuple) (added, removed uint64, err error) {
+	fromVals, err := row.ParseTaggedValues(from)
+	if err != nil {
+		return 0, 0, err
+	}
+	toVals, err := row.ParseTaggedValues(to)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for tag, v := range fromVals {
+		fromBlob, ok := v.(types.Blob)
+		if !ok {
+			continue
+		}
+		toBlob, ok := toVals[tag].(types.Blob)
+		if !ok {
+			removed += fromBlob.Len()
+			continue
+		}
+		blobAdded, blobRemoved, err := diffBlobBytes(ctx, fromBlob, toBlob)
+		if err != nil {
+			return 0, 0, err
+		}
+		added += blobAdded
+		removed += blobRemoved
+	}
+
+	for tag, v := range toVals {
+		if toBlob, ok := v.(types.Blob); ok {
+			if _, ok := fromVals[tag].(types.Blob); !ok {
+				added += toBlob.Len()
+			}
+		}
+	}
+
+	return added, removed, nil
+}
+
+func diffBlobBytes(ctx context.Context, from, to types.Blob) (added, removed uint64, err error) {
+	changes := make(chan types.Splice, 128)
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		defer close(changes)
+		return to.Diff(egCtx, from, changes)
+	})
+
+	for splice := range changes {
+		added += splice.SpAdded
+		removed += splice.SpRemoved
+	}
+
+	if err := eg.Wait(); err != nil {
+		return 0, 0, err
+	}
+
+	return added, removed, nil
+}
+
 func reportKeylessChanges(ctx context.Context, change *diff.Difference, ch chan<- DiffSummaryProgress) error {
 	var oldCard uint64
 	if change.OldValue != nil {
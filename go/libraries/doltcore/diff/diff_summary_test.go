@@ -0,0 +1,59 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestCountBlobByteChanges(t *testing.T) {
+	ctx := context.Background()
+	vrw := types.NewValueStore((&chunks.MemoryStorage{}).NewView())
+
+	blob := func(s string) types.Blob {
+		b, err := types.NewBlob(ctx, vrw, strings.NewReader(s))
+		require.NoError(t, err)
+		return b
+	}
+	tuple := func(vals ...types.Value) types.Tuple {
+		tup, err := types.NewTuple(vrw.Format(), vals...)
+		require.NoError(t, err)
+		return tup
+	}
+
+	text := strings.Repeat("abcdefghijklmnopqrstuvwxyz", 10000)
+	edited := text[:1000] + "0123" + text[1010:]
+
+	from := tuple(types.Uint(0), types.Int(1), types.Uint(1), blob(text), types.Uint(2), blob("removed"))
+	to := tuple(types.Uint(0), types.Int(2), types.Uint(1), blob(edited), types.Uint(3), blob("added!"))
+
+	added, removed, err := countBlobByteChanges(ctx, from, to)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4+6), added)
+	assert.Equal(t, uint64(10+7), removed)
+
+	added, removed, err = countBlobByteChanges(ctx, from, from)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), added)
+	assert.Equal(t, uint64(0), removed)
+}
@@ -497,6 +497,13 @@ func (td TableDelta) IsKeyless(ctx context.Context) (bool, error) {
 
 	from, to := schema.IsKeyless(f), schema.IsKeyless(t)
 
+	// an added or dropped table only has a schema on one side of the delta
+	if td.IsAdd() {
+		return to, nil
+	} else if td.IsDrop() {
+		return from, nil
+	}
+
 	if from && to {
 		return true, nil
 	} else if !from && !to {
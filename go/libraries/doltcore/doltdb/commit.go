@@ -158,6 +158,9 @@ func (c *Commit) getParent(ctx context.Context, idx int) (*types.Struct, error)
 	if err != nil {
 		return nil, err
 	}
+	if targVal == nil {
+		return nil, missingCommitError{parentRef.TargetHash()}
+	}
 	parentSt := targVal.(types.Struct)
 	return &parentSt, nil
 }
@@ -275,7 +278,9 @@ func (c *Commit) CanFastReverseTo(ctx context.Context, new *Commit) (bool, error
 func (c *Commit) GetAncestor(ctx context.Context, as *AncestorSpec) (*Commit, error) {
 	ancestorSt, err := getAncestor(ctx, c.vrw, c.commitSt, as)
 
-	if err != nil {
+	if _, ok := err.(missingCommitError); ok {
+		return nil, ErrHashNotFound
+	} else if err != nil {
 		return nil, err
 	}
 
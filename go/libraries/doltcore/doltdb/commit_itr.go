@@ -16,7 +16,6 @@ package doltdb
 
 import (
 	"context"
-	"errors"
 	"io"
 
 	"github.com/dolthub/dolt/go/store/hash"
@@ -135,7 +134,7 @@ func (cmItr *commitItr) Next(ctx context.Context) (hash.Hash, *Commit, error) {
 	cmItr.curr, err = hashToCommit(ctx, cmItr.ddb.ValueReadWriter(), next)
 
 	if err != nil {
-		return hash.Hash{}, nil, err
+		return hash.Hash{}, nil, cmItr.ddb.missingCommitErr(err)
 	}
 
 	return next, cmItr.curr, nil
@@ -149,7 +148,7 @@ func hashToCommit(ctx context.Context, vrw types.ValueReadWriter, h hash.Hash) (
 	}
 
 	if val == nil {
-		return nil, errors.New("failed to get commit")
+		return nil, missingCommitError{h}
 	}
 
 	cmSt := val.(types.Struct)
@@ -63,13 +63,16 @@ var InMemDoltDB = "mem://"
 // errors in many cases.
 type DoltDB struct {
 	db datas.Database
+
+	// shallow is the hashes of the commits behind the boundary of a shallow clone, which were not fetched
+	shallow hash.HashSet
 }
 
 // DoltDBFromCS creates a DoltDB from a noms chunks.ChunkStore
 func DoltDBFromCS(cs chunks.ChunkStore) *DoltDB {
 	db := datas.NewDatabase(cs)
 
-	return &DoltDB{db}
+	return &DoltDB{db: db}
 }
 
 // LoadDoltDB will acquire a reference to the underlying noms db.  If the Location is InMemDoltDB then a reference
@@ -96,7 +99,67 @@ func LoadDoltDBWithParams(ctx context.Context, nbf *types.NomsBinFormat, urlStr
 		return nil, err
 	}
 
-	return &DoltDB{db}, nil
+	return &DoltDB{db: db}, nil
+}
+
+// WithFallback returns a DoltDB over the data of a local DoltDB, which reads the data missing from it from the
+// DoltDB returned by openFallback. It is used by sparse clones to read the tables they didn't fetch from the remote.
+func (ddb *DoltDB) WithFallback(openFallback func(ctx context.Context) (*DoltDB, error)) (*DoltDB, error) {
+	db, err := datas.NewDatabaseWithFallback(ddb.db, func(ctx context.Context) (datas.Database, error) {
+		fallback, err := openFallback(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return fallback.db, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &DoltDB{db: db, shallow: ddb.shallow}, nil
+}
+
+// SetShallowBoundary records the commits of a shallow clone whose parents were not fetched. Reading those parents
+// fails with ErrShallowBoundary, while reading any other missing commit fails with ErrHashNotFound as usual.
+func (ddb *DoltDB) SetShallowBoundary(ctx context.Context, boundary []hash.Hash) error {
+	shallow := make(hash.HashSet)
+	for _, h := range boundary {
+		cm, err := hashToCommit(ctx, ddb.db, h)
+
+		if err != nil {
+			return err
+		}
+
+		parents, err := cm.ParentHashes(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		for _, ph := range parents {
+			shallow.Insert(ph)
+		}
+	}
+
+	ddb.shallow = shallow
+	return nil
+}
+
+// missingCommitErr replaces a missingCommitError with ErrShallowBoundary if the missing commit is behind the shallow
+// boundary of the database, or with ErrHashNotFound otherwise. Other errors are returned as is.
+func (ddb *DoltDB) missingCommitErr(err error) error {
+	mce, ok := err.(missingCommitError)
+
+	if !ok {
+		return err
+	} else if ddb.shallow.Has(mce.h) {
+		return ErrShallowBoundary{mce.h}
+	}
+
+	return ErrHashNotFound
 }
 
 func (ddb *DoltDB) CSMetricsSummary() string {
@@ -307,6 +370,11 @@ func (ddb *DoltDB) Resolve(ctx context.Context, cs *CommitSpec, cwb ref.DoltRef)
 	switch cs.csType {
 	case hashCommitSpec:
 		commitSt, err = getCommitStForHash(ctx, ddb.db, cs.baseSpec)
+
+		if err == ErrHashNotFound {
+			h, _ := hash.MaybeParse(strings.TrimPrefix(cs.baseSpec, "#"))
+			err = ddb.missingCommitErr(missingCommitError{h})
+		}
 	case refCommitSpec:
 		// For a ref in a CommitSpec, we have the following behavior.
 		// If it starts with `refs/`, we look for an exact match before
@@ -350,7 +418,7 @@ func (ddb *DoltDB) Resolve(ctx context.Context, cs *CommitSpec, cwb ref.DoltRef)
 	commitSt, err = getAncestor(ctx, ddb.db, commitSt, cs.aSpec)
 
 	if err != nil {
-		return nil, err
+		return nil, ddb.missingCommitErr(err)
 	}
 
 	return NewCommit(ddb.db, commitSt), nil
@@ -755,7 +823,7 @@ func WriteValAndGetRef(ctx context.Context, vrw types.ValueReadWriter, val types
 func (ddb *DoltDB) ResolveParent(ctx context.Context, commit *Commit, parentIdx int) (*Commit, error) {
 	parentCommitSt, err := commit.getParent(ctx, parentIdx)
 	if err != nil {
-		return nil, err
+		return nil, ddb.missingCommitErr(err)
 	}
 	return NewCommit(ddb.ValueReadWriter(), *parentCommitSt), nil
 }
@@ -787,6 +855,12 @@ func (ddb *DoltDB) HasRef(ctx context.Context, doltRef ref.DoltRef) (bool, error
 	return dss.Has(ctx, types.String(doltRef.String()))
 }
 
+// HasChunk returns whether the chunk with the hash given is stored in this database. Chunks which a sparse clone reads
+// from its remote are not stored in it.
+func (ddb *DoltDB) HasChunk(ctx context.Context, h hash.Hash) (bool, error) {
+	return datas.HasChunk(ctx, ddb.db, h)
+}
+
 var branchRefFilter = map[ref.RefType]struct{}{ref.BranchRefType: {}}
 
 // GetBranches returns a list of all branches in the database.
@@ -801,6 +875,13 @@ func (ddb *DoltDB) GetTags(ctx context.Context) ([]ref.DoltRef, error) {
 	return ddb.GetRefsOfType(ctx, tagsRefFilter)
 }
 
+var stashRefFilter = map[ref.RefType]struct{}{ref.StashRefType: {}}
+
+// GetStashes returns a list of all stashes in the database.
+func (ddb *DoltDB) GetStashes(ctx context.Context) ([]ref.DoltRef, error) {
+	return ddb.GetRefsOfType(ctx, stashRefFilter)
+}
+
 // GetRefs returns a list of all refs in the database.
 func (ddb *DoltDB) GetRefs(ctx context.Context) ([]ref.DoltRef, error) {
 	return ddb.GetRefsOfType(ctx, ref.RefTypes)
@@ -1079,6 +1160,25 @@ func (ddb *DoltDB) PullChunks(ctx context.Context, tempDir string, srcDB *DoltDB
 	}
 }
 
+// PullChunksExcluding pulls a commit into a database from the source database given, without pulling the chunks in
+// |excluded| or the chunks only reachable through them.
+func (ddb *DoltDB) PullChunksExcluding(ctx context.Context, tempDir string, srcDB *DoltDB, stRef types.Ref, excluded hash.HashSet, pullerEventCh chan datas.PullerEvent) error {
+	if !datas.CanUsePuller(srcDB.db) || !datas.CanUsePuller(ddb.db) {
+		return errors.New("this type of chunk store does not support partial pulls")
+	}
+
+	puller, err := datas.NewPuller(ctx, tempDir, 256*1024, srcDB.db, ddb.db, stRef.TargetHash(), pullerEventCh)
+
+	if err == datas.ErrDBUpToDate {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	puller.Exclude(excluded)
+	return puller.Pull(ctx)
+}
+
 func (ddb *DoltDB) Clone(ctx context.Context, destDB *DoltDB, eventCh chan<- datas.TableFileEvent) error {
 	return datas.Clone(ctx, ddb.db, destDB.db, eventCh)
 }
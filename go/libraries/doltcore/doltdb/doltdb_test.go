@@ -341,3 +341,112 @@ func TestLDNoms(t *testing.T) {
 		}
 	}
 }
+
+func TestStashes(t *testing.T) {
+	ctx := context.Background()
+	ddb, err := LoadDoltDB(ctx, types.Format_7_18, InMemDoltDB)
+	require.NoError(t, err)
+	err = ddb.WriteEmptyRepo(ctx, "Bill Billerson", "bigbillieb@fake.horse")
+	require.NoError(t, err)
+
+	cs, _ := NewCommitSpec("master")
+	head, err := ddb.Resolve(ctx, cs, nil)
+	require.NoError(t, err)
+	headRoot, err := head.GetRootValue()
+	require.NoError(t, err)
+
+	tSchema := createTestSchema(t)
+	rowData, _ := createTestRowData(t, ddb.db, tSchema)
+	tbl, err := createTestTable(ddb.db, tSchema, rowData)
+	require.NoError(t, err)
+	working, err := headRoot.PutTable(ctx, "test", tbl)
+	require.NoError(t, err)
+
+	meta, err := NewCommitMeta("Bill Billerson", "bigbillieb@fake.horse", "WIP")
+	require.NoError(t, err)
+	stash, err := ddb.NewStash(ctx, head, headRoot, working, meta)
+	require.NoError(t, err)
+
+	refs, err := ddb.GetStashes(ctx)
+	require.NoError(t, err)
+	require.Len(t, refs, 1)
+	assert.True(t, ref.Equals(stash.GetDoltRef(), refs[0]))
+
+	resolved, err := ddb.ResolveStash(ctx, refs[0].(ref.StashRef))
+	require.NoError(t, err)
+	assert.Equal(t, "WIP", resolved.Meta.Description)
+
+	headHash, err := head.HashOf()
+	require.NoError(t, err)
+	resolvedHeadHash, err := resolved.HeadCommit.HashOf()
+	require.NoError(t, err)
+	assert.Equal(t, headHash, resolvedHeadHash)
+
+	stagedRoot, err := resolved.GetStagedRoot()
+	require.NoError(t, err)
+	assertRootHashesEqual(t, headRoot, stagedRoot)
+
+	workingRoot, err := resolved.GetWorkingRoot()
+	require.NoError(t, err)
+	assertRootHashesEqual(t, working, workingRoot)
+
+	err = ddb.DeleteStash(ctx, stash.GetDoltRef())
+	require.NoError(t, err)
+	refs, err = ddb.GetStashes(ctx)
+	require.NoError(t, err)
+	assert.Len(t, refs, 0)
+
+	_, err = ddb.ResolveStash(ctx, stash.GetDoltRef().(ref.StashRef))
+	assert.Equal(t, ErrStashNotFound, err)
+}
+
+func assertRootHashesEqual(t *testing.T, expected, actual *RootValue) {
+	expectedHash, err := expected.HashOf()
+	require.NoError(t, err)
+	actualHash, err := actual.HashOf()
+	require.NoError(t, err)
+	assert.Equal(t, expectedHash, actualHash)
+}
+
+func TestShallowBoundary(t *testing.T) {
+	ctx := context.Background()
+	ddb, err := LoadDoltDB(ctx, types.Format_7_18, InMemDoltDB)
+	require.NoError(t, err)
+	err = ddb.WriteEmptyRepo(ctx, "Bill Billerson", "bigbillieb@fake.horse")
+	require.NoError(t, err)
+
+	cs, _ := NewCommitSpec("master")
+	first, err := ddb.Resolve(ctx, cs, nil)
+	require.NoError(t, err)
+	firstHash, err := first.HashOf()
+	require.NoError(t, err)
+	root, err := first.GetRootValue()
+	require.NoError(t, err)
+	rootHash, err := ddb.WriteRootValue(ctx, root)
+	require.NoError(t, err)
+	meta, err := NewCommitMeta("Bill Billerson", "bigbillieb@fake.horse", "second")
+	require.NoError(t, err)
+	second, err := ddb.Commit(ctx, rootHash, ref.NewBranchRef("master"), meta)
+	require.NoError(t, err)
+	secondHash, err := second.HashOf()
+	require.NoError(t, err)
+
+	// the parents of the boundary commits are behind the boundary
+	err = ddb.SetShallowBoundary(ctx, []hash.Hash{secondHash})
+	require.NoError(t, err)
+	assert.True(t, ddb.shallow.Has(firstHash))
+
+	missing := hash.Of([]byte("missing"))
+	missingCS, _ := NewCommitSpec(missing.String())
+	_, err = ddb.Resolve(ctx, missingCS, nil)
+	assert.Equal(t, ErrHashNotFound, err)
+
+	ddb.shallow.Insert(missing)
+	_, err = ddb.Resolve(ctx, missingCS, nil)
+	assert.Equal(t, ErrShallowBoundary{missing}, err)
+
+	err = ddb.SetShallowBoundary(ctx, nil)
+	require.NoError(t, err)
+	_, err = ddb.Resolve(ctx, missingCS, nil)
+	assert.Equal(t, ErrHashNotFound, err)
+}
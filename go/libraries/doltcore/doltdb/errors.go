@@ -17,6 +17,8 @@ package doltdb
 import (
 	"errors"
 	"fmt"
+
+	"github.com/dolthub/dolt/go/store/hash"
 )
 
 var ErrInvBranchName = errors.New("not a valid user branch name")
@@ -32,6 +34,7 @@ var ErrFoundHashNotACommit = errors.New("the value retrieved for this hash is no
 var ErrHashNotFound = errors.New("could not find a value for this hash")
 var ErrBranchNotFound = errors.New("branch not found")
 var ErrTagNotFound = errors.New("tag not found")
+var ErrStashNotFound = errors.New("stash not found")
 var ErrTableNotFound = errors.New("table not found")
 var ErrTableExists = errors.New("table already exists")
 var ErrAlreadyOnBranch = errors.New("Already on branch")
@@ -53,6 +56,30 @@ func (e ErrClientOutOfDate) Error() string {
 	visit https://github.com/dolthub/dolt/releases/latest/`, e.clientVer, e.repoVer)
 }
 
+// ErrShallowBoundary is returned when walking the history of a shallow clone reaches a commit which was not fetched.
+type ErrShallowBoundary struct {
+	Hash hash.Hash
+}
+
+func (e ErrShallowBoundary) Error() string {
+	return fmt.Sprintf("commit %s is beyond the shallow boundary of this repository. run 'dolt fetch --unshallow' to fetch the rest of its history", e.Hash.String())
+}
+
+func IsShallowBoundary(err error) bool {
+	_, ok := err.(ErrShallowBoundary)
+	return ok
+}
+
+// missingCommitError is returned when a commit is not in the database. The methods of DoltDB report it as
+// ErrShallowBoundary or ErrHashNotFound, depending on whether the commit is behind the shallow boundary.
+type missingCommitError struct {
+	h hash.Hash
+}
+
+func (e missingCommitError) Error() string {
+	return ErrHashNotFound.Error()
+}
+
 func IsInvalidFormatErr(err error) bool {
 	switch err {
 	case ErrInvBranchName, ErrInvTableName, ErrInvHash, ErrInvalidAncestorSpec, ErrInvalidBranchOrHash:
@@ -549,6 +549,26 @@ func (root *RootValue) TablesInConflict(ctx context.Context) ([]string, error) {
 	return names, nil
 }
 
+// TablesWithSchemaConflicts returns the names of the tables whose schemas could not be merged.
+func (root *RootValue) TablesWithSchemaConflicts(ctx context.Context) ([]string, error) {
+	var names []string
+	err := root.IterTables(ctx, func(name string, table *Table, sch schema.Schema) (stop bool, err error) {
+		if has, err := table.HasSchemaConflict(); err != nil {
+			return true, err
+		} else if has {
+			names = append(names, name)
+		}
+		return false, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+// HasConflicts returns whether any table has rows in conflict or a schema which could not be merged.
 func (root *RootValue) HasConflicts(ctx context.Context) (bool, error) {
 	cnfTbls, err := root.TablesInConflict(ctx)
 
@@ -556,7 +576,17 @@ func (root *RootValue) HasConflicts(ctx context.Context) (bool, error) {
 		return false, err
 	}
 
-	return len(cnfTbls) > 0, nil
+	if len(cnfTbls) > 0 {
+		return true, nil
+	}
+
+	schCnfTbls, err := root.TablesWithSchemaConflicts(ctx)
+
+	if err != nil {
+		return false, err
+	}
+
+	return len(schCnfTbls) > 0, nil
 }
 
 // IterTables calls the callback function cb on each table in this RootValue.
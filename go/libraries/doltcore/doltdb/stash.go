@@ -0,0 +1,160 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+)
+
+// Stash is a saved set of working and staged changes. A stash is stored as a commit of the working root whose parents
+// are the commit that was HEAD when the changes were stashed, and a commit of the staged root.
+type Stash struct {
+	Name       string
+	Meta       *CommitMeta
+	HeadCommit *Commit
+	commit     *Commit
+	staged     *Commit
+}
+
+// GetDoltRef returns a DoltRef for this Stash.
+func (s *Stash) GetDoltRef() ref.DoltRef {
+	return ref.NewStashRef(s.Name)
+}
+
+// HashOf returns the hash of the commit which stores this stash.
+func (s *Stash) HashOf() (string, error) {
+	h, err := s.commit.HashOf()
+
+	if err != nil {
+		return "", err
+	}
+
+	return h.String(), nil
+}
+
+// GetWorkingRoot returns the working root that was stashed.
+func (s *Stash) GetWorkingRoot() (*RootValue, error) {
+	return s.commit.GetRootValue()
+}
+
+// GetStagedRoot returns the staged root that was stashed.
+func (s *Stash) GetStagedRoot() (*RootValue, error) {
+	return s.staged.GetRootValue()
+}
+
+// NewStash saves |workingRoot| and |stagedRoot| as a new stash on top of |headCommit|.
+func (ddb *DoltDB) NewStash(ctx context.Context, headCommit *Commit, stagedRoot, workingRoot *RootValue, meta *CommitMeta) (*Stash, error) {
+	stagedHash, err := ddb.WriteRootValue(ctx, stagedRoot)
+
+	if err != nil {
+		return nil, err
+	}
+
+	stagedCm, err := ddb.CommitDanglingWithParentCommits(ctx, stagedHash, []*Commit{headCommit}, meta)
+
+	if err != nil {
+		return nil, err
+	}
+
+	workingHash, err := ddb.WriteRootValue(ctx, workingRoot)
+
+	if err != nil {
+		return nil, err
+	}
+
+	stashCm, err := ddb.CommitDanglingWithParentCommits(ctx, workingHash, []*Commit{headCommit, stagedCm}, meta)
+
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := stashCm.HashOf()
+
+	if err != nil {
+		return nil, err
+	}
+
+	stashRef := ref.NewStashRef(h.String())
+	err = ddb.SetHeadToCommit(ctx, stashRef, stashCm)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Stash{
+		Name:       stashRef.GetPath(),
+		Meta:       meta,
+		HeadCommit: headCommit,
+		commit:     stashCm,
+		staged:     stagedCm,
+	}, nil
+}
+
+// ResolveStash takes a StashRef and returns the corresponding Stash object.
+func (ddb *DoltDB) ResolveStash(ctx context.Context, stashRef ref.StashRef) (*Stash, error) {
+	hasRef, err := ddb.HasRef(ctx, stashRef)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !hasRef {
+		return nil, ErrStashNotFound
+	}
+
+	cm, err := ddb.ResolveRef(ctx, stashRef)
+
+	if err != nil {
+		return nil, err
+	}
+
+	parents, err := ddb.ResolveAllParents(ctx, cm)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(parents) != 2 {
+		return nil, fmt.Errorf("stash %s is not a valid stash commit", stashRef.String())
+	}
+
+	meta, err := cm.GetCommitMeta()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Stash{
+		Name:       stashRef.GetPath(),
+		Meta:       meta,
+		HeadCommit: parents[0],
+		commit:     cm,
+		staged:     parents[1],
+	}, nil
+}
+
+// DeleteStash deletes the stash given, returning an error if it doesn't exist.
+func (ddb *DoltDB) DeleteStash(ctx context.Context, stash ref.DoltRef) error {
+	err := ddb.deleteRef(ctx, stash)
+
+	if err == ErrBranchNotFound {
+		return ErrStashNotFound
+	}
+
+	return err
+}
@@ -120,18 +120,26 @@ func GetAllTableNames(ctx context.Context, root *RootValue) ([]string, error) {
 var writeableSystemTables = []string{
 	DoltQueryCatalogTableName,
 	SchemasTableName,
+	MergeStrategiesTableName,
+	BranchProtectionTableName,
+	HooksTableName,
 }
 
 var persistedSystemTables = []string{
 	DocTableName,
 	DoltQueryCatalogTableName,
 	SchemasTableName,
+	MergeStrategiesTableName,
+	BranchProtectionTableName,
+	HooksTableName,
 }
 
 var generatedSystemTables = []string{
 	BranchesTableName,
 	LogTableName,
+	DiffTableName,
 	TableOfTablesInConflictName,
+	SchemaConflictsTableName,
 	CommitsTableName,
 	CommitAncestorsTableName,
 }
@@ -192,6 +200,45 @@ const (
 	SchemasTablesIndexName = "fragment_name"
 )
 
+const (
+	// MergeStrategiesTableName is the name of the table declaring how merge conflicts are resolved automatically
+	MergeStrategiesTableName = "dolt_merge_strategies"
+	// MergeStrategiesTableCol is the name of the column containing the table a strategy applies to
+	MergeStrategiesTableCol = "table_name"
+	// MergeStrategiesColumnCol is the name of the column containing the column a strategy applies to
+	MergeStrategiesColumnCol = "column_name"
+	// MergeStrategiesStrategyCol is the name of the column containing the strategy used to resolve conflicts
+	MergeStrategiesStrategyCol = "strategy"
+	// MergeStrategiesExpressionCol is the name of the column containing the SQL expression of a custom strategy
+	MergeStrategiesExpressionCol = "expression"
+)
+
+const (
+	// BranchProtectionTableName is the name of the table declaring the rules which protect branches from updates
+	BranchProtectionTableName = "dolt_branch_protection"
+	// BranchProtectionBranchCol is the name of the column containing the branch a rule applies to
+	BranchProtectionBranchCol = "branch"
+	// BranchProtectionMergeOnlyCol is the name of the column requiring a branch only be updated by merge commits
+	BranchProtectionMergeOnlyCol = "merge_only"
+	// BranchProtectionNoForcePushCol is the name of the column disallowing updates which are not fast forwards
+	BranchProtectionNoForcePushCol = "no_force_push"
+	// BranchProtectionVerifyConstraintsCol is the name of the column requiring the constraints of a branch be satisfied
+	BranchProtectionVerifyConstraintsCol = "verify_constraints"
+	// BranchProtectionMessagePatternCol is the name of the column containing the pattern commit messages must match
+	BranchProtectionMessagePatternCol = "message_pattern"
+)
+
+const (
+	// HooksTableName is the name of the table declaring the SQL assertions run before and after commits and merges
+	HooksTableName = "dolt_hooks"
+	// HooksNameCol is the name of the column containing the name of a hook
+	HooksNameCol = "name"
+	// HooksEventCol is the name of the column containing the event which runs a hook, such as pre-commit
+	HooksEventCol = "event"
+	// HooksQueryCol is the name of the column containing the query of a hook, which must return no rows
+	HooksQueryCol = "query"
+)
+
 const (
 	// DoltHistoryTablePrefix is the prefix assigned to all the generated history tables
 	DoltHistoryTablePrefix = "dolt_history_"
@@ -207,9 +254,15 @@ const (
 	// LogTableName is the log system table name
 	LogTableName = "dolt_log"
 
+	// DiffTableName is the name of the system table listing the tables changed by each commit
+	DiffTableName = "dolt_diff"
+
 	// TableOfTablesInConflictName is the conflicts system table name
 	TableOfTablesInConflictName = "dolt_conflicts"
 
+	// SchemaConflictsTableName is the schema conflicts system table name
+	SchemaConflictsTableName = "dolt_schema_conflicts"
+
 	// BranchesTableName is the branches system table name
 	BranchesTableName = "dolt_branches"
 
@@ -33,6 +33,7 @@ const (
 	tableRowsKey       = "rows"
 	conflictsKey       = "conflicts"
 	conflictSchemasKey = "conflict_schemas"
+	schemaConflictsKey = "schema_conflicts"
 	indexesKey         = "indexes"
 	autoIncrementKey   = "auto_increment"
 
@@ -215,6 +216,126 @@ func (t *Table) ClearConflicts() (*Table, error) {
 	return &Table{t.vrw, tSt}, nil
 }
 
+// SetSchemaConflict records that the schemas given could not be merged. |schemas| holds refs to the ancestor's, our
+// and their schema for the table, |theirTbl| is their version of the table, and |description| explains which parts of
+// the schemas conflict.
+func (t *Table) SetSchemaConflict(ctx context.Context, schemas Conflict, theirTbl *Table, description string) (*Table, error) {
+	theirRef, err := WriteValAndGetRef(ctx, t.vrw, theirTbl.tableStruct)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tpl, err := types.NewTuple(t.vrw.Format(), schemas.Base, schemas.Value, schemas.MergeValue, types.String(description), theirRef)
+
+	if err != nil {
+		return nil, err
+	}
+
+	updatedSt, err := t.tableStruct.Set(schemaConflictsKey, tpl)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Table{t.vrw, updatedSt}, nil
+}
+
+// GetSchemaConflict returns the schemas which could not be merged for this table, and the description of the
+// conflict. Returns ErrNoConflicts if the table has no schema conflict.
+func (t *Table) GetSchemaConflict(ctx context.Context) (base, sch, mergeSch schema.Schema, description string, err error) {
+	conflictVal, ok, err := t.tableStruct.MaybeGet(schemaConflictsKey)
+
+	if err != nil {
+		return nil, nil, nil, "", err
+	}
+
+	if !ok {
+		return nil, nil, nil, "", ErrNoConflicts
+	}
+
+	tpl := conflictVal.(types.Tuple)
+	schemas, err := ConflictFromTuple(tpl)
+
+	if err != nil {
+		return nil, nil, nil, "", err
+	}
+
+	descVal, err := tpl.Get(3)
+
+	if err != nil {
+		return nil, nil, nil, "", err
+	}
+
+	if base, err = RefToSchema(ctx, t.vrw, schemas.Base.(types.Ref)); err != nil {
+		return nil, nil, nil, "", err
+	}
+	if sch, err = RefToSchema(ctx, t.vrw, schemas.Value.(types.Ref)); err != nil {
+		return nil, nil, nil, "", err
+	}
+	if mergeSch, err = RefToSchema(ctx, t.vrw, schemas.MergeValue.(types.Ref)); err != nil {
+		return nil, nil, nil, "", err
+	}
+
+	return base, sch, mergeSch, string(descVal.(types.String)), nil
+}
+
+// GetSchemaConflictTheirTable returns their version of a table whose schema could not be merged. Returns
+// ErrNoConflicts if the table has no schema conflict.
+func (t *Table) GetSchemaConflictTheirTable(ctx context.Context) (*Table, error) {
+	conflictVal, ok, err := t.tableStruct.MaybeGet(schemaConflictsKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, ErrNoConflicts
+	}
+
+	tpl := conflictVal.(types.Tuple)
+
+	if tpl.Len() < 5 {
+		return nil, errors.New("their table was not recorded for this schema conflict")
+	}
+
+	theirRef, err := tpl.Get(4)
+
+	if err != nil {
+		return nil, err
+	}
+
+	theirSt, err := theirRef.(types.Ref).TargetValue(ctx, t.vrw)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Table{t.vrw, theirSt.(types.Struct)}, nil
+}
+
+// HasSchemaConflict returns whether the schema of this table could not be merged.
+func (t *Table) HasSchemaConflict() (bool, error) {
+	if t == nil {
+		return false, nil
+	}
+
+	_, ok, err := t.tableStruct.MaybeGet(schemaConflictsKey)
+
+	return ok, err
+}
+
+// ClearSchemaConflict marks the schema conflict of this table as resolved.
+func (t *Table) ClearSchemaConflict() (*Table, error) {
+	tSt, err := t.tableStruct.Delete(schemaConflictsKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Table{t.vrw, tSt}, nil
+}
+
 func (t *Table) GetConflictSchemas(ctx context.Context) (base, sch, mergeSch schema.Schema, err error) {
 	schemasVal, ok, err := t.tableStruct.MaybeGet(conflictSchemasKey)
 
@@ -0,0 +1,334 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+var branchProtectionCols, _ = schema.NewColCollection(
+	schema.NewColumn(doltdb.BranchProtectionBranchCol, schema.BranchProtectionBranchTag, types.StringKind, true, schema.NotNullConstraint{}),
+	schema.NewColumn(doltdb.BranchProtectionMergeOnlyCol, schema.BranchProtectionMergeOnlyTag, types.BoolKind, false),
+	schema.NewColumn(doltdb.BranchProtectionNoForcePushCol, schema.BranchProtectionNoForcePushTag, types.BoolKind, false),
+	schema.NewColumn(doltdb.BranchProtectionVerifyConstraintsCol, schema.BranchProtectionVerifyConstraintsTag, types.BoolKind, false),
+	schema.NewColumn(doltdb.BranchProtectionMessagePatternCol, schema.BranchProtectionMessagePatternTag, types.StringKind, false),
+)
+
+// BranchProtectionSchema is the schema of the dolt_branch_protection system table
+var BranchProtectionSchema = schema.MustSchemaFromCols(branchProtectionCols)
+
+// BranchProtection is the set of rules protecting a branch. The rules of a branch are the ones declared in the
+// dolt_branch_protection table of its head commit, so changes to the rules only apply once they are committed.
+type BranchProtection struct {
+	Branch string
+	// MergeOnly requires every commit made to the branch to be a merge commit
+	MergeOnly bool
+	// NoForcePush disallows updates of the branch which are not fast forwards
+	NoForcePush bool
+	// VerifyConstraints requires the foreign keys and CHECK constraints of each commit made to the branch to be satisfied
+	VerifyConstraints bool
+	// MessagePattern is a regular expression the message of each commit made to the branch must match
+	MessagePattern string
+}
+
+// BranchProtectionViolation is the error returned when a branch update breaks one of the rules protecting the branch.
+type BranchProtectionViolation struct {
+	Branch string
+	Reason string
+}
+
+func (bpv BranchProtectionViolation) Error() string {
+	return fmt.Sprintf("branch '%s' is protected: %s", bpv.Branch, bpv.Reason)
+}
+
+// IsBranchProtectionViolation returns whether the error is a BranchProtectionViolation
+func IsBranchProtectionViolation(err error) bool {
+	_, ok := err.(BranchProtectionViolation)
+	return ok
+}
+
+// LoadBranchProtection reads the rules protecting the branch given from the dolt_branch_protection table of a root
+// value. If the branch is not protected, nil is returned.
+func LoadBranchProtection(ctx context.Context, root *doltdb.RootValue, branch string) (*BranchProtection, error) {
+	tbl, ok, err := root.GetTable(ctx, doltdb.BranchProtectionTableName)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, nil
+	}
+
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := row.TaggedValues{schema.BranchProtectionBranchTag: types.String(branch)}.NomsTupleForPKCols(root.VRW().Format(), sch.GetPKCols()).Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r, ok, err := table.GetRow(ctx, tbl, sch, key.(types.Tuple))
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, nil
+	}
+
+	bp := &BranchProtection{
+		Branch:            branch,
+		MergeOnly:         getBoolColVal(r, schema.BranchProtectionMergeOnlyTag),
+		NoForcePush:       getBoolColVal(r, schema.BranchProtectionNoForcePushTag),
+		VerifyConstraints: getBoolColVal(r, schema.BranchProtectionVerifyConstraintsTag),
+	}
+
+	if val, ok := r.GetColVal(schema.BranchProtectionMessagePatternTag); ok && !types.IsNull(val) {
+		bp.MessagePattern = string(val.(types.String))
+	}
+
+	if _, err := regexp.Compile(bp.MessagePattern); err != nil {
+		return nil, fmt.Errorf("invalid commit message pattern for branch '%s': %w", branch, err)
+	}
+
+	return bp, nil
+}
+
+func getBoolColVal(r row.Row, tag uint64) bool {
+	val, ok := r.GetColVal(tag)
+	if !ok || types.IsNull(val) {
+		return false
+	}
+
+	return bool(val.(types.Bool))
+}
+
+// LoadBranchHeadProtection reads the rules protecting a branch from its head commit. If the branch does not exist or
+// is not protected, nil is returned.
+func LoadBranchHeadProtection(ctx context.Context, ddb *doltdb.DoltDB, branch ref.DoltRef) (*BranchProtection, *doltdb.Commit, error) {
+	if has, err := ddb.HasRef(ctx, branch); err != nil {
+		return nil, nil, err
+	} else if !has {
+		return nil, nil, nil
+	}
+
+	head, err := ddb.ResolveRef(ctx, branch)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root, err := head.GetRootValue()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bp, err := LoadBranchProtection(ctx, root, branch.GetPath())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return bp, head, nil
+}
+
+// CheckCommit returns a BranchProtectionViolation if a commit of the root value given with the message given breaks
+// the rules protecting the branch.
+func (bp *BranchProtection) CheckCommit(ctx context.Context, root *doltdb.RootValue, message string, isMerge bool) error {
+	if bp == nil {
+		return nil
+	}
+
+	if bp.MergeOnly && !isMerge {
+		return BranchProtectionViolation{bp.Branch, "only merge commits are allowed"}
+	}
+
+	if len(bp.MessagePattern) > 0 {
+		if matched, _ := regexp.MatchString(bp.MessagePattern, message); !matched {
+			return BranchProtectionViolation{bp.Branch, fmt.Sprintf("commit message does not match the pattern '%s'", bp.MessagePattern)}
+		}
+	}
+
+	if bp.VerifyConstraints {
+		violations, err := VerifyConstraints(ctx, root)
+		if err != nil {
+			return err
+		}
+
+		if len(violations) > 0 {
+			return BranchProtectionViolation{bp.Branch, "constraints are not satisfied:\n" + strings.Join(violations, "\n")}
+		}
+	}
+
+	return nil
+}
+
+// CheckUpdate returns a BranchProtectionViolation if moving the branch from its current head to a new head breaks the
+// rules protecting the branch. Each commit on the first parent path from the new head back to the current head is
+// checked as if it had been committed to the branch.
+func (bp *BranchProtection) CheckUpdate(ctx context.Context, ddb *doltdb.DoltDB, head, newHead *doltdb.Commit) error {
+	if bp == nil {
+		return nil
+	}
+
+	// a new head behind the current head is reported by ErrIsAhead, and moving the branch back to it is not a fast forward
+	canFF, err := head.CanFastForwardTo(ctx, newHead)
+	if err == doltdb.ErrUpToDate {
+		return nil
+	} else if err != nil && err != doltdb.ErrIsAhead {
+		return err
+	}
+
+	if !canFF && bp.NoForcePush {
+		return BranchProtectionViolation{bp.Branch, "force pushes are not allowed"}
+	}
+
+	headHash, err := head.HashOf()
+	if err != nil {
+		return err
+	}
+
+	for cm := newHead; ; {
+		h, err := cm.HashOf()
+		if err != nil {
+			return err
+		} else if h == headHash {
+			return nil
+		}
+
+		numParents, err := cm.NumParents()
+		if err != nil {
+			return err
+		}
+
+		meta, err := cm.GetCommitMeta()
+		if err != nil {
+			return err
+		}
+
+		root, err := cm.GetRootValue()
+		if err != nil {
+			return err
+		}
+
+		err = bp.CheckCommit(ctx, root, meta.Description, numParents > 1)
+		if err != nil {
+			return err
+		}
+
+		// only the new head is checked when the update is not a fast forward
+		if !canFF || numParents == 0 {
+			return nil
+		}
+
+		cm, err = ddb.ResolveParent(ctx, cm, 0)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// CheckBranchUpdate returns a BranchProtectionViolation if moving a branch of the database given to a new head breaks
+// the rules protecting the branch.
+func CheckBranchUpdate(ctx context.Context, ddb *doltdb.DoltDB, branch ref.DoltRef, newHead *doltdb.Commit) error {
+	bp, head, err := LoadBranchHeadProtection(ctx, ddb, branch)
+	if err != nil {
+		return err
+	}
+
+	return bp.CheckUpdate(ctx, ddb, head, newHead)
+}
+
+// VerifyConstraints returns a description of each foreign key and CHECK constraint of the tables given which is not
+// satisfied by the rows of a root value. The constraints of every table of the root value are verified if no tables are
+// given.
+func VerifyConstraints(ctx context.Context, root *doltdb.RootValue, tblNames ...string) ([]string, error) {
+	if len(tblNames) == 0 {
+		var err error
+		tblNames, err = root.GetTableNames(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fkColl, err := root.GetForeignKeyCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var violations []string
+	for _, tblName := range tblNames {
+		tbl, ok, err := root.GetTable(ctx, tblName)
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			return nil, errors.New("table " + tblName + " does not exist")
+		}
+
+		fks, _ := fkColl.KeysForTable(tblName)
+		for _, fk := range fks {
+			childIdx, childIdxData, err := getIndexAndData(ctx, root, fk.TableName, fk.TableIndex)
+			if err != nil {
+				return nil, err
+			}
+
+			parentIdx, parentIdxData, err := getIndexAndData(ctx, root, fk.ReferencedTableName, fk.ReferencedTableIndex)
+			if err != nil {
+				return nil, err
+			}
+
+			err = table.ForeignKeyIsSatisfied(ctx, root.VRW(), fk, childIdxData, parentIdxData, childIdx, parentIdx)
+			if err != nil {
+				violations = append(violations, err.Error())
+			}
+		}
+
+		err = table.ChecksAreSatisfied(ctx, tblName, tbl, sqlutil.NewCheckEvaluator)
+		if err != nil {
+			violations = append(violations, err.Error())
+		}
+	}
+
+	return violations, nil
+}
+
+func getIndexAndData(ctx context.Context, root *doltdb.RootValue, tblName, idxName string) (schema.Index, types.Map, error) {
+	tbl, ok, err := root.GetTable(ctx, tblName)
+	if err != nil {
+		return nil, types.EmptyMap, err
+	} else if !ok {
+		return nil, types.EmptyMap, errors.New("table " + tblName + " does not exist")
+	}
+
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, types.EmptyMap, err
+	}
+
+	idxData, err := tbl.GetIndexRowData(ctx, idxName)
+	if err != nil {
+		return nil, types.EmptyMap, err
+	}
+
+	return sch.Indexes().GetByName(idxName), idxData, nil
+}
@@ -0,0 +1,150 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func protectionRow(t *testing.T, branch string, noForcePush bool, pattern string) row.Row {
+	r, err := row.New(types.Format_7_18, BranchProtectionSchema, row.TaggedValues{
+		schema.BranchProtectionBranchTag:         types.String(branch),
+		schema.BranchProtectionNoForcePushTag:    types.Bool(noForcePush),
+		schema.BranchProtectionMessagePatternTag: types.String(pattern),
+	})
+	require.NoError(t, err)
+	return r
+}
+
+func commitAll(ctx context.Context, t *testing.T, dEnv *env.DoltEnv, msg string) error {
+	err := StageAllTables(ctx, dEnv.DbData())
+	require.NoError(t, err)
+
+	_, err = CommitStaged(ctx, dEnv.DbData(), CommitStagedProps{
+		Message:    msg,
+		Date:       time.Now(),
+		AllowEmpty: true,
+		Name:       "billy bob",
+		Email:      "bigbillieb@fake.horse",
+	})
+	return err
+}
+
+func TestBranchProtection(t *testing.T) {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnv()
+	master := ref.NewBranchRef("master")
+
+	dtestutils.CreateTestTable(t, dEnv, doltdb.BranchProtectionTableName, BranchProtectionSchema,
+		protectionRow(t, "master", true, "^ISSUE-[0-9]+"),
+		protectionRow(t, "other", false, ""),
+	)
+
+	// the rules only apply once they are committed
+	err := commitAll(ctx, t, dEnv, "protect master")
+	require.NoError(t, err)
+
+	bp, head, err := LoadBranchHeadProtection(ctx, dEnv.DoltDB, master)
+	require.NoError(t, err)
+	require.NotNil(t, bp)
+	assert.Equal(t, &BranchProtection{Branch: "master", NoForcePush: true, MessagePattern: "^ISSUE-[0-9]+"}, bp)
+
+	bp, _, err = LoadBranchHeadProtection(ctx, dEnv.DoltDB, ref.NewBranchRef("missing"))
+	require.NoError(t, err)
+	assert.Nil(t, bp)
+
+	err = commitAll(ctx, t, dEnv, "no issue")
+	require.Error(t, err)
+	assert.True(t, IsBranchProtectionViolation(err))
+
+	err = commitAll(ctx, t, dEnv, "ISSUE-1 first")
+	require.NoError(t, err)
+
+	newHead, err := dEnv.DoltDB.ResolveRef(ctx, master)
+	require.NoError(t, err)
+	parent, err := dEnv.DoltDB.ResolveParent(ctx, newHead, 0)
+	require.NoError(t, err)
+	assert.Equal(t, head, parent)
+
+	root, err := head.GetRootValue()
+	require.NoError(t, err)
+	valHash, err := dEnv.DoltDB.WriteRootValue(ctx, root)
+	require.NoError(t, err)
+
+	// moving master back to its parent is not a fast forward
+	err = CheckBranchUpdate(ctx, dEnv.DoltDB, master, head)
+	require.Error(t, err)
+	assert.True(t, IsBranchProtectionViolation(err))
+
+	newMeta, err := doltdb.NewCommitMeta("billy bob", "bigbillieb@fake.horse", "ISSUE-2 second")
+	require.NoError(t, err)
+	ffCommit, err := dEnv.DoltDB.CommitDanglingWithParentCommits(ctx, valHash, []*doltdb.Commit{newHead}, newMeta)
+	require.NoError(t, err)
+
+	err = CheckBranchUpdate(ctx, dEnv.DoltDB, master, ffCommit)
+	assert.NoError(t, err)
+
+	badMeta, err := doltdb.NewCommitMeta("billy bob", "bigbillieb@fake.horse", "second")
+	require.NoError(t, err)
+	badCommit, err := dEnv.DoltDB.CommitDanglingWithParentCommits(ctx, valHash, []*doltdb.Commit{ffCommit}, badMeta)
+	require.NoError(t, err)
+
+	err = CheckBranchUpdate(ctx, dEnv.DoltDB, master, badCommit)
+	require.Error(t, err)
+	assert.True(t, IsBranchProtectionViolation(err))
+}
+
+func TestVerifyConstraints(t *testing.T) {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnv()
+
+	sch := dtestutils.MustSchema(
+		schema.NewColumn("pk", 0, types.IntKind, true, schema.NotNullConstraint{}),
+		schema.NewColumn("v", 1, types.IntKind, false),
+	)
+	_, err := sch.Checks().AddCheck("chk_v", "v < 10")
+	require.NoError(t, err)
+
+	newRow := func(pk, v int64) row.Row {
+		r, err := row.New(types.Format_7_18, sch, row.TaggedValues{0: types.Int(pk), 1: types.Int(v)})
+		require.NoError(t, err)
+		return r
+	}
+	dtestutils.CreateTestTable(t, dEnv, "checked", sch, newRow(1, 1), newRow(2, 20), newRow(3, 30))
+
+	root, err := dEnv.WorkingRoot(ctx)
+	require.NoError(t, err)
+
+	violations, err := VerifyConstraints(ctx, root)
+	require.NoError(t, err)
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0], "`chk_v` on `checked` is violated by 2 row(s)")
+
+	_, err = VerifyConstraints(ctx, root, "missing")
+	assert.Error(t, err)
+}
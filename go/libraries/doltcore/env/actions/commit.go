@@ -24,6 +24,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/utils/config"
+	"github.com/dolthub/dolt/go/libraries/utils/set"
 	"github.com/dolthub/dolt/go/store/hash"
 )
 
@@ -38,6 +39,8 @@ type CommitStagedProps struct {
 	CheckForeignKeys bool
 	Name             string
 	Email            string
+	// Hooks runs the pre-commit and post-commit hooks of the repository. No hooks are run if it is nil.
+	Hooks *Hooks
 }
 
 // GetNameAndEmail returns the name and email from the supplied config
@@ -104,6 +107,11 @@ func CommitStaged(ctx context.Context, dbData env.DbData, props CommitStagedProp
 		if err != nil {
 			return "", err
 		}
+		schInConflict, err := root.TablesWithSchemaConflicts(ctx)
+		if err != nil {
+			return "", err
+		}
+		inConflict = set.Unique(append(inConflict, schInConflict...))
 		if len(inConflict) > 0 {
 			return "", NewTblInConflictError(inConflict)
 		}
@@ -136,6 +144,31 @@ func CommitStaged(ctx context.Context, dbData env.DbData, props CommitStagedProp
 		}
 	}
 
+	bp, head, err := LoadBranchHeadProtection(ctx, ddb, rsr.CWBHeadRef())
+	if err != nil {
+		return "", err
+	}
+
+	err = bp.CheckCommit(ctx, srt, props.Message, rsr.IsMergeActive())
+	if err != nil {
+		return "", err
+	}
+
+	if props.Hooks != nil {
+		var headHash hash.Hash
+		if head != nil {
+			headHash, err = head.HashOf()
+			if err != nil {
+				return "", err
+			}
+		}
+
+		err = props.Hooks.Run(ctx, PreCommitHook, srt, headHash.String(), stagedTblNames)
+		if err != nil {
+			return "", err
+		}
+	}
+
 	h, err := env.UpdateStagedRoot(ctx, ddb, rsw, srt)
 
 	if err != nil {
@@ -176,6 +209,12 @@ func CommitStaged(ctx context.Context, dbData env.DbData, props CommitStagedProp
 
 	h, err = c.HashOf()
 
+	if err != nil {
+		return "", err
+	}
+
+	err = props.Hooks.Run(ctx, PostCommitHook, srt, h.String(), stagedTblNames)
+
 	return h.String(), err
 }
 
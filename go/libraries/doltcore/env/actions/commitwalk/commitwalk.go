@@ -141,14 +141,18 @@ func newQueue() *q {
 // GetDotDotRevisions returns the commits reachable from commit at hash
 // `includedHead` that are not reachable from hash `excludedHead`.
 // `includedHead` and `excludedHead` must be commits in `ddb`. Returns up
-// to `num` commits, in reverse topological order starting at `includedHead`,
+// to `num` commits (if `num` <= 0 then all commits), in reverse topological order starting at `includedHead`,
 // with tie breaking based on the height of commit graph between
 // concurrent commits --- higher commits appear first. Remaining
 // ties are broken by timestamp; newer commits appear first.
 //
 // Roughly mimics `git log master..feature`.
 func GetDotDotRevisions(ctx context.Context, includedDB *doltdb.DoltDB, includedHead hash.Hash, excludedDB *doltdb.DoltDB, excludedHead hash.Hash, num int) ([]*doltdb.Commit, error) {
-	commitList := make([]*doltdb.Commit, 0, num)
+	var commitList []*doltdb.Commit
+	if num > 0 {
+		commitList = make([]*doltdb.Commit, 0, num)
+	}
+
 	q := newQueue()
 	if err := q.SetInvisible(ctx, excludedDB, excludedHead); err != nil {
 		return nil, err
@@ -202,6 +206,9 @@ type commiterator struct {
 	ddb             *doltdb.DoltDB
 	startCommitHash hash.Hash
 	q               *q
+	// boundaryErr is the error returned once every commit was iterated, if the walk reached the boundary of a
+	// shallow clone
+	boundaryErr error
 }
 
 var _ doltdb.CommitItr = (*commiterator)(nil)
@@ -230,7 +237,10 @@ func (i *commiterator) Next(ctx context.Context) (hash.Hash, *doltdb.Commit, err
 		}
 
 		for _, parentID := range parents {
-			if err := i.q.AddPendingIfUnseen(ctx, nextC.ddb, parentID); err != nil {
+			err := i.q.AddPendingIfUnseen(ctx, nextC.ddb, parentID)
+			if doltdb.IsShallowBoundary(err) {
+				i.boundaryErr = err
+			} else if err != nil {
 				return hash.Hash{}, nil, err
 			}
 		}
@@ -238,12 +248,17 @@ func (i *commiterator) Next(ctx context.Context) (hash.Hash, *doltdb.Commit, err
 		return nextC.hash, nextC.commit, nil
 	}
 
+	if i.boundaryErr != nil {
+		return hash.Hash{}, nil, i.boundaryErr
+	}
+
 	return hash.Hash{}, nil, io.EOF
 }
 
 // Reset implements doltdb.CommitItr
 func (i *commiterator) Reset(ctx context.Context) error {
 	i.q = newQueue()
+	i.boundaryErr = nil
 	if err := i.q.AddPendingIfUnseen(ctx, i.ddb, i.startCommitHash); err != nil {
 		return err
 	}
@@ -121,6 +121,12 @@ func TestGetDotDotRevisions(t *testing.T) {
 	require.NoError(t, err)
 	assert.Len(t, res, 0)
 
+	res, err = GetDotDotRevisions(context.Background(), env.DoltDB, featureHash, env.DoltDB, masterHash, -1)
+	require.NoError(t, err)
+	assert.Len(t, res, 7)
+	assertEqualHashes(t, featureCommits[7], res[0])
+	assertEqualHashes(t, featureCommits[1], res[6])
+
 	res, err = GetDotDotRevisions(context.Background(), env.DoltDB, featureHash, env.DoltDB, masterHash, 3)
 	require.NoError(t, err)
 	assert.Len(t, res, 3)
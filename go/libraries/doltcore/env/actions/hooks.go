@@ -0,0 +1,231 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/utils/config"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// HookEvent is the name of an event hooks run for
+type HookEvent string
+
+const (
+	// PreCommitHook hooks run before a commit is made, and stop it if they fail
+	PreCommitHook HookEvent = "pre-commit"
+	// PostCommitHook hooks run after a commit is made
+	PostCommitHook HookEvent = "post-commit"
+	// PreMergeHook hooks run before the result of a merge is written to the working set, and stop it if they fail
+	PreMergeHook HookEvent = "pre-merge"
+	// PostMergeHook hooks run after the result of a merge is written to the working set
+	PostMergeHook HookEvent = "post-merge"
+)
+
+var hookEvents = map[HookEvent]bool{
+	PreCommitHook:  true,
+	PostCommitHook: true,
+	PreMergeHook:   true,
+	PostMergeHook:  true,
+}
+
+// HookConfigKeyPrefix is the prefix of the config keys declaring the executable run for an event, e.g.
+// hooks.pre-commit. Executables are only read from config, so that cloning a repository never runs its commands.
+const HookConfigKeyPrefix = "hooks."
+
+var hooksCols, _ = schema.NewColCollection(
+	schema.NewColumn(doltdb.HooksNameCol, schema.HooksNameTag, types.StringKind, true, schema.NotNullConstraint{}),
+	schema.NewColumn(doltdb.HooksEventCol, schema.HooksEventTag, types.StringKind, false, schema.NotNullConstraint{}),
+	schema.NewColumn(doltdb.HooksQueryCol, schema.HooksQueryTag, types.StringKind, false, schema.NotNullConstraint{}),
+)
+
+// HooksSchema is the schema of the dolt_hooks system table
+var HooksSchema = schema.MustSchemaFromCols(hooksCols)
+
+// HookQueryFunc runs a SQL query against a root value and returns the number of rows in its result.
+type HookQueryFunc func(ctx context.Context, root *doltdb.RootValue, query string) (int, error)
+
+// HookFailure is the error returned when a hook fails.
+type HookFailure struct {
+	Event  HookEvent
+	Name   string
+	Reason string
+}
+
+func (hf HookFailure) Error() string {
+	return fmt.Sprintf("%s hook '%s' failed: %s", hf.Event, hf.Name, hf.Reason)
+}
+
+// IsHookFailure returns whether the error is a HookFailure
+func IsHookFailure(err error) bool {
+	_, ok := err.(HookFailure)
+	return ok
+}
+
+// Hooks runs the hooks of a repository. Hooks are either SQL assertions declared in the dolt_hooks table, which fail
+// if their query returns any rows, or executables declared in config, which fail if they exit with a non-zero status.
+type Hooks struct {
+	cfg       config.ReadableConfig
+	queryFunc HookQueryFunc
+	out       io.Writer
+}
+
+// NewHooks returns the Hooks of a repository. Executables are read from the config given, and write their output to
+// the writer given, which also receives the failures of the hooks run after an event.
+func NewHooks(cfg config.ReadableConfig, queryFunc HookQueryFunc, out io.Writer) *Hooks {
+	return &Hooks{cfg: cfg, queryFunc: queryFunc, out: out}
+}
+
+// Run runs the hooks of an event. The SQL assertions declared in the root value given are run against it, and the
+// executable declared for the event is run with the commit hash and the changed tables given as arguments. For pre
+// events, the first hook which fails stops the others and its HookFailure is returned. Failures of post events are
+// only reported to the output of the hooks, since the commit or merge already happened.
+func (h *Hooks) Run(ctx context.Context, event HookEvent, root *doltdb.RootValue, commitHash string, tables []string) error {
+	if h == nil {
+		return nil
+	}
+
+	err := h.run(ctx, event, root, commitHash, tables)
+
+	if err != nil && (event == PostCommitHook || event == PostMergeHook) {
+		fmt.Fprintf(h.out, "warning: %s\n", err.Error())
+		return nil
+	}
+
+	return err
+}
+
+func (h *Hooks) run(ctx context.Context, event HookEvent, root *doltdb.RootValue, commitHash string, tables []string) error {
+	queries, err := loadHookQueries(ctx, root, event)
+	if err != nil {
+		return err
+	}
+
+	for _, q := range queries {
+		n, err := h.queryFunc(ctx, root, q.query)
+		if err != nil {
+			return HookFailure{event, q.name, err.Error()}
+		} else if n > 0 {
+			return HookFailure{event, q.name, fmt.Sprintf("query returned %d rows", n)}
+		}
+	}
+
+	if h.cfg == nil {
+		return nil
+	}
+
+	path, err := h.cfg.GetString(HookConfigKeyPrefix + string(event))
+	if err == config.ErrConfigParamNotFound || (err == nil && len(path) == 0) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	cmd := exec.CommandContext(ctx, path, append([]string{commitHash}, tables...)...)
+	cmd.Env = append(os.Environ(), "DOLT_HOOK="+string(event))
+	cmd.Stdout = h.out
+	cmd.Stderr = h.out
+
+	err = cmd.Run()
+	if err != nil {
+		return HookFailure{event, path, err.Error()}
+	}
+
+	return nil
+}
+
+type hookQuery struct {
+	name  string
+	query string
+}
+
+// loadHookQueries reads the SQL assertions of an event from the dolt_hooks table of a root value, ordered by name.
+func loadHookQueries(ctx context.Context, root *doltdb.RootValue, event HookEvent) ([]hookQuery, error) {
+	tbl, ok, err := root.GetTable(ctx, doltdb.HooksTableName)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, nil
+	}
+
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var queries []hookQuery
+	err = data.IterAll(ctx, func(key, value types.Value) error {
+		r, err := row.FromNoms(sch, key.(types.Tuple), value.(types.Tuple))
+		if err != nil {
+			return err
+		}
+
+		name := getStringColVal(r, schema.HooksNameTag)
+		ev := HookEvent(strings.ToLower(getStringColVal(r, schema.HooksEventTag)))
+
+		if !hookEvents[ev] {
+			return fmt.Errorf("unknown event '%s' for hook '%s'", ev, name)
+		} else if ev == event {
+			queries = append(queries, hookQuery{name, getStringColVal(r, schema.HooksQueryTag)})
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return queries, nil
+}
+
+func getStringColVal(r row.Row, tag uint64) string {
+	val, ok := r.GetColVal(tag)
+	if !ok || types.IsNull(val) {
+		return ""
+	}
+
+	return string(val.(types.String))
+}
+
+// ChangedTables returns the names of the tables which differ between two root values.
+func ChangedTables(ctx context.Context, fromRoot, toRoot *doltdb.RootValue) ([]string, error) {
+	deltas, err := diff.GetTableDeltas(ctx, fromRoot, toRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	var tblNames []string
+	for _, td := range deltas {
+		tblNames = append(tblNames, td.CurName())
+	}
+
+	return tblNames, nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/utils/config"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func hookRow(t *testing.T, name string, event HookEvent, query string) row.Row {
+	r, err := row.New(types.Format_7_18, HooksSchema, row.TaggedValues{
+		schema.HooksNameTag:  types.String(name),
+		schema.HooksEventTag: types.String(event),
+		schema.HooksQueryTag: types.String(query),
+	})
+	require.NoError(t, err)
+	return r
+}
+
+func TestHooks(t *testing.T) {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnv()
+
+	dtestutils.CreateTestTable(t, dEnv, doltdb.HooksTableName, HooksSchema,
+		hookRow(t, "a_check", PreCommitHook, "fails"),
+		hookRow(t, "b_check", PreCommitHook, "passes"),
+		hookRow(t, "c_check", PostCommitHook, "fails"),
+	)
+
+	root, err := dEnv.WorkingRoot(ctx)
+	require.NoError(t, err)
+
+	var queries []string
+	queryFunc := func(ctx context.Context, root *doltdb.RootValue, query string) (int, error) {
+		queries = append(queries, query)
+		if query == "fails" {
+			return 1, nil
+		}
+		return 0, nil
+	}
+
+	out := &bytes.Buffer{}
+	hooks := NewHooks(config.NewMapConfig(map[string]string{}), queryFunc, out)
+
+	err = hooks.Run(ctx, PreCommitHook, root, "", nil)
+	require.Error(t, err)
+	assert.True(t, IsHookFailure(err))
+	assert.Equal(t, HookFailure{PreCommitHook, "a_check", "query returned 1 rows"}, err)
+	assert.Equal(t, []string{"fails"}, queries)
+
+	// failures of post events are only reported
+	err = hooks.Run(ctx, PostCommitHook, root, "", nil)
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), "post-commit hook 'c_check' failed")
+
+	queries = nil
+	err = hooks.Run(ctx, PreMergeHook, root, "", nil)
+	assert.NoError(t, err)
+	assert.Empty(t, queries)
+
+	var nilHooks *Hooks
+	assert.NoError(t, nilHooks.Run(ctx, PreCommitHook, root, "", nil))
+}
+
+func TestHookExecutables(t *testing.T) {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnv()
+
+	root, err := dEnv.WorkingRoot(ctx)
+	require.NoError(t, err)
+
+	out := &bytes.Buffer{}
+	cfg := config.NewMapConfig(map[string]string{
+		HookConfigKeyPrefix + string(PreCommitHook): "false",
+		HookConfigKeyPrefix + string(PreMergeHook):  "echo",
+	})
+	hooks := NewHooks(cfg, nil, out)
+
+	err = hooks.Run(ctx, PreCommitHook, root, "abc", nil)
+	require.Error(t, err)
+	assert.True(t, IsHookFailure(err))
+
+	err = hooks.Run(ctx, PreMergeHook, root, "abc", []string{"t1", "t2"})
+	require.NoError(t, err)
+	assert.Equal(t, "abc t1 t2\n", out.String())
+}
@@ -17,11 +17,14 @@ package actions
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/utils/set"
 	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
 )
 
 var ErrCantFF = errors.New("can't fast forward merge")
@@ -30,7 +33,7 @@ var ErrCantFF = errors.New("can't fast forward merge")
 // This is accomplished first by verifying that the remote tracking reference for the source database can be updated to
 // the given commit via a fast forward merge.  If this is the case, an attempt will be made to update the branch in the
 // destination db to the given commit via fast forward move.  If that succeeds the tracking branch is updated in the
-// source db.
+// source db. The update fails with a BranchProtectionViolation if it breaks the rules protecting the destination branch.
 func Push(ctx context.Context, dEnv *env.DoltEnv, mode ref.RefUpdateMode, destRef ref.BranchRef, remoteRef ref.RemoteRef, srcDB, destDB *doltdb.DoltDB, commit *doltdb.Commit, progChan chan datas.PullProgress, pullerEventCh chan datas.PullerEvent) error {
 	var err error
 	if mode == ref.FastForwardOnly {
@@ -55,6 +58,12 @@ func Push(ctx context.Context, dEnv *env.DoltEnv, mode ref.RefUpdateMode, destRe
 		return err
 	}
 
+	err = CheckBranchUpdate(ctx, destDB, destRef, commit)
+
+	if err != nil {
+		return err
+	}
+
 	switch mode {
 	case ref.ForceUpdate:
 		err = destDB.SetHeadToCommit(ctx, destRef, commit)
@@ -129,6 +138,242 @@ func FetchCommit(ctx context.Context, dEnv *env.DoltEnv, srcDB, destDB *doltdb.D
 	return destDB.PullChunks(ctx, dEnv.TempTableFilesDir(), srcDB, stRef, progChan, pullerEventCh)
 }
 
+// FetchCommitShallow fetches a commit and the |depth| - 1 generations of commits behind it, along with their
+// underlying data, from a remote source database to the local destination database. It returns the hashes of the
+// fetched commits whose parents were not fetched, which make up the shallow boundary of the destination database.
+func FetchCommitShallow(ctx context.Context, dEnv *env.DoltEnv, srcDB, destDB *doltdb.DoltDB, srcDBCommit *doltdb.Commit, depth int, pullerEventCh chan datas.PullerEvent) ([]hash.Hash, error) {
+	if depth < 1 {
+		return nil, errors.New("depth must be a positive number")
+	}
+
+	return fetchCommitPartial(ctx, dEnv, srcDB, destDB, srcDBCommit, depth, nil, pullerEventCh)
+}
+
+// FetchCommitSparse fetches a commit like FetchCommit, or like FetchCommitShallow if depth is positive, but only
+// fetches the data of the tables given and of the dolt system tables. The data of the other tables is left in the
+// source database, and has to be read from it when it is accessed. The tables given must exist in the commit.
+func FetchCommitSparse(ctx context.Context, dEnv *env.DoltEnv, srcDB, destDB *doltdb.DoltDB, srcDBCommit *doltdb.Commit, depth int, tables []string, pullerEventCh chan datas.PullerEvent) ([]hash.Hash, error) {
+	root, err := srcDBCommit.GetRootValue()
+
+	if err != nil {
+		return nil, err
+	}
+
+	tableHashes := make([]hash.Hash, len(tables))
+	for i, tbl := range tables {
+		h, ok, err := root.GetTableHash(ctx, tbl)
+
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			return nil, fmt.Errorf("table '%s' not found", tbl)
+		}
+
+		tableHashes[i] = h
+	}
+
+	shallow, err := fetchCommitPartial(ctx, dEnv, srcDB, destDB, srcDBCommit, depth, tables, pullerEventCh)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// the commit may have been fetched before without some of the tables, which are fetched now
+	for _, h := range tableHashes {
+		err = destDB.PushChunksForRefHash(ctx, dEnv.TempTableFilesDir(), srcDB, h, pullerEventCh)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return shallow, nil
+}
+
+// fetchCommitPartial fetches a commit and the part of its history which is missing from the destination database. If
+// depth is positive, the history is truncated after |depth| generations of commits, and the hashes of the fetched
+// commits whose parents were not fetched are returned. If tables is not nil, the data of the tables which are not in
+// it, other than the dolt system tables, is not fetched.
+func fetchCommitPartial(ctx context.Context, dEnv *env.DoltEnv, srcDB, destDB *doltdb.DoltDB, srcDBCommit *doltdb.Commit, depth int, tables []string, pullerEventCh chan datas.PullerEvent) ([]hash.Hash, error) {
+	h, err := srcDBCommit.HashOf()
+
+	if err != nil {
+		return nil, err
+	}
+
+	walked := hash.NewHashSet(h)
+	excluded := make(hash.HashSet)
+	var commits []*doltdb.Commit
+	var shallow []hash.Hash
+
+	// walk the history breadth first, so that every commit within |depth| generations is fetched no matter which
+	// path it is reached through
+	generation := []*doltdb.Commit{srcDBCommit}
+	for i := 1; len(generation) > 0; i++ {
+		var next []*doltdb.Commit
+		for _, cm := range generation {
+			commits = append(commits, cm)
+			parentHashes, err := cm.ParentHashes(ctx)
+
+			if err != nil {
+				return nil, err
+			}
+
+			if depth > 0 && i >= depth {
+				isBoundary := false
+				for _, ph := range parentHashes {
+					if !walked.Has(ph) {
+						excluded.Insert(ph)
+						isBoundary = true
+					}
+				}
+
+				if isBoundary {
+					h, err := cm.HashOf()
+
+					if err != nil {
+						return nil, err
+					}
+
+					shallow = append(shallow, h)
+				}
+
+				continue
+			}
+
+			for _, ph := range parentHashes {
+				if walked.Has(ph) {
+					continue
+				}
+
+				walked.Insert(ph)
+
+				// history which was fetched before doesn't need to be walked
+				fetched, err := destDB.HasChunk(ctx, ph)
+
+				if err != nil {
+					return nil, err
+				} else if fetched {
+					continue
+				}
+
+				cs, err := doltdb.NewCommitSpec(ph.String())
+
+				if err != nil {
+					return nil, err
+				}
+
+				parent, err := srcDB.Resolve(ctx, cs, nil)
+
+				if err != nil {
+					return nil, err
+				}
+
+				next = append(next, parent)
+			}
+		}
+
+		generation = next
+	}
+
+	if tables != nil {
+		err = excludeUnlistedTables(ctx, commits, tables, excluded)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	stRef, err := srcDBCommit.GetStRef()
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = destDB.PullChunksExcluding(ctx, dEnv.TempTableFilesDir(), srcDB, stRef, excluded, pullerEventCh)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return shallow, nil
+}
+
+// excludeUnlistedTables adds the hashes of the tables of the commits given which are not in tables, and which are not
+// dolt system tables, to excluded.
+func excludeUnlistedTables(ctx context.Context, commits []*doltdb.Commit, tables []string, excluded hash.HashSet) error {
+	listed := set.NewStrSet(tables)
+	included := make(hash.HashSet)
+	for _, cm := range commits {
+		root, err := cm.GetRootValue()
+
+		if err != nil {
+			return err
+		}
+
+		names, err := root.GetTableNames(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		for _, name := range names {
+			h, _, err := root.GetTableHash(ctx, name)
+
+			if err != nil {
+				return err
+			}
+
+			if listed.Contains(name) || doltdb.HasDoltPrefix(name) {
+				included.Insert(h)
+			} else {
+				excluded.Insert(h)
+			}
+		}
+	}
+
+	// an unlisted table can have the same data as a listed table
+	for h := range included {
+		excluded.Remove(h)
+	}
+
+	return nil
+}
+
+// FetchShallowHistory fetches the history behind the shallow boundary commits given from a remote source database
+// to the local destination database, after which the destination database is no longer shallow.
+func FetchShallowHistory(ctx context.Context, dEnv *env.DoltEnv, srcDB, destDB *doltdb.DoltDB, shallow []hash.Hash, pullerEventCh chan datas.PullerEvent) error {
+	for _, h := range shallow {
+		cs, err := doltdb.NewCommitSpec(h.String())
+
+		if err != nil {
+			return err
+		}
+
+		cm, err := destDB.Resolve(ctx, cs, nil)
+
+		if err != nil {
+			return err
+		}
+
+		parentHashes, err := cm.ParentHashes(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		for _, ph := range parentHashes {
+			err = destDB.PushChunksForRefHash(ctx, dEnv.TempTableFilesDir(), srcDB, ph, pullerEventCh)
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
 // FetchCommit takes a fetches a commit tag and all underlying data from a remote source database to the local destination database.
 func FetchTag(ctx context.Context, dEnv *env.DoltEnv, srcDB, destDB *doltdb.DoltDB, srcDBTag *doltdb.Tag, progChan chan datas.PullProgress, pullerEventCh chan datas.PullerEvent) error {
 	stRef, err := srcDBTag.GetStRef()
@@ -20,6 +20,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
 )
 
@@ -53,7 +54,7 @@ func AutoResolveTables(ctx context.Context, dEnv *env.DoltEnv, autoResolver merg
 }
 
 func autoResolve(ctx context.Context, dEnv *env.DoltEnv, root *doltdb.RootValue, autoResolver merge.AutoResolver, tbls []string) error {
-	tableEditSession := editor.CreateTableEditSession(root, editor.TableEditSessionProps{})
+	tableEditSession := editor.CreateTableEditSession(root, editor.TableEditSessionProps{Checks: sqlutil.NewCheckEvaluator})
 
 	for _, tblName := range tbls {
 		tbl, ok, err := root.GetTable(ctx, tblName)
@@ -80,3 +81,30 @@ func autoResolve(ctx context.Context, dEnv *env.DoltEnv, root *doltdb.RootValue,
 
 	return dEnv.UpdateWorkingRoot(ctx, newRoot)
 }
+
+// ResolveSchemaConflicts resolves the schema conflicts of the tables given using |resolver|, and returns the names of
+// the tables given which do not have a schema conflict. The rows of a table with a schema conflict were never merged, so
+// it has no row conflicts to resolve.
+func ResolveSchemaConflicts(ctx context.Context, dEnv *env.DoltEnv, resolver merge.SchemaConflictResolver, tbls []string) ([]string, error) {
+	root, err := dEnv.WorkingRoot(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var remaining []string
+	for _, tblName := range tbls {
+		resolved, err := merge.ResolveSchemaConflict(ctx, root, tblName, resolver)
+
+		if err == doltdb.ErrNoConflicts {
+			remaining = append(remaining, tblName)
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		root = resolved
+	}
+
+	return remaining, dEnv.UpdateWorkingRoot(ctx, root)
+}
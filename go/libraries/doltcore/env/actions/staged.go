@@ -169,8 +169,15 @@ func checkTablesForConflicts(ctx context.Context, tbls []string, working *doltdb
 
 			if num > 0 {
 				inConflict = append(inConflict, tblName)
+				continue
 			}
 		}
+
+		if has, err := tbl.HasSchemaConflict(); err != nil {
+			return nil, err
+		} else if has {
+			inConflict = append(inConflict, tblName)
+		}
 	}
 
 	if len(inConflict) > 0 {
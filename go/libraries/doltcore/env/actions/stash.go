@@ -0,0 +1,233 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+)
+
+var ErrNoLocalChanges = errors.New("no local changes to save")
+
+// StashChanges saves the working and staged changes of |dEnv| as a new stash and resets the working set and the staged
+// tables to HEAD. If |message| is empty a message describing the HEAD commit is used.
+func StashChanges(ctx context.Context, dEnv *env.DoltEnv, message string) (*doltdb.Stash, error) {
+	headRef := dEnv.RepoState.CWBHeadRef()
+	headCommit, err := dEnv.DoltDB.ResolveRef(ctx, headRef)
+
+	if err != nil {
+		return nil, err
+	}
+
+	headRoot, err := headCommit.GetRootValue()
+
+	if err != nil {
+		return nil, err
+	}
+
+	headHash, err := headRoot.HashOf()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if dEnv.RepoState.WorkingHash() == headHash && dEnv.RepoState.StagedHash() == headHash {
+		return nil, ErrNoLocalChanges
+	}
+
+	roots, err := getRoots(ctx, dEnv, WorkingRoot, StagedRoot)
+
+	if err != nil {
+		return nil, err
+	}
+
+	name, email, err := GetNameAndEmail(dEnv.Config)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if message == "" {
+		headMeta, err := headCommit.GetCommitMeta()
+
+		if err != nil {
+			return nil, err
+		}
+
+		cmHash, err := headCommit.HashOf()
+
+		if err != nil {
+			return nil, err
+		}
+
+		message = fmt.Sprintf("WIP on %s: %s %s", headRef.GetPath(), cmHash.String(), headMeta.Description)
+	} else {
+		message = fmt.Sprintf("On %s: %s", headRef.GetPath(), message)
+	}
+
+	meta, err := doltdb.NewCommitMeta(name, email, message)
+
+	if err != nil {
+		return nil, err
+	}
+
+	stash, err := dEnv.DoltDB.NewStash(ctx, headCommit, roots[StagedRoot], roots[WorkingRoot], meta)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = dEnv.UpdateWorkingRoot(ctx, headRoot)
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = dEnv.UpdateStagedRoot(ctx, headRoot)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = SaveTrackedDocsFromWorking(ctx, dEnv)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return stash, nil
+}
+
+// GetStashes returns all the stashes in |ddb| ordered from newest to oldest.
+func GetStashes(ctx context.Context, ddb *doltdb.DoltDB) ([]*doltdb.Stash, error) {
+	stashRefs, err := ddb.GetStashes(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var stashes []*doltdb.Stash
+	for _, r := range stashRefs {
+		sr, ok := r.(ref.StashRef)
+		if !ok {
+			return nil, fmt.Errorf("DoltDB.GetStashes() returned non-stash DoltRef")
+		}
+
+		stash, err := ddb.ResolveStash(ctx, sr)
+		if err != nil {
+			return nil, err
+		}
+
+		stashes = append(stashes, stash)
+	}
+
+	sort.SliceStable(stashes, func(i, j int) bool {
+		return stashes[i].Meta.Timestamp > stashes[j].Meta.Timestamp
+	})
+
+	return stashes, nil
+}
+
+// ApplyStash applies the changes saved in |stash| to the working set of |dEnv| with a three-way merge which uses the
+// commit the changes were stashed on as the common ancestor. If merging the working changes results in conflicts they
+// are left in the working set and the staged tables are not updated. Otherwise the stashed staged changes are merged
+// into the staged tables.
+func ApplyStash(ctx context.Context, dEnv *env.DoltEnv, stash *doltdb.Stash) (map[string]*merge.MergeStats, error) {
+	roots, err := getRoots(ctx, dEnv, WorkingRoot, StagedRoot)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ancRoot, err := stash.HeadCommit.GetRootValue()
+
+	if err != nil {
+		return nil, err
+	}
+
+	stashWorking, err := stash.GetWorkingRoot()
+
+	if err != nil {
+		return nil, err
+	}
+
+	mergedWorking, tblToStats, err := merge.MergeRoots(ctx, roots[WorkingRoot], stashWorking, ancRoot, merge.MergeOpts{Checks: sqlutil.NewCheckEvaluator})
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = dEnv.UpdateWorkingRoot(ctx, mergedWorking)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = SaveTrackedDocsFromWorking(ctx, dEnv)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if hasConflicts(tblToStats) {
+		return tblToStats, nil
+	}
+
+	stashStaged, err := stash.GetStagedRoot()
+
+	if err != nil {
+		return nil, err
+	}
+
+	mergedStaged, stagedStats, err := merge.MergeRoots(ctx, roots[StagedRoot], stashStaged, ancRoot, merge.MergeOpts{Checks: sqlutil.NewCheckEvaluator})
+
+	if err != nil {
+		return nil, err
+	}
+
+	// staged changes which can't be merged cleanly remain unstaged changes in the working set
+	if !hasConflicts(stagedStats) {
+		_, err = dEnv.UpdateStagedRoot(ctx, mergedStaged)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return tblToStats, nil
+}
+
+// DropStash deletes |stash| from the database.
+func DropStash(ctx context.Context, ddb *doltdb.DoltDB, stash *doltdb.Stash) error {
+	return ddb.DeleteStash(ctx, stash.GetDoltRef())
+}
+
+func hasConflicts(tblToStats map[string]*merge.MergeStats) bool {
+	for _, stats := range tblToStats {
+		if stats.HasConflicts() {
+			return true
+		}
+	}
+
+	return false
+}
@@ -125,11 +125,38 @@ func Load(ctx context.Context, hdp HomeDirProvider, fs filesys.Filesys, urlStr,
 		}
 	}
 
+	if dbLoadErr == nil && rsErr == nil && repoState.Sparse != nil {
+		dEnv.DoltDB, dEnv.DBLoadError = dEnv.WithSparseFallback(ddb)
+	}
+
+	if dEnv.DBLoadError == nil && rsErr == nil && len(repoState.Shallow) > 0 {
+		boundary := make([]hash.Hash, len(repoState.Shallow))
+		for i, hashStr := range repoState.Shallow {
+			boundary[i] = hash.Parse(hashStr)
+		}
+
+		dEnv.DBLoadError = dEnv.DoltDB.SetShallowBoundary(ctx, boundary)
+	}
+
 	dbfactory.InitializeFactories(dEnv)
 
 	return dEnv
 }
 
+// WithSparseFallback returns a DoltDB which reads the chunks missing from ddb from the remote of the sparse clone. The
+// remote is only opened once a missing chunk is read.
+func (dEnv *DoltEnv) WithSparseFallback(ddb *doltdb.DoltDB) (*doltdb.DoltDB, error) {
+	return ddb.WithFallback(func(ctx context.Context) (*doltdb.DoltDB, error) {
+		r, ok := dEnv.RepoState.Remotes[dEnv.RepoState.Sparse.Remote]
+
+		if !ok {
+			return nil, fmt.Errorf("remote '%s' of the sparse clone not found", dEnv.RepoState.Sparse.Remote)
+		}
+
+		return r.GetRemoteDB(ctx, ddb.ValueReadWriter().Format())
+	})
+}
+
 // HasDoltDir returns true if the .dolt directory exists and is a valid directory
 func (dEnv *DoltEnv) HasDoltDir() bool {
 	return dEnv.hasDoltDir("./")
@@ -551,6 +578,10 @@ func (dEnv *DoltEnv) IsMergeActive() bool {
 	return dEnv.RepoState.Merge != nil
 }
 
+func (dEnv *DoltEnv) IsRebaseActive() bool {
+	return dEnv.RepoState.Rebase != nil
+}
+
 func (dEnv *DoltEnv) GetTablesWithConflicts(ctx context.Context) ([]string, error) {
 	root, err := dEnv.WorkingRoot(ctx)
 
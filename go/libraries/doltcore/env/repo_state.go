@@ -64,13 +64,48 @@ type MergeState struct {
 	PreMergeWorking string `json:"working_pre_merge"`
 }
 
+// RebaseStep is a single step of a rebase. Action is one of the rebase plan actions (pick, squash, fixup, drop or
+// reword), Commit is the hash of the commit the action applies to, and Message is the new commit message of a reword.
+type RebaseStep struct {
+	Action  string `json:"action"`
+	Commit  string `json:"commit"`
+	Message string `json:"message,omitempty"`
+}
+
+// RebaseState tracks a rebase of the current branch which stopped before all of its commits were replayed.
+type RebaseState struct {
+	// OrigHead is the hash of the commit the branch pointed at before the rebase started
+	OrigHead string `json:"orig_head"`
+	// Onto is the hash of the commit the branch is being rebased onto
+	Onto string `json:"onto"`
+	// Current is the step whose changes are being replayed, or nil if no step was stopped on
+	Current *RebaseStep `json:"current"`
+	// Todo is the list of steps which still need to be replayed, oldest commit first
+	Todo []RebaseStep `json:"todo"`
+	// Committed is true once a replayed step has created a commit on top of Onto
+	Committed bool `json:"committed,omitempty"`
+}
+
+// SparseState describes a sparse clone, which only fetched the data of some of the tables of a remote
+type SparseState struct {
+	// Remote is the name of the remote which the data of the other tables is read from
+	Remote string `json:"remote"`
+	// Tables is the list of tables whose data was fetched
+	Tables []string `json:"tables"`
+}
+
 type RepoState struct {
 	Head     ref.MarshalableRef      `json:"head"`
 	Staged   string                  `json:"staged"`
 	Working  string                  `json:"working"`
 	Merge    *MergeState             `json:"merge"`
+	Rebase   *RebaseState            `json:"rebase,omitempty"`
 	Remotes  map[string]Remote       `json:"remotes"`
 	Branches map[string]BranchConfig `json:"branches"`
+	// Shallow is the hashes of the commits of a shallow clone whose parents were not fetched
+	Shallow []string `json:"shallow,omitempty"`
+	// Sparse is the state of a sparse clone, or nil if every table was fetched
+	Sparse *SparseState `json:"sparse,omitempty"`
 }
 
 func LoadRepoState(fs filesys.ReadWriteFS) (*RepoState, error) {
@@ -99,8 +134,11 @@ func CloneRepoState(fs filesys.ReadWriteFS, r Remote) (*RepoState, error) {
 		hashStr,
 		hashStr,
 		nil,
+		nil,
 		map[string]Remote{r.Name: r},
 		make(map[string]BranchConfig),
+		nil,
+		nil,
 	}
 
 	err := rs.Save(fs)
@@ -125,8 +163,11 @@ func CreateRepoState(fs filesys.ReadWriteFS, br string, rootHash hash.Hash) (*Re
 		hashStr,
 		hashStr,
 		nil,
+		nil,
 		make(map[string]Remote),
 		make(map[string]BranchConfig),
+		nil,
+		nil,
 	}
 
 	err = rs.Save(fs)
@@ -174,6 +215,20 @@ func (rs *RepoState) ClearMerge(fs filesys.Filesys) error {
 	return rs.Save(fs)
 }
 
+func (rs *RepoState) StartRebase(rebase *RebaseState, fs filesys.Filesys) error {
+	rs.Rebase = rebase
+	return rs.Save(fs)
+}
+
+func (rs *RepoState) ClearRebase(fs filesys.Filesys) error {
+	rs.Rebase = nil
+	return rs.Save(fs)
+}
+
+func (rs *RepoState) IsRebaseActive() bool {
+	return rs.Rebase != nil
+}
+
 func (rs *RepoState) AddRemote(r Remote) {
 	rs.Remotes[r.Name] = r
 }
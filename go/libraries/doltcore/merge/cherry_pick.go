@@ -0,0 +1,72 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+)
+
+var ErrCommitHasNoParent = errors.New("commit has no parent")
+var ErrCommitIsMerge = errors.New("commit is a merge commit")
+
+// CherryPick applies the changes introduced by |cm| relative to its parent onto |root|. The changes are applied using a
+// three-way merge where the parent of |cm| is the ancestor, so rows which were modified by |cm| and which have also
+// been modified in |root| are returned as conflicts on the resulting root.
+func CherryPick(ctx context.Context, ddb *doltdb.DoltDB, root *doltdb.RootValue, cm *doltdb.Commit, opts MergeOpts) (*doltdb.RootValue, map[string]*MergeStats, error) {
+	cmRoot, parentRoot, err := getCommitAndParentRoots(ctx, ddb, cm)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return MergeRoots(ctx, root, cmRoot, parentRoot, opts)
+}
+
+func getCommitAndParentRoots(ctx context.Context, ddb *doltdb.DoltDB, cm *doltdb.Commit) (*doltdb.RootValue, *doltdb.RootValue, error) {
+	numParents, err := cm.NumParents()
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if numParents == 0 {
+		return nil, nil, ErrCommitHasNoParent
+	} else if numParents > 1 {
+		return nil, nil, ErrCommitIsMerge
+	}
+
+	parent, err := ddb.ResolveParent(ctx, cm, 0)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cmRoot, err := cm.GetRootValue()
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	parentRoot, err := parent.GetRootValue()
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return cmRoot, parentRoot, nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+
+	sqle "github.com/dolthub/go-mysql-server"
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// defaultsSchema returns a sql.Schema for |sch| whose column defaults are resolved, so that they can be evaluated by
+// applyColumnDefaults.
+func defaultsSchema(tblName string, sch schema.Schema) (sql.Schema, error) {
+	cols := make([]*sqle.ColumnWithRawDefault, 0, sch.GetAllCols().Size())
+	_ = sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		cols = append(cols, &sqle.ColumnWithRawDefault{
+			SqlColumn: &sql.Column{
+				Name:          col.Name,
+				Type:          col.TypeInfo.ToSqlType(),
+				Nullable:      col.IsNullable(),
+				Source:        tblName,
+				PrimaryKey:    col.IsPartOfPK,
+				AutoIncrement: col.AutoIncrement,
+			},
+			Default: col.Default,
+		})
+		return false, nil
+	})
+
+	return sqle.ResolveDefaults(tblName, cols)
+}
+
+// applyColumnDefaults sets the columns of |r| at |indexes| in the columns of |sch| to their default values. |sqlSch| is
+// the schema returned by defaultsSchema for |sch|.
+func applyColumnDefaults(ctx context.Context, vrw types.ValueReadWriter, sch schema.Schema, sqlSch sql.Schema, indexes []int, r row.Row) (row.Row, error) {
+	sqlCtx, ok := ctx.(*sql.Context)
+	if !ok {
+		sqlCtx = sql.NewContext(ctx)
+	}
+
+	cols := sch.GetAllCols()
+	sqlRow := make(sql.Row, len(sqlSch))
+	for i, tag := range cols.Tags {
+		if val, ok := r.GetColVal(tag); ok {
+			var err error
+			sqlRow[i], err = cols.TagToCol[tag].TypeInfo.ConvertNomsValueToValue(val)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	sqlRow, err := sqle.ApplyDefaults(sqlCtx, sqlSch, indexes, sqlRow)
+	if err != nil {
+		return nil, err
+	}
+
+	taggedVals := make(row.TaggedValues)
+	for i, tag := range cols.Tags {
+		if sqlRow[i] == nil {
+			continue
+		}
+
+		taggedVals[tag], err = cols.TagToCol[tag].TypeInfo.ConvertValueToNomsValue(ctx, vrw, sqlRow[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return row.New(r.Format(), sch, taggedVals)
+}
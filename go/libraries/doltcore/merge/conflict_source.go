@@ -41,7 +41,8 @@ const (
 type ConflictReader struct {
 	confItr types.MapIterator
 	joiner  *rowconv.Joiner
-	nbf     *types.NomsBinFormat
+	tblSch  schema.Schema
+	vrw     types.ValueReadWriter
 }
 
 // NewConflictReader returns a new conflict reader for a given table
@@ -68,6 +69,12 @@ func NewConflictReader(ctx context.Context, tbl *doltdb.Table) (*ConflictReader,
 		return nil, err
 	}
 
+	tblSch, err := tbl.GetSchema(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
 	_, confData, err := tbl.GetConflicts(ctx)
 
 	if err != nil {
@@ -80,17 +87,17 @@ func NewConflictReader(ctx context.Context, tbl *doltdb.Table) (*ConflictReader,
 		return nil, err
 	}
 
-	return &ConflictReader{confItr, joiner, tbl.Format()}, nil
+	return &ConflictReader{confItr, joiner, tblSch, tbl.ValueReadWriter()}, nil
 }
 
-func tagMappingConverter(src, dest schema.Schema) (*rowconv.RowConverter, error) {
+func tagMappingConverter(ctx context.Context, vrw types.ValueReadWriter, src, dest schema.Schema) (*rowconv.RowConverter, error) {
 	mapping, err := rowconv.TagMapping(src, dest)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return rowconv.NewRowConverter(mapping)
+	return rowconv.NewRowConverter(ctx, vrw, mapping)
 }
 
 // GetSchema gets the schema of the rows that this reader will return
@@ -107,21 +114,31 @@ func (cr *ConflictReader) GetJoiner() *rowconv.Joiner {
 // io.EOF will be returned in the error field.  This can be used in a pipeline, or to iterate through all the conflicts
 // in a table.
 func (cr *ConflictReader) NextConflict(ctx context.Context) (row.Row, pipeline.ImmutableProperties, error) {
+	r, _, err := cr.NextConflictWithColumns(ctx)
+	return r, pipeline.NoProps, err
+}
+
+// NextConflictWithColumns works like NextConflict, but also returns the names of the columns whose cells were changed
+// to different values on both sides of the merge. Columns are named as they are in the table's current schema. A row
+// which was deleted on one side and modified on the other is in conflict without any conflicting cells.
+// Cells are matched across the base, our and their rows by column tag, so columns which were renamed or added on one
+// side of the merge are compared correctly.
+func (cr *ConflictReader) NextConflictWithColumns(ctx context.Context) (row.Row, []string, error) {
 	key, value, err := cr.confItr.Next(ctx)
 
 	if err != nil {
-		return nil, pipeline.NoProps, err
+		return nil, nil, err
 	}
 
 	if key == nil {
-		return nil, pipeline.NoProps, io.EOF
+		return nil, nil, io.EOF
 	}
 
 	keyTpl := key.(types.Tuple)
 	conflict, err := doltdb.ConflictFromTuple(value.(types.Tuple))
 
 	if err != nil {
-		return nil, pipeline.NoProps, err
+		return nil, nil, err
 	}
 
 	namedRows := make(map[string]row.Row)
@@ -129,7 +146,7 @@ func (cr *ConflictReader) NextConflict(ctx context.Context) (row.Row, pipeline.I
 		namedRows[baseStr], err = row.FromNoms(cr.joiner.SchemaForName(baseStr), keyTpl, conflict.Base.(types.Tuple))
 
 		if err != nil {
-			return nil, pipeline.NoProps, err
+			return nil, nil, err
 		}
 	}
 
@@ -137,7 +154,7 @@ func (cr *ConflictReader) NextConflict(ctx context.Context) (row.Row, pipeline.I
 		namedRows[oursStr], err = row.FromNoms(cr.joiner.SchemaForName(oursStr), keyTpl, conflict.Value.(types.Tuple))
 
 		if err != nil {
-			return nil, pipeline.NoProps, err
+			return nil, nil, err
 		}
 	}
 
@@ -145,17 +162,71 @@ func (cr *ConflictReader) NextConflict(ctx context.Context) (row.Row, pipeline.I
 		namedRows[theirsStr], err = row.FromNoms(cr.joiner.SchemaForName(theirsStr), keyTpl, conflict.MergeValue.(types.Tuple))
 
 		if err != nil {
-			return nil, pipeline.NoProps, err
+			return nil, nil, err
 		}
 	}
 
 	joinedRow, err := cr.joiner.Join(namedRows)
 
 	if err != nil {
-		return nil, pipeline.NoProps, err
+		return nil, nil, err
+	}
+
+	cnfCols, err := cr.conflictingColumns(ctx, conflict)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return joinedRow, cnfCols, nil
+}
+
+func (cr *ConflictReader) conflictingColumns(ctx context.Context, conflict doltdb.Conflict) ([]string, error) {
+	if types.IsNull(conflict.Value) || types.IsNull(conflict.MergeValue) {
+		return nil, nil
+	}
+
+	var baseVals row.TaggedValues
+	if !types.IsNull(conflict.Base) {
+		var err error
+		baseVals, err = row.ParseTaggedValues(conflict.Base.(types.Tuple))
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	vals, err := row.ParseTaggedValues(conflict.Value.(types.Tuple))
+
+	if err != nil {
+		return nil, err
+	}
+
+	mergeVals, err := row.ParseTaggedValues(conflict.MergeValue.(types.Tuple))
+
+	if err != nil {
+		return nil, err
+	}
+
+	var cnfCols []string
+	err = cr.tblSch.GetNonPKCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		baseVal, _ := baseVals.Get(tag)
+		val, _ := vals.Get(tag)
+		mergeVal, _ := mergeVals.Get(tag)
+
+		_, isConflict, err := mergeColumnCell(ctx, cr.vrw, col, val, mergeVal, baseVal)
+		if isConflict {
+			cnfCols = append(cnfCols, col.Name)
+		}
+
+		return err != nil, err
+	})
+
+	if err != nil {
+		return nil, err
 	}
 
-	return joinedRow, pipeline.NoProps, nil
+	return cnfCols, nil
 }
 
 // GetKeyForConflicts returns the pk for a conflict row
@@ -0,0 +1,135 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// mergeColumnCell performs a three-way merge of a single cell of the given column. Cells of JSON columns that were
+// changed on both sides are merged structurally, so that changes made to different keys of a document don't conflict.
+// It returns the merged value, or true if the changes made on both sides could not be merged.
+func mergeColumnCell(ctx context.Context, vrw types.ValueReadWriter, col schema.Column, val, mergeVal, baseVal types.Value) (types.Value, bool, error) {
+	resultVal, isConflict := mergeCell(val, mergeVal, baseVal)
+	if !isConflict || col.TypeInfo.GetTypeIdentifier() != typeinfo.JSONTypeIdentifier {
+		return resultVal, isConflict, nil
+	}
+
+	return mergeJSONCell(ctx, vrw, val, mergeVal, baseVal)
+}
+
+// mergeJSONCell merges JSON documents which were changed on both sides of a merge. Objects are merged key by key, and
+// any other change made on both sides, such as changes to the same key or to the same array, is a conflict.
+func mergeJSONCell(ctx context.Context, vrw types.ValueReadWriter, val, mergeVal, baseVal types.Value) (types.Value, bool, error) {
+	var docs [3]types.Value
+	for i, v := range []types.Value{val, mergeVal, baseVal} {
+		st, ok := v.(types.Struct)
+		if !ok {
+			// a document was added or removed on one side
+			return nil, true, nil
+		}
+
+		var err error
+		docs[i], err = typeinfo.JSONDocFromNomsValue(st)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	merged, isConflict, err := mergeJSONValues(ctx, vrw, docs[0], docs[1], docs[2])
+	if err != nil || isConflict {
+		return nil, isConflict, err
+	}
+
+	resultVal, err := typeinfo.NewJSONNomsValue(vrw.Format(), merged)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return resultVal, false, nil
+}
+
+// mergeJSONValues performs a three-way merge of the values within JSON documents. A nil value is a key which is
+// missing from an object, which unlike a JSON null is not equal to types.NullValue.
+func mergeJSONValues(ctx context.Context, vrw types.ValueReadWriter, val, mergeVal, baseVal types.Value) (types.Value, bool, error) {
+	switch {
+	case jsonValuesEqual(val, mergeVal), jsonValuesEqual(mergeVal, baseVal):
+		return val, false, nil
+	case jsonValuesEqual(val, baseVal):
+		return mergeVal, false, nil
+	}
+
+	obj, ok := val.(types.Map)
+	mergeObj, mergeOk := mergeVal.(types.Map)
+	baseObj, baseOk := baseVal.(types.Map)
+	if !ok || !mergeOk || !baseOk {
+		return nil, true, nil
+	}
+
+	var keys []types.Value
+	seen := make(map[types.String]bool)
+	for _, m := range []types.Map{obj, mergeObj, baseObj} {
+		err := m.IterAll(ctx, func(k, _ types.Value) error {
+			if !seen[k.(types.String)] {
+				seen[k.(types.String)] = true
+				keys = append(keys, k)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	var kvs []types.Value
+	for _, k := range keys {
+		var elems [3]types.Value
+		for i, m := range []types.Map{obj, mergeObj, baseObj} {
+			elem, ok, err := m.MaybeGet(ctx, k)
+			if err != nil {
+				return nil, false, err
+			}
+			if ok {
+				elems[i] = elem
+			}
+		}
+
+		merged, isConflict, err := mergeJSONValues(ctx, vrw, elems[0], elems[1], elems[2])
+		if err != nil || isConflict {
+			return nil, isConflict, err
+		}
+		if merged != nil {
+			kvs = append(kvs, k, merged)
+		}
+	}
+
+	resultObj, err := types.NewMap(ctx, vrw, kvs...)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return resultObj, false, nil
+}
+
+func jsonValuesEqual(v1, v2 types.Value) bool {
+	if v1 == nil || v2 == nil {
+		return v1 == nil && v2 == nil
+	}
+	return v1.Equals(v2)
+}
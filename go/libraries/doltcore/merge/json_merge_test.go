@@ -0,0 +1,121 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestMergeJSONCell(t *testing.T) {
+	ctx := context.Background()
+	vrw := types.NewValueStore((&chunks.MemoryStorage{}).NewView())
+	col, err := schema.NewColumnWithTypeInfo("j", 1, typeinfo.JSONType, false, "", false, "")
+	require.NoError(t, err)
+
+	doc := func(text string) types.Value {
+		if text == "" {
+			return types.NullValue
+		}
+		val, err := typeinfo.JSONType.ParseValue(ctx, vrw, &text)
+		require.NoError(t, err)
+		return val
+	}
+
+	tests := []struct {
+		name           string
+		val            string
+		mergeVal       string
+		baseVal        string
+		expectedResult string
+		expectConflict bool
+	}{
+		{
+			"different keys changed",
+			`{"a": 2, "b": 1}`,
+			`{"a": 1, "b": 2}`,
+			`{"a": 1, "b": 1}`,
+			`{"a": 2, "b": 2}`,
+			false,
+		},
+		{
+			"different keys added and removed",
+			`{"a": 1, "c": 3}`,
+			`{"b": 1}`,
+			`{"a": 1, "b": 1}`,
+			`{"c": 3}`,
+			false,
+		},
+		{
+			"nested objects",
+			`{"o": {"x": 1, "y": 1}, "n": true}`,
+			`{"o": {"x": 0, "y": 2}}`,
+			`{"o": {"x": 0, "y": 1}}`,
+			`{"o": {"x": 1, "y": 2}, "n": true}`,
+			false,
+		},
+		{
+			"same key changed",
+			`{"a": 2}`,
+			`{"a": 3}`,
+			`{"a": 1}`,
+			"",
+			true,
+		},
+		{
+			"array changed on both sides",
+			`{"a": [1, 2]}`,
+			`{"a": [0, 1]}`,
+			`{"a": [1]}`,
+			"",
+			true,
+		},
+		{
+			"key removed and set to null",
+			`{}`,
+			`{"a": null}`,
+			`{"a": 1}`,
+			"",
+			true,
+		},
+		{
+			"document removed",
+			"",
+			`{"a": 1, "b": 2}`,
+			`{"a": 1}`,
+			"",
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, isConflict, err := mergeColumnCell(ctx, vrw, col, doc(test.val), doc(test.mergeVal), doc(test.baseVal))
+			require.NoError(t, err)
+			assert.Equal(t, test.expectConflict, isConflict)
+			if !test.expectConflict {
+				assert.True(t, doc(test.expectedResult).Equals(result))
+			}
+		})
+	}
+}
@@ -26,7 +26,9 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/row"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/checks"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+	"github.com/dolthub/dolt/go/libraries/utils/set"
 	"github.com/dolthub/dolt/go/libraries/utils/valutil"
 	"github.com/dolthub/dolt/go/store/atomicerr"
 	"github.com/dolthub/dolt/go/store/hash"
@@ -35,17 +37,23 @@ import (
 
 var ErrFastForward = errors.New("fast forward")
 var ErrSameTblAddedTwice = errors.New("table with same name added in 2 commits can't be merged")
+var ErrTblDeletedAndModified = errors.New("table deleted in one commit and modified in another can't be merged")
 
 type Merger struct {
 	root      *doltdb.RootValue
 	mergeRoot *doltdb.RootValue
 	ancRoot   *doltdb.RootValue
 	vrw       types.ValueReadWriter
+
+	// strategies are used to resolve conflicting rows automatically
+	strategies *MergeStrategies
+	// theirsLatest is true when the merge root is from a more recent commit than the root
+	theirsLatest bool
 }
 
 // NewMerger creates a new merger utility object.
 func NewMerger(ctx context.Context, root, mergeRoot, ancRoot *doltdb.RootValue, vrw types.ValueReadWriter) *Merger {
-	return &Merger{root, mergeRoot, ancRoot, vrw}
+	return &Merger{root, mergeRoot, ancRoot, vrw, nil, true}
 }
 
 // MergeTable merges schema and table data for the table tblName.
@@ -118,6 +126,11 @@ func (merger *Merger) MergeTable(ctx context.Context, tblName string, sess *edit
 		}
 
 		if h == anch {
+			if !mergeOk {
+				// table was only removed in the merge root
+				return nil, &MergeStats{Operation: TableRemoved}, nil
+			}
+
 			// fast-forward
 			ms := MergeStats{Operation: TableModified}
 			if h != mh {
@@ -133,6 +146,10 @@ func (merger *Merger) MergeTable(ctx context.Context, tblName string, sess *edit
 			// fast-forward
 			return tbl, &MergeStats{Operation: TableUnmodified}, nil
 		}
+
+		if !ok || !mergeOk {
+			return nil, nil, ErrTblDeletedAndModified
+		}
 	}
 
 	tblSchema, err := tbl.GetSchema(ctx)
@@ -145,13 +162,12 @@ func (merger *Merger) MergeTable(ctx context.Context, tblName string, sess *edit
 		return nil, nil, err
 	}
 
-	postMergeSchema, schConflicts, err := SchemaMerge(tblSchema, mergeTblSchema, ancTblSchema, tblName)
+	postMergeSchema, schConflicts, err := SchemaMerge(ctx, tblSchema, mergeTblSchema, ancTblSchema, tblName, sess.Props.Checks)
 	if err != nil {
 		return nil, nil, err
 	}
 	if schConflicts.Count() != 0 {
-		// error on schema conflicts for now
-		return nil, nil, schConflicts.AsError()
+		return setSchemaConflict(ctx, tbl, mergeTbl, ancTbl, schConflicts)
 	}
 
 	rows, err := tbl.GetRowData(ctx)
@@ -174,6 +190,18 @@ func (merger *Merger) MergeTable(ctx context.Context, tblName string, sess *edit
 		return nil, nil, err
 	}
 
+	// indexes which are new to our table are built once the rows have been merged
+	emptyIndexData, err := types.NewMap(ctx, merger.vrw)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, index := range indexesAddedByMerge(tblSchema, postMergeSchema) {
+		updatedTbl, err = updatedTbl.SetIndexRowData(ctx, index.Name(), emptyIndexData)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	err = sess.UpdateRoot(ctx, func(ctx context.Context, root *doltdb.RootValue) (*doltdb.RootValue, error) {
 		return root.PutTable(ctx, tblName, updatedTbl)
 	})
@@ -183,7 +211,23 @@ func (merger *Merger) MergeTable(ctx context.Context, tblName string, sess *edit
 		return nil, nil, err
 	}
 
-	resultTbl, conflicts, stats, err := mergeTableData(ctx, merger.vrw, tblName, postMergeSchema, rows, mergeRows, ancRows, updatedTblEditor, sess)
+	var res *strategyResolver
+	if merger.strategies != nil && !schema.IsKeyless(postMergeSchema) {
+		res = &strategyResolver{
+			tblName:      tblName,
+			sch:          postMergeSchema,
+			strategies:   merger.strategies,
+			theirsLatest: merger.theirsLatest,
+			vrw:          merger.vrw,
+		}
+	}
+
+	resultTbl, conflicts, stats, err := mergeTableData(ctx, merger.vrw, tblName, postMergeSchema, rows, mergeRows, ancRows, updatedTblEditor, sess, res)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resultTbl, err = migrateMergedTable(ctx, tblName, resultTbl, tblSchema, postMergeSchema, mergeRows)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -220,6 +264,136 @@ func (merger *Merger) MergeTable(ctx context.Context, tblName string, sess *edit
 	return resultTbl, stats, nil
 }
 
+// setSchemaConflict records that the schemas of |tbl| and |mergeTbl| could not be merged. The table keeps our schema
+// and rows, as the rows of their branch cannot be merged without a merged schema, and their table is recorded so the
+// conflict can be resolved to either side.
+func setSchemaConflict(ctx context.Context, tbl, mergeTbl, ancTbl *doltdb.Table, schConflicts SchemaConflict) (*doltdb.Table, *MergeStats, error) {
+	asr, err := ancTbl.GetSchemaRef()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sr, err := tbl.GetSchemaRef()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	msr, err := mergeTbl.GetSchemaRef()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resultTbl, err := tbl.SetSchemaConflict(ctx, doltdb.NewConflict(asr, sr, msr), mergeTbl, schConflicts.Description())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resultTbl, &MergeStats{Operation: TableModified, SchemaConflicts: schConflicts.Count()}, nil
+}
+
+// migrateMergedTable brings the rows and indexes of a merged table in line with the merged schema. Rows which were
+// added on our branch get the default value of any column added on their branch, indexes which were added or renamed
+// on their branch are built, and the data of indexes which no longer exist is removed.
+func migrateMergedTable(ctx context.Context, tblName string, tbl *doltdb.Table, ourSch, mergedSch schema.Schema, mergeRows types.Map) (*doltdb.Table, error) {
+	var defaultIndexes []int
+	for i, col := range mergedSch.GetAllCols().GetColumns() {
+		if _, ok := ourSch.GetAllCols().GetByTag(col.Tag); !ok && col.Default != "" {
+			defaultIndexes = append(defaultIndexes, i)
+		}
+	}
+
+	if len(defaultIndexes) > 0 {
+		sqlSch, err := defaultsSchema(tblName, mergedSch)
+		if err != nil {
+			return nil, err
+		}
+
+		rowData, err := tbl.GetRowData(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		me := rowData.Edit()
+		err = rowData.Iter(ctx, func(k, v types.Value) (stop bool, err error) {
+			if has, err := mergeRows.Has(ctx, k); err != nil || has {
+				// rows which exist on their branch already have a value for their new columns
+				return err != nil, err
+			}
+
+			r, err := row.FromNoms(mergedSch, k.(types.Tuple), v.(types.Tuple))
+			if err != nil {
+				return true, err
+			}
+
+			var missing []int
+			for _, i := range defaultIndexes {
+				if _, ok := r.GetColVal(mergedSch.GetAllCols().GetAtIndex(i).Tag); !ok {
+					missing = append(missing, i)
+				}
+			}
+			if len(missing) == 0 {
+				return false, nil
+			}
+
+			r, err = applyColumnDefaults(ctx, tbl.ValueReadWriter(), mergedSch, sqlSch, missing, r)
+			if err != nil {
+				return true, err
+			}
+
+			me.Set(r.NomsMapKey(mergedSch), r.NomsMapValue(mergedSch))
+			return false, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		rowData, err = me.Map(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		tbl, err = tbl.UpdateRows(ctx, rowData)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, index := range ourSch.Indexes().AllIndexes() {
+		if !mergedSch.Indexes().Contains(index.Name()) {
+			var err error
+			tbl, err = tbl.DeleteIndexRowData(ctx, index.Name())
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, index := range indexesAddedByMerge(ourSch, mergedSch) {
+		indexData, err := editor.RebuildIndex(ctx, tbl, index.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		tbl, err = tbl.SetIndexRowData(ctx, index.Name(), indexData)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return tbl, nil
+}
+
+// indexesAddedByMerge returns the indexes of the merged schema which our schema does not have.
+func indexesAddedByMerge(ourSch, mergedSch schema.Schema) []schema.Index {
+	var added []schema.Index
+	for _, index := range mergedSch.Indexes().AllIndexes() {
+		if ourIndex := ourSch.Indexes().GetByName(index.Name()); ourIndex == nil || !ourIndex.Equals(index) {
+			added = append(added, index)
+		}
+	}
+	return added
+}
+
 func calcTableMergeStats(ctx context.Context, tbl *doltdb.Table, mergeTbl *doltdb.Table) (MergeStats, error) {
 	rows, err := tbl.GetRowData(ctx)
 
@@ -260,11 +434,11 @@ func calcTableMergeStats(ctx context.Context, tbl *doltdb.Table, mergeTbl *doltd
 	return ms, nil
 }
 
-type rowMerger func(ctx context.Context, nbf *types.NomsBinFormat, sch schema.Schema, r, mergeRow, baseRow types.Value) (types.Value, bool, error)
+type rowMerger func(ctx context.Context, vrw types.ValueReadWriter, sch schema.Schema, r, mergeRow, baseRow types.Value) (types.Value, bool, error)
 
 type applicator func(ctx context.Context, sch schema.Schema, tableEditor editor.TableEditor, rowData types.Map, stats *MergeStats, change types.ValueChanged) error
 
-func mergeTableData(ctx context.Context, vrw types.ValueReadWriter, tblName string, sch schema.Schema, rows, mergeRows, ancRows types.Map, tblEdit editor.TableEditor, sess *editor.TableEditSession) (*doltdb.Table, types.Map, *MergeStats, error) {
+func mergeTableData(ctx context.Context, vrw types.ValueReadWriter, tblName string, sch schema.Schema, rows, mergeRows, ancRows types.Map, tblEdit editor.TableEditor, sess *editor.TableEditSession, res *strategyResolver) (*doltdb.Table, types.Map, *MergeStats, error) {
 	var rowMerge rowMerger
 	var applyChange applicator
 	if schema.IsKeyless(sch) {
@@ -365,14 +539,45 @@ func mergeTableData(ctx context.Context, vrw types.ValueReadWriter, tblName stri
 
 			if !processed {
 				r, mergeRow, ancRow := change.NewValue, mergeChange.NewValue, change.OldValue
-				mergedRow, isConflict, err := rowMerge(ctx, vrw.Format(), sch, r, mergeRow, ancRow)
+				mergedRow, isConflict, err := rowMerge(ctx, vrw, sch, r, mergeRow, ancRow)
 				if err != nil {
 					return err
 				}
 
-				if isConflict {
+				var resolved bool
+				var resolvedRow types.Value
+				if isConflict && res != nil {
+					resolvedRow, resolved, err = res.resolveRow(ctx, r, mergeRow, ancRow)
+					if err != nil {
+						return err
+					}
+				}
+
+				if resolved {
+					stats.Resolved++
+					err = applyResolvedRow(ctx, sch, tblEdit, rows, stats, key, r, resolvedRow)
+					if err != nil {
+						return err
+					}
+				} else if isConflict {
+					if resolvedRow != nil {
+						mergedRow = resolvedRow
+					}
+
+					// the cells which are not in conflict are merged into our row, and the conflict records the merged
+					// row as ours so that it matches the row in the table
+					ours := r
+					if mergedRow != nil && !mergedRow.Equals(r) {
+						ours = mergedRow
+						vc := types.ValueChanged{ChangeType: change.ChangeType, Key: key, OldValue: ancRow, NewValue: mergedRow}
+						err = applyChange(ctx, sch, tblEdit, rows, stats, vc)
+						if err != nil {
+							return err
+						}
+					}
+
 					stats.Conflicts++
-					conflictTuple, err := doltdb.NewConflict(ancRow, r, mergeRow).ToNomsList(vrw)
+					conflictTuple, err := doltdb.NewConflict(ancRow, ours, mergeRow).ToNomsList(vrw)
 					if err != nil {
 						return err
 					}
@@ -426,6 +631,25 @@ func mergeTableData(ctx context.Context, vrw types.ValueReadWriter, tblName stri
 	return mergedTable, conflicts, stats, nil
 }
 
+// applyResolvedRow replaces our row with a row resolved by a merge strategy
+func applyResolvedRow(ctx context.Context, sch schema.Schema, tblEdit editor.TableEditor, rows types.Map, stats *MergeStats, key, r, resolvedRow types.Value) error {
+	var vc types.ValueChanged
+	switch {
+	case r == nil && resolvedRow == nil:
+		return nil
+	case r != nil && resolvedRow != nil && r.Equals(resolvedRow):
+		return nil
+	case resolvedRow == nil:
+		vc = types.ValueChanged{ChangeType: types.DiffChangeRemoved, Key: key, OldValue: r}
+	case r == nil:
+		vc = types.ValueChanged{ChangeType: types.DiffChangeAdded, Key: key, NewValue: resolvedRow}
+	default:
+		vc = types.ValueChanged{ChangeType: types.DiffChangeModified, Key: key, OldValue: r, NewValue: resolvedRow}
+	}
+
+	return applyPkChange(ctx, sch, tblEdit, rows, stats, vc)
+}
+
 func addConflict(conflictChan chan types.Value, done <-chan struct{}, key types.Value, value types.Tuple) error {
 	select {
 	case conflictChan <- key:
@@ -594,7 +818,12 @@ func convertValueChanged(vc types.ValueChanged) (types.ValueChanged, uint64, err
 	}
 }
 
-func pkRowMerge(ctx context.Context, nbf *types.NomsBinFormat, sch schema.Schema, r, mergeRow, baseRow types.Value) (types.Value, bool, error) {
+// pkRowMerge merges the changes made to a row on both sides of a merge cell by cell, matching the cells of each row by
+// column tag. Cells that were modified on only one side take the modified value. If both sides made different changes
+// to the same cell the row is in conflict, and the partially merged row, which keeps our value for each conflicting
+// cell, is returned along with true. If the row was deleted on one side and modified on the other there is no partial
+// merge and a nil row is returned along with true.
+func pkRowMerge(ctx context.Context, vrw types.ValueReadWriter, sch schema.Schema, r, mergeRow, baseRow types.Value) (types.Value, bool, error) {
 	var baseVals row.TaggedValues
 	if baseRow == nil {
 		if r.Equals(mergeRow) {
@@ -626,58 +855,61 @@ func pkRowMerge(ctx context.Context, nbf *types.NomsBinFormat, sch schema.Schema
 		return nil, false, err
 	}
 
-	processTagFunc := func(tag uint64) (resultVal types.Value, isConflict bool) {
+	resultVals := make(row.TaggedValues)
+
+	var isConflict bool
+	err = sch.GetNonPKCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
 		baseVal, _ := baseVals.Get(tag)
 		val, _ := rowVals.Get(tag)
 		mergeVal, _ := mergeVals.Get(tag)
 
-		if valutil.NilSafeEqCheck(val, mergeVal) {
-			return val, false
-		} else {
-			modified := !valutil.NilSafeEqCheck(val, baseVal)
-			mergeModified := !valutil.NilSafeEqCheck(mergeVal, baseVal)
-			switch {
-			case modified && mergeModified:
-				return nil, true
-			case modified:
-				return val, false
-			default:
-				return mergeVal, false
-			}
+		resultVal, cellConflict, err := mergeColumnCell(ctx, vrw, col, val, mergeVal, baseVal)
+		if err != nil {
+			return true, err
+		}
+		if cellConflict {
+			isConflict = true
+			resultVal = val
 		}
 
-	}
-
-	resultVals := make(row.TaggedValues)
-
-	var isConflict bool
-	err = sch.GetNonPKCols().Iter(func(tag uint64, _ schema.Column) (stop bool, err error) {
-		var val types.Value
-		val, isConflict = processTagFunc(tag)
-		resultVals[tag] = val
-
-		return isConflict, nil
+		resultVals[tag] = resultVal
+		return false, nil
 	})
 
 	if err != nil {
 		return nil, false, err
 	}
 
-	if isConflict {
-		return nil, true, nil
-	}
-
-	tpl := resultVals.NomsTupleForNonPKCols(nbf, sch.GetNonPKCols())
+	tpl := resultVals.NomsTupleForNonPKCols(vrw.Format(), sch.GetNonPKCols())
 	v, err := tpl.Value(ctx)
 
 	if err != nil {
 		return nil, false, err
 	}
 
-	return v, false, nil
+	return v, isConflict, nil
 }
 
-func keylessRowMerge(ctx context.Context, nbf *types.NomsBinFormat, sch schema.Schema, val, mergeVal, ancVal types.Value) (types.Value, bool, error) {
+// mergeCell performs a three-way merge of a single cell. It returns the merged value, or true if both sides changed
+// the cell to different values.
+func mergeCell(val, mergeVal, baseVal types.Value) (types.Value, bool) {
+	if valutil.NilSafeEqCheck(val, mergeVal) {
+		return val, false
+	}
+
+	modified := !valutil.NilSafeEqCheck(val, baseVal)
+	mergeModified := !valutil.NilSafeEqCheck(mergeVal, baseVal)
+	switch {
+	case modified && mergeModified:
+		return nil, true
+	case modified:
+		return val, false
+	default:
+		return mergeVal, false
+	}
+}
+
+func keylessRowMerge(ctx context.Context, vrw types.ValueReadWriter, sch schema.Schema, val, mergeVal, ancVal types.Value) (types.Value, bool, error) {
 	// both sides of the merge produced a diff for this key,
 	// so we always throw a conflict
 	return nil, true, nil
@@ -718,7 +950,7 @@ func mergeAutoIncrementValues(ctx context.Context, tbl, otherTbl, resultTbl *dol
 	return resultTbl.SetAutoIncrementValue(autoVal)
 }
 
-func MergeCommits(ctx context.Context, commit, mergeCommit *doltdb.Commit) (*doltdb.RootValue, map[string]*MergeStats, error) {
+func MergeCommits(ctx context.Context, commit, mergeCommit *doltdb.Commit, opts MergeOpts) (*doltdb.RootValue, map[string]*MergeStats, error) {
 	ancCommit, err := doltdb.GetCommitAncestor(ctx, commit, mergeCommit)
 
 	if err != nil {
@@ -743,11 +975,51 @@ func MergeCommits(ctx context.Context, commit, mergeCommit *doltdb.Commit) (*dol
 		return nil, nil, err
 	}
 
-	return MergeRoots(ctx, ourRoot, theirRoot, ancRoot)
+	meta, err := commit.GetCommitMeta()
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mergeMeta, err := mergeCommit.GetCommitMeta()
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return mergeRoots(ctx, ourRoot, theirRoot, ancRoot, opts, mergeMeta.UserTimestamp >= meta.UserTimestamp)
+}
+
+// MergeOpts are the options of a merge of two roots.
+type MergeOpts struct {
+	// NoStrategies leaves every conflicting row in conflict, ignoring the merge strategies declared for them
+	NoStrategies bool
+	// Checks creates the evaluators of the CHECK constraints of the merged tables. Merges without one fail when a
+	// merged table has CHECK constraints.
+	Checks checks.EvaluatorFactory
+}
+
+// MergeRoots merges theirRoot into ourRoot using ancRoot as the common ancestor. Unless the options given say
+// otherwise, conflicts are resolved automatically when merge strategies are declared for them in the
+// dolt_merge_strategies table of ourRoot, and the latest strategy treats theirRoot as the most recent.
+func MergeRoots(ctx context.Context, ourRoot, theirRoot, ancRoot *doltdb.RootValue, opts MergeOpts) (*doltdb.RootValue, map[string]*MergeStats, error) {
+	return mergeRoots(ctx, ourRoot, theirRoot, ancRoot, opts, true)
 }
 
-func MergeRoots(ctx context.Context, ourRoot, theirRoot, ancRoot *doltdb.RootValue) (*doltdb.RootValue, map[string]*MergeStats, error) {
+func mergeRoots(ctx context.Context, ourRoot, theirRoot, ancRoot *doltdb.RootValue, opts MergeOpts, theirsLatest bool) (*doltdb.RootValue, map[string]*MergeStats, error) {
+	var strategies *MergeStrategies
+	if !opts.NoStrategies {
+		var err error
+		strategies, err = LoadMergeStrategies(ctx, ourRoot)
+
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	merger := NewMerger(ctx, ourRoot, theirRoot, ancRoot, ourRoot.VRW())
+	merger.strategies = strategies
+	merger.theirsLatest = theirsLatest
 
 	tblNames, err := doltdb.UnionTableNames(ctx, ourRoot, theirRoot)
 
@@ -760,6 +1032,7 @@ func MergeRoots(ctx context.Context, ourRoot, theirRoot, ancRoot *doltdb.RootVal
 	newRoot := ourRoot
 	tableEditSession := editor.CreateTableEditSession(ourRoot, editor.TableEditSessionProps{
 		ForeignKeyChecksDisabled: true,
+		Checks:                   opts.Checks,
 	})
 	var unconflicted []string
 	// need to validate merges can be done on all tables before starting the actual merges.
@@ -773,7 +1046,7 @@ func MergeRoots(ctx context.Context, ourRoot, theirRoot, ancRoot *doltdb.RootVal
 		if mergedTable != nil {
 			tblToStats[tblName] = stats
 
-			if stats.Conflicts == 0 {
+			if !stats.HasConflicts() {
 				unconflicted = append(unconflicted, tblName)
 			}
 
@@ -798,9 +1071,8 @@ func MergeRoots(ctx context.Context, ourRoot, theirRoot, ancRoot *doltdb.RootVal
 			if err != nil {
 				return nil, nil, err
 			}
-		} else {
-			panic("?")
 		}
+		// otherwise the table was removed from our root and remains removed
 	}
 
 	err = tableEditSession.UpdateRoot(ctx, func(ctx context.Context, root *doltdb.RootValue) (value *doltdb.RootValue, err error) {
@@ -867,6 +1139,14 @@ func GetTablesInConflict(ctx context.Context, dEnv *env.DoltEnv) (workingInConfl
 		return nil, nil, nil, err
 	}
 
+	workingSchInConflict, err := workingRoot.TablesWithSchemaConflicts(ctx)
+
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	workingInConflict = set.Unique(append(workingInConflict, workingSchInConflict...))
+
 	return workingInConflict, stagedInConflict, headInConflict, err
 }
 
@@ -21,6 +21,9 @@ import (
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/checks"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
 )
 
 type conflictKind byte
@@ -28,18 +31,20 @@ type conflictKind byte
 const (
 	TagCollision conflictKind = iota
 	NameCollision
+	UnresolvedReference
 )
 
 type SchemaConflict struct {
 	TableName    string
 	ColConflicts []ColConflict
 	IdxConflicts []IdxConflict
+	ChkConflicts []ChkConflict
 }
 
 var EmptySchConflicts = SchemaConflict{}
 
 func (sc SchemaConflict) Count() int {
-	return len(sc.ColConflicts) + len(sc.IdxConflicts)
+	return len(sc.ColConflicts) + len(sc.IdxConflicts) + len(sc.ChkConflicts)
 }
 
 func (sc SchemaConflict) AsError() error {
@@ -51,9 +56,27 @@ func (sc SchemaConflict) AsError() error {
 	for _, c := range sc.IdxConflicts {
 		b.WriteString(fmt.Sprintf("\t%s\n", c.String()))
 	}
+	for _, c := range sc.ChkConflicts {
+		b.WriteString(fmt.Sprintf("\t%s\n", c.String()))
+	}
 	return fmt.Errorf(b.String())
 }
 
+// Description returns a description of each of the conflicts, one per line.
+func (sc SchemaConflict) Description() string {
+	var descs []string
+	for _, c := range sc.ColConflicts {
+		descs = append(descs, c.String())
+	}
+	for _, c := range sc.IdxConflicts {
+		descs = append(descs, c.String())
+	}
+	for _, c := range sc.ChkConflicts {
+		descs = append(descs, c.String())
+	}
+	return strings.Join(descs, "\n")
+}
+
 type ColConflict struct {
 	Kind         conflictKind
 	Ours, Theirs schema.Column
@@ -75,16 +98,44 @@ type IdxConflict struct {
 }
 
 func (c IdxConflict) String() string {
+	switch c.Kind {
+	case NameCollision:
+		return fmt.Sprintf("two indexes with the name '%s'", c.Ours.Name())
+	case TagCollision:
+		return fmt.Sprintf("different index definitions for our index %s and their index %s", c.Ours.Name(), c.Theirs.Name())
+	}
 	return ""
 }
 
+// ChkConflict is a conflict between the CHECK constraints of two branches. Ours or Theirs is empty if the constraint
+// was dropped on that branch.
+type ChkConflict struct {
+	Kind         conflictKind
+	Ours, Theirs schema.Check
+}
+
+func (c ChkConflict) String() string {
+	name := c.Ours.Name
+	if name == "" {
+		name = c.Theirs.Name
+	}
+	switch {
+	case c.Kind == UnresolvedReference:
+		return fmt.Sprintf("CHECK constraint '%s' references a column which is not in the merged schema", name)
+	case c.Ours.Name == "" || c.Theirs.Name == "":
+		return fmt.Sprintf("CHECK constraint '%s' was modified on one branch and dropped on the other", name)
+	}
+	return fmt.Sprintf("different definitions for our CHECK constraint '%s' and their CHECK constraint '%s'", c.Ours.Name, c.Theirs.Name)
+}
+
 type FKConflict struct {
 	Kind         conflictKind
 	Ours, Theirs doltdb.ForeignKey
 }
 
-// SchemaMerge performs a three-way merge of ourSch, theirSch, and ancSch.
-func SchemaMerge(ourSch, theirSch, ancSch schema.Schema, tblName string) (sch schema.Schema, sc SchemaConflict, err error) {
+// SchemaMerge performs a three-way merge of ourSch, theirSch, and ancSch. The merged CHECK constraints are validated
+// against the merged schema by the evaluators returned by newChecks.
+func SchemaMerge(ctx context.Context, ourSch, theirSch, ancSch schema.Schema, tblName string, newChecks checks.EvaluatorFactory) (sch schema.Schema, sc SchemaConflict, err error) {
 	// (sch - ancSch) ∪ (mergeSch - ancSch) ∪ (sch ∩ mergeSch)
 
 	sc = SchemaConflict{
@@ -115,6 +166,32 @@ func SchemaMerge(ourSch, theirSch, ancSch schema.Schema, tblName string) (sch sc
 		return false, nil
 	})
 
+	var mergedChks []schema.Check
+	mergedChks, sc.ChkConflicts = mergeChecks(ourSch, theirSch, ancSch)
+
+	if len(mergedChks) > 0 && newChecks == nil {
+		return nil, sc, editor.ErrNoCheckEvaluator
+	}
+
+	// a CHECK constraint added on one branch may reference a column dropped on the other
+	for _, chk := range mergedChks {
+		_, err = sch.Checks().AddCheck(chk.Name, chk.Expression)
+		if err != nil {
+			return nil, sc, err
+		}
+
+		if _, chkErr := newChecks(ctx, sch); chkErr != nil {
+			_, err = sch.Checks().RemoveCheck(chk.Name)
+			if err != nil {
+				return nil, sc, err
+			}
+			sc.ChkConflicts = append(sc.ChkConflicts, ChkConflict{Kind: UnresolvedReference, Ours: chk, Theirs: chk})
+		}
+	}
+	if len(sc.ChkConflicts) > 0 {
+		return nil, sc, nil
+	}
+
 	return sch, sc, nil
 }
 
@@ -252,48 +329,100 @@ func columnsInCommon(ourCC, theirCC, ancCC *schema.ColCollection) (common *schem
 			return false, nil
 		}
 
-		if ancCol.Equals(theirCol) {
-			// col modified on our branch
-			col, ok := common.GetByNameCaseInsensitive(ourCol.Name)
-			if ok {
-				conflicts = append(conflicts, ColConflict{
-					Kind:   NameCollision,
-					Ours:   ourCol,
-					Theirs: col,
-				})
-			} else {
-				common, err = common.Append(ourCol)
-			}
-			return false, err
+		mergedCol, ok := mergeColumnDefs(ourCol, theirCol, ancCol)
+		if !ok {
+			// col modified on our branch and their branch with different def
+			conflicts = append(conflicts, ColConflict{
+				Kind:   TagCollision,
+				Ours:   ourCol,
+				Theirs: theirCol,
+			})
+			return false, nil
 		}
 
-		if ancCol.Equals(ourCol) {
-			// col modified on their branch
-			col, ok := common.GetByNameCaseInsensitive(theirCol.Name)
-			if ok {
-				conflicts = append(conflicts, ColConflict{
-					Kind:   NameCollision,
-					Ours:   col,
-					Theirs: theirCol,
-				})
-			} else {
-				common, err = common.Append(theirCol)
-			}
-			return false, err
+		col, ok := common.GetByNameCaseInsensitive(mergedCol.Name)
+		if ok {
+			conflicts = append(conflicts, ColConflict{
+				Kind:   NameCollision,
+				Ours:   mergedCol,
+				Theirs: col,
+			})
+		} else {
+			common, err = common.Append(mergedCol)
 		}
-
-		// col modified on our branch and their branch with different def
-		conflicts = append(conflicts, ColConflict{
-			Kind:   TagCollision,
-			Ours:   ourCol,
-			Theirs: theirCol,
-		})
-		return false, nil
+		return false, err
 	})
 
 	return common, conflicts, err
 }
 
+// mergeColumnDefs performs a three-way merge of the definition of a column which exists in the ancestor and on both
+// branches, taking each attribute of the column from the branch which changed it. If both branches changed the type
+// of the column, the merged column takes whichever type is a widening of the other. Returns false if both branches
+// made incompatible changes to the same attribute.
+func mergeColumnDefs(ourCol, theirCol, ancCol schema.Column) (schema.Column, bool) {
+	merged := ourCol
+	ok := true
+
+	mergeAttr := func(ourChanged, theirChanged, same bool) (takeTheirs bool) {
+		switch {
+		case !theirChanged || same:
+			return false
+		case !ourChanged:
+			return true
+		default:
+			ok = false
+			return false
+		}
+	}
+
+	if mergeAttr(ourCol.Name != ancCol.Name, theirCol.Name != ancCol.Name, ourCol.Name == theirCol.Name) {
+		merged.Name = theirCol.Name
+	}
+	if mergeAttr(ourCol.Default != ancCol.Default, theirCol.Default != ancCol.Default, ourCol.Default == theirCol.Default) {
+		merged.Default = theirCol.Default
+	}
+	if mergeAttr(ourCol.Comment != ancCol.Comment, theirCol.Comment != ancCol.Comment, ourCol.Comment == theirCol.Comment) {
+		merged.Comment = theirCol.Comment
+	}
+	if mergeAttr(ourCol.AutoIncrement != ancCol.AutoIncrement, theirCol.AutoIncrement != ancCol.AutoIncrement, ourCol.AutoIncrement == theirCol.AutoIncrement) {
+		merged.AutoIncrement = theirCol.AutoIncrement
+	}
+	if mergeAttr(ourCol.IsPartOfPK != ancCol.IsPartOfPK, theirCol.IsPartOfPK != ancCol.IsPartOfPK, ourCol.IsPartOfPK == theirCol.IsPartOfPK) {
+		merged.IsPartOfPK = theirCol.IsPartOfPK
+	}
+	if mergeAttr(
+		!schema.ColConstraintsAreEqual(ourCol.Constraints, ancCol.Constraints),
+		!schema.ColConstraintsAreEqual(theirCol.Constraints, ancCol.Constraints),
+		schema.ColConstraintsAreEqual(ourCol.Constraints, theirCol.Constraints),
+	) {
+		merged.Constraints = theirCol.Constraints
+	}
+	if !ok {
+		return schema.Column{}, false
+	}
+
+	ourTypeChanged := ourCol.Kind != ancCol.Kind || !ourCol.TypeInfo.Equals(ancCol.TypeInfo)
+	theirTypeChanged := theirCol.Kind != ancCol.Kind || !theirCol.TypeInfo.Equals(ancCol.TypeInfo)
+	switch {
+	case !theirTypeChanged:
+	case !ourTypeChanged:
+		merged.Kind, merged.TypeInfo = theirCol.Kind, theirCol.TypeInfo
+	default:
+		// both branches changed the type, which merges if one type can hold every value of the other
+		if ourCol.Kind != theirCol.Kind {
+			return schema.Column{}, false
+		}
+		ti, isWider := typeinfo.WiderType(ourCol.TypeInfo, theirCol.TypeInfo)
+		if !isWider {
+			return schema.Column{}, false
+		}
+		merged.TypeInfo = ti
+	}
+
+	return merged, true
+}
+
 // assumes indexes are unique over their column sets
 func mergeIndexes(mergedCC *schema.ColCollection, ourSch, theirSch, ancSch schema.Schema) (merged schema.IndexCollection, conflicts []IdxConflict) {
 	merged, conflicts = indexesInCommon(mergedCC, ourSch.Indexes(), theirSch.Indexes(), ancSch.Indexes())
@@ -320,6 +449,47 @@ func mergeIndexes(mergedCC *schema.ColCollection, ourSch, theirSch, ancSch schem
 	return merged, conflicts
 }
 
+// mergeChecks performs a three-way merge of the CHECK constraints of the schemas, matching constraints by name.
+func mergeChecks(ourSch, theirSch, ancSch schema.Schema) (merged []schema.Check, conflicts []ChkConflict) {
+	ours, theirs, anc := ourSch.Checks(), theirSch.Checks(), ancSch.Checks()
+
+	for _, ourChk := range ours.AllChecks() {
+		theirChk, inTheirs := theirs.GetByNameCaseInsensitive(ourChk.Name)
+		ancChk, inAnc := anc.GetByNameCaseInsensitive(ourChk.Name)
+		ourChanged := !inAnc || ancChk.Expression != ourChk.Expression
+		theirChanged := !inAnc || ancChk.Expression != theirChk.Expression
+
+		switch {
+		case inTheirs && (ourChk.Expression == theirChk.Expression || !theirChanged):
+			merged = append(merged, ourChk)
+		case inTheirs && !ourChanged:
+			merged = append(merged, theirChk)
+		case inTheirs:
+			conflicts = append(conflicts, ChkConflict{Kind: NameCollision, Ours: ourChk, Theirs: theirChk})
+		case ourChanged && inAnc:
+			conflicts = append(conflicts, ChkConflict{Kind: NameCollision, Ours: ourChk})
+		case ourChanged:
+			merged = append(merged, ourChk)
+		}
+		// otherwise they dropped a constraint we didn't change
+	}
+
+	for _, theirChk := range theirs.AllChecks() {
+		if ours.Contains(theirChk.Name) {
+			continue
+		}
+		ancChk, inAnc := anc.GetByNameCaseInsensitive(theirChk.Name)
+		if !inAnc {
+			merged = append(merged, theirChk)
+		} else if ancChk.Expression != theirChk.Expression {
+			conflicts = append(conflicts, ChkConflict{Kind: NameCollision, Theirs: theirChk})
+		}
+		// otherwise we dropped a constraint they didn't change
+	}
+
+	return merged, conflicts
+}
+
 func indexesInCommon(mergedCC *schema.ColCollection, ours, theirs, anc schema.IndexCollection) (common schema.IndexCollection, conflicts []IdxConflict) {
 	common = schema.NewIndexCollection(mergedCC)
 	_ = ours.Iter(func(ourIdx schema.Index) (stop bool, err error) {
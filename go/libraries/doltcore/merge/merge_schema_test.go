@@ -0,0 +1,170 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+)
+
+func TestMergeColumnDefs(t *testing.T) {
+	col := func(name string, ti typeinfo.TypeInfo, def, comment string, constraints ...schema.ColConstraint) schema.Column {
+		c, err := schema.NewColumnWithTypeInfo(name, 1, ti, false, def, false, comment, constraints...)
+		require.NoError(t, err)
+		return c
+	}
+
+	anc := col("c", typeinfo.Int16Type, "", "")
+
+	tests := []struct {
+		name     string
+		ours     schema.Column
+		theirs   schema.Column
+		expected schema.Column
+		ok       bool
+	}{
+		{
+			name:     "rename on ours, widen on theirs",
+			ours:     col("c2", typeinfo.Int16Type, "", ""),
+			theirs:   col("c", typeinfo.Int64Type, "", ""),
+			expected: col("c2", typeinfo.Int64Type, "", ""),
+			ok:       true,
+		},
+		{
+			name:     "widen on both takes the wider type",
+			ours:     col("c", typeinfo.Int32Type, "", ""),
+			theirs:   col("c", typeinfo.Int24Type, "", ""),
+			expected: col("c", typeinfo.Int32Type, "", ""),
+			ok:       true,
+		},
+		{
+			name:     "default on ours, comment and constraint on theirs",
+			ours:     col("c", typeinfo.Int16Type, "7", ""),
+			theirs:   col("c", typeinfo.Int16Type, "", "a comment", schema.NotNullConstraint{}),
+			expected: col("c", typeinfo.Int16Type, "7", "a comment", schema.NotNullConstraint{}),
+			ok:       true,
+		},
+		{
+			name:   "rename on both",
+			ours:   col("c2", typeinfo.Int16Type, "", ""),
+			theirs: col("c3", typeinfo.Int16Type, "", ""),
+			ok:     false,
+		},
+		{
+			name:   "incompatible types",
+			ours:   col("c", typeinfo.Int64Type, "", ""),
+			theirs: col("c", typeinfo.StringDefaultType, "", ""),
+			ok:     false,
+		},
+		{
+			name:   "different defaults",
+			ours:   col("c", typeinfo.Int16Type, "1", ""),
+			theirs: col("c", typeinfo.Int16Type, "2", ""),
+			ok:     false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			merged, ok := mergeColumnDefs(test.ours, test.theirs, anc)
+			require.Equal(t, test.ok, ok)
+			if ok {
+				assert.Equal(t, test.expected, merged)
+			}
+		})
+	}
+}
+
+func TestMergeChecks(t *testing.T) {
+	sch := func(checks ...string) schema.Schema {
+		cols, err := schema.NewColCollection()
+		require.NoError(t, err)
+		s, err := schema.SchemaFromCols(cols)
+		require.NoError(t, err)
+		for i := 0; i < len(checks); i += 2 {
+			_, err := s.Checks().AddCheck(checks[i], checks[i+1])
+			require.NoError(t, err)
+		}
+		return s
+	}
+	chk := func(name, expression string) schema.Check {
+		return schema.Check{Name: name, Expression: expression}
+	}
+
+	tests := []struct {
+		name      string
+		ours      schema.Schema
+		theirs    schema.Schema
+		anc       schema.Schema
+		merged    []schema.Check
+		conflicts []ChkConflict
+	}{
+		{
+			name:   "added on both sides",
+			ours:   sch("a", "x > 0"),
+			theirs: sch("b", "y > 0"),
+			anc:    sch(),
+			merged: []schema.Check{chk("a", "x > 0"), chk("b", "y > 0")},
+		},
+		{
+			name:   "same check added on both sides",
+			ours:   sch("a", "x > 0"),
+			theirs: sch("a", "x > 0"),
+			anc:    sch(),
+			merged: []schema.Check{chk("a", "x > 0")},
+		},
+		{
+			name:   "modified on theirs",
+			ours:   sch("a", "x > 0"),
+			theirs: sch("a", "x > 1"),
+			anc:    sch("a", "x > 0"),
+			merged: []schema.Check{chk("a", "x > 1")},
+		},
+		{
+			name:   "dropped on theirs",
+			ours:   sch("a", "x > 0", "b", "y > 0"),
+			theirs: sch("b", "y > 0"),
+			anc:    sch("a", "x > 0", "b", "y > 0"),
+			merged: []schema.Check{chk("b", "y > 0")},
+		},
+		{
+			name:      "modified differently on both sides",
+			ours:      sch("a", "x > 1"),
+			theirs:    sch("a", "x > 2"),
+			anc:       sch("a", "x > 0"),
+			conflicts: []ChkConflict{{Kind: NameCollision, Ours: chk("a", "x > 1"), Theirs: chk("a", "x > 2")}},
+		},
+		{
+			name:      "modified on ours, dropped on theirs",
+			ours:      sch("a", "x > 1"),
+			theirs:    sch(),
+			anc:       sch("a", "x > 0"),
+			conflicts: []ChkConflict{{Kind: NameCollision, Ours: chk("a", "x > 1")}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			merged, conflicts := mergeChecks(test.ours, test.theirs, test.anc)
+			assert.Equal(t, test.merged, merged)
+			assert.Equal(t, test.conflicts, conflicts)
+		})
+	}
+}
@@ -29,4 +29,14 @@ type MergeStats struct {
 	Deletes       int
 	Modifications int
 	Conflicts     int
+	// Resolved is the number of conflicting rows resolved by merge strategies
+	Resolved int
+	// SchemaConflicts is the number of conflicts between the schemas of each branch. A table with schema conflicts
+	// keeps our schema and rows, and the row changes from their branch are not merged.
+	SchemaConflicts int
+}
+
+// HasConflicts returns whether the merge left rows or the schema of the table in conflict.
+func (ms *MergeStats) HasConflicts() bool {
+	return ms.Conflicts > 0 || ms.SchemaConflicts > 0
 }
@@ -0,0 +1,418 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	sqle "github.com/dolthub/go-mysql-server"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/shopspring/decimal"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// Strategy is the name of a strategy used to resolve merge conflicts automatically
+type Strategy string
+
+const (
+	// OursStrategy keeps our value
+	OursStrategy Strategy = "ours"
+	// TheirsStrategy takes their value
+	TheirsStrategy Strategy = "theirs"
+	// LatestStrategy takes the value from the side of the merge with the most recent commit
+	LatestStrategy Strategy = "latest"
+	// MaxStrategy takes the greater of our and their values
+	MaxStrategy Strategy = "max"
+	// MinStrategy takes the lesser of our and their values
+	MinStrategy Strategy = "min"
+	// SumDeltaStrategy applies the changes made to a numeric value on both sides, e.g. counters incremented on both
+	// sides of a merge are incremented by the sum of both increments.
+	SumDeltaStrategy Strategy = "sum"
+	// SqlStrategy evaluates a SQL expression which can refer to the conflicting values as :base, :our and :their
+	SqlStrategy Strategy = "sql"
+)
+
+// AnyName can be used as the table or column name of a merge strategy to apply it to all tables or all columns
+const AnyName = "*"
+
+var strategies = map[Strategy]bool{
+	OursStrategy:     true,
+	TheirsStrategy:   true,
+	LatestStrategy:   true,
+	MaxStrategy:      true,
+	MinStrategy:      true,
+	SumDeltaStrategy: true,
+	SqlStrategy:      true,
+}
+
+var mergeStrategiesCols, _ = schema.NewColCollection(
+	schema.NewColumn(doltdb.MergeStrategiesTableCol, schema.MergeStrategiesTableTag, types.StringKind, true, schema.NotNullConstraint{}),
+	schema.NewColumn(doltdb.MergeStrategiesColumnCol, schema.MergeStrategiesColumnTag, types.StringKind, true, schema.NotNullConstraint{}),
+	schema.NewColumn(doltdb.MergeStrategiesStrategyCol, schema.MergeStrategiesStrategyTag, types.StringKind, false, schema.NotNullConstraint{}),
+	schema.NewColumn(doltdb.MergeStrategiesExpressionCol, schema.MergeStrategiesExpressionTag, types.StringKind, false),
+)
+
+// MergeStrategiesSchema is the schema of the dolt_merge_strategies system table
+var MergeStrategiesSchema = schema.MustSchemaFromCols(mergeStrategiesCols)
+
+// MergeStrategy declares how conflicts in a table, or in a single column of a table, are resolved during a merge.
+type MergeStrategy struct {
+	Table      string
+	Column     string
+	Strategy   Strategy
+	Expression string
+}
+
+// MergeStrategies is the set of merge strategies declared in a root value.
+type MergeStrategies struct {
+	// strategies are keyed by the lower case table name and then by the lower case column name
+	strategies map[string]map[string]MergeStrategy
+}
+
+// LoadMergeStrategies reads the merge strategies declared in the dolt_merge_strategies table of a root value. If the
+// root does not have the table, nil is returned.
+func LoadMergeStrategies(ctx context.Context, root *doltdb.RootValue) (*MergeStrategies, error) {
+	tbl, ok, err := root.GetTable(ctx, doltdb.MergeStrategiesTableName)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, nil
+	}
+
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ms := &MergeStrategies{strategies: make(map[string]map[string]MergeStrategy)}
+	err = data.IterAll(ctx, func(key, value types.Value) error {
+		r, err := row.FromNoms(sch, key.(types.Tuple), value.(types.Tuple))
+		if err != nil {
+			return err
+		}
+
+		st := MergeStrategy{
+			Table:      getStringColVal(r, schema.MergeStrategiesTableTag),
+			Column:     getStringColVal(r, schema.MergeStrategiesColumnTag),
+			Strategy:   Strategy(strings.ToLower(getStringColVal(r, schema.MergeStrategiesStrategyTag))),
+			Expression: getStringColVal(r, schema.MergeStrategiesExpressionTag),
+		}
+
+		if !strategies[st.Strategy] {
+			return fmt.Errorf("unknown merge strategy '%s' for %s.%s", st.Strategy, st.Table, st.Column)
+		} else if st.Strategy == SqlStrategy && len(st.Expression) == 0 {
+			return fmt.Errorf("merge strategy '%s' for %s.%s has no expression", st.Strategy, st.Table, st.Column)
+		}
+
+		tblName := strings.ToLower(st.Table)
+		if _, ok := ms.strategies[tblName]; !ok {
+			ms.strategies[tblName] = make(map[string]MergeStrategy)
+		}
+
+		ms.strategies[tblName][strings.ToLower(st.Column)] = st
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ms, nil
+}
+
+func getStringColVal(r row.Row, tag uint64) string {
+	val, ok := r.GetColVal(tag)
+	if !ok || types.IsNull(val) {
+		return ""
+	}
+
+	return string(val.(types.String))
+}
+
+// ForColumn returns the strategy used to resolve conflicts in a column of a table. Strategies declared for the column
+// take precedence over strategies declared for the whole table, which take precedence over strategies declared for
+// all tables.
+func (ms *MergeStrategies) ForColumn(tblName, colName string) (MergeStrategy, bool) {
+	if ms == nil {
+		return MergeStrategy{}, false
+	}
+
+	tblName, colName = strings.ToLower(tblName), strings.ToLower(colName)
+	for _, tn := range []string{tblName, AnyName} {
+		for _, cn := range []string{colName, AnyName} {
+			if st, ok := ms.strategies[tn][cn]; ok {
+				return st, true
+			}
+		}
+	}
+
+	return MergeStrategy{}, false
+}
+
+// ForTable returns the strategy used to resolve conflicts for whole rows of a table, such as a row deleted on one side
+// of a merge and modified on the other.
+func (ms *MergeStrategies) ForTable(tblName string) (MergeStrategy, bool) {
+	return ms.ForColumn(tblName, AnyName)
+}
+
+// strategyResolver resolves the conflicts in the rows of a single table using the declared merge strategies
+type strategyResolver struct {
+	tblName      string
+	sch          schema.Schema
+	strategies   *MergeStrategies
+	theirsLatest bool
+	vrw          types.ValueReadWriter
+
+	// engine is used to evaluate the expressions of SQL strategies, and is created on first use
+	engine *sqle.Engine
+}
+
+// resolveRow attempts to resolve a conflicting row using the merge strategies. If every conflicting cell of the row
+// is resolved, the resolved row is returned along with true. A nil resolved row means the row is deleted. If only
+// some of the conflicting cells are resolved, the partially resolved row, which keeps our value for each unresolved
+// cell, is returned along with false.
+func (res *strategyResolver) resolveRow(ctx context.Context, r, mergeRow, baseRow types.Value) (types.Value, bool, error) {
+	if r == nil || mergeRow == nil {
+		// removed from one and modified in another
+		st, ok := res.strategies.ForTable(res.tblName)
+		if !ok {
+			return nil, false, nil
+		}
+
+		switch st.Strategy {
+		case OursStrategy:
+			return r, true, nil
+		case TheirsStrategy:
+			return mergeRow, true, nil
+		case LatestStrategy:
+			if res.theirsLatest {
+				return mergeRow, true, nil
+			}
+			return r, true, nil
+		default:
+			return nil, false, nil
+		}
+	}
+
+	var baseVals row.TaggedValues
+	if baseRow != nil {
+		var err error
+		baseVals, err = row.ParseTaggedValues(baseRow.(types.Tuple))
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	rowVals, err := row.ParseTaggedValues(r.(types.Tuple))
+	if err != nil {
+		return nil, false, err
+	}
+
+	mergeVals, err := row.ParseTaggedValues(mergeRow.(types.Tuple))
+	if err != nil {
+		return nil, false, err
+	}
+
+	resultVals := make(row.TaggedValues)
+
+	allResolved := true
+	err = res.sch.GetNonPKCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		baseVal, _ := baseVals.Get(tag)
+		val, _ := rowVals.Get(tag)
+		mergeVal, _ := mergeVals.Get(tag)
+
+		resultVal, isConflict, err := mergeColumnCell(ctx, res.vrw, col, val, mergeVal, baseVal)
+		if err != nil {
+			return true, err
+		}
+		if isConflict {
+			resolved := false
+			if st, ok := res.strategies.ForColumn(res.tblName, col.Name); ok {
+				resultVal, resolved, err = res.resolveCell(ctx, st, col, baseVal, val, mergeVal)
+				if err != nil {
+					return true, err
+				}
+			}
+
+			if !resolved {
+				allResolved = false
+				resultVal = val
+			}
+		}
+
+		resultVals[tag] = resultVal
+		return false, nil
+	})
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	v, err := resultVals.NomsTupleForNonPKCols(res.vrw.Format(), res.sch.GetNonPKCols()).Value(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return v, allResolved, nil
+}
+
+// resolveCell resolves a single conflicting cell using the strategy given. It returns false if the strategy cannot
+// resolve the conflict, e.g. a numeric strategy applied to a NULL value.
+func (res *strategyResolver) resolveCell(ctx context.Context, st MergeStrategy, col schema.Column, baseVal, val, mergeVal types.Value) (types.Value, bool, error) {
+	switch st.Strategy {
+	case OursStrategy:
+		return val, true, nil
+	case TheirsStrategy:
+		return mergeVal, true, nil
+	case LatestStrategy:
+		if res.theirsLatest {
+			return mergeVal, true, nil
+		}
+		return val, true, nil
+	case MaxStrategy, MinStrategy:
+		if types.IsNull(val) || types.IsNull(mergeVal) {
+			return nil, false, nil
+		}
+
+		less, err := val.Less(res.vrw.Format(), mergeVal)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if less == (st.Strategy == MaxStrategy) {
+			return mergeVal, true, nil
+		}
+		return val, true, nil
+	case SumDeltaStrategy:
+		sum, ok := sumDelta(baseVal, val, mergeVal)
+		return sum, ok, nil
+	case SqlStrategy:
+		return res.evalExpression(ctx, st, col, baseVal, val, mergeVal)
+	default:
+		return nil, false, fmt.Errorf("unknown merge strategy '%s'", st.Strategy)
+	}
+}
+
+// sumDelta returns the base value plus the changes made to it on both sides of the merge.
+func sumDelta(baseVal, val, mergeVal types.Value) (types.Value, bool) {
+	if types.IsNull(val) || types.IsNull(mergeVal) || val.Kind() != mergeVal.Kind() {
+		return nil, false
+	}
+
+	hasBase := !types.IsNull(baseVal)
+	if hasBase && baseVal.Kind() != val.Kind() {
+		return nil, false
+	}
+
+	switch v := val.(type) {
+	case types.Int:
+		var b types.Int
+		if hasBase {
+			b = baseVal.(types.Int)
+		}
+		return v + mergeVal.(types.Int) - b, true
+	case types.Uint:
+		var b types.Uint
+		if hasBase {
+			b = baseVal.(types.Uint)
+		}
+		sum := v + mergeVal.(types.Uint)
+		if sum < b {
+			return nil, false
+		}
+		return sum - b, true
+	case types.Float:
+		var b types.Float
+		if hasBase {
+			b = baseVal.(types.Float)
+		}
+		return v + mergeVal.(types.Float) - b, true
+	case types.Decimal:
+		b := decimal.Zero
+		if hasBase {
+			b = decimal.Decimal(baseVal.(types.Decimal))
+		}
+		sum := decimal.Decimal(v).Add(decimal.Decimal(mergeVal.(types.Decimal))).Sub(b)
+		return types.Decimal(sum), true
+	default:
+		return nil, false
+	}
+}
+
+func (res *strategyResolver) evalExpression(ctx context.Context, st MergeStrategy, col schema.Column, baseVal, val, mergeVal types.Value) (types.Value, bool, error) {
+	sqlType := col.TypeInfo.ToSqlType()
+	bindings := make(map[string]sql.Expression)
+	for name, v := range map[string]types.Value{baseStr: baseVal, oursStr: val, theirsStr: mergeVal} {
+		if types.IsNull(v) {
+			bindings[name] = expression.NewLiteral(nil, sql.Null)
+			continue
+		}
+
+		goVal, err := col.TypeInfo.ConvertNomsValueToValue(v)
+		if err != nil {
+			return nil, false, err
+		}
+
+		bindings[name] = expression.NewLiteral(goVal, sqlType)
+	}
+
+	if res.engine == nil {
+		res.engine = sqle.NewDefault()
+	}
+
+	_, itr, err := res.engine.QueryWithBindings(sql.NewContext(ctx), "SELECT "+st.Expression, bindings)
+	if err != nil {
+		return nil, false, fmt.Errorf("error evaluating merge strategy for %s.%s: %w", res.tblName, col.Name, err)
+	}
+
+	defer itr.Close()
+
+	r, err := itr.Next()
+	if err != nil {
+		return nil, false, fmt.Errorf("error evaluating merge strategy for %s.%s: %w", res.tblName, col.Name, err)
+	}
+
+	if r[0] == nil {
+		return nil, true, nil
+	}
+
+	converted, err := sqlType.Convert(r[0])
+	if err != nil {
+		return nil, false, fmt.Errorf("error evaluating merge strategy for %s.%s: %w", res.tblName, col.Name, err)
+	}
+
+	v, err := col.TypeInfo.ConvertValueToNomsValue(ctx, res.vrw, converted)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if types.IsNull(v) {
+		return nil, true, nil
+	}
+
+	return v, true, nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestMergeStrategiesForColumn(t *testing.T) {
+	ms := &MergeStrategies{strategies: map[string]map[string]MergeStrategy{
+		"t":     {"c1": {Strategy: TheirsStrategy}, AnyName: {Strategy: OursStrategy}},
+		AnyName: {"c2": {Strategy: MaxStrategy}, AnyName: {Strategy: LatestStrategy}},
+	}}
+
+	tests := []struct {
+		tbl      string
+		col      string
+		expected Strategy
+	}{
+		{"t", "c1", TheirsStrategy},
+		{"T", "C1", TheirsStrategy},
+		{"t", "c2", OursStrategy},
+		{"t2", "c2", MaxStrategy},
+		{"t2", "c3", LatestStrategy},
+	}
+
+	for _, test := range tests {
+		st, ok := ms.ForColumn(test.tbl, test.col)
+		assert.True(t, ok)
+		assert.Equal(t, test.expected, st.Strategy, "%s.%s", test.tbl, test.col)
+	}
+
+	var noStrategies *MergeStrategies
+	_, ok := noStrategies.ForTable("t")
+	assert.False(t, ok)
+}
+
+func TestResolveCell(t *testing.T) {
+	col := schema.NewColumn("c1", 1, types.IntKind, false)
+	tests := []struct {
+		name         string
+		st           MergeStrategy
+		theirsLatest bool
+		base         types.Value
+		val          types.Value
+		mergeVal     types.Value
+		expected     types.Value
+		resolved     bool
+	}{
+		{"ours", MergeStrategy{Strategy: OursStrategy}, false, types.Int(1), types.Int(2), types.Int(3), types.Int(2), true},
+		{"theirs", MergeStrategy{Strategy: TheirsStrategy}, false, types.Int(1), types.Int(2), types.Int(3), types.Int(3), true},
+		{"latest ours", MergeStrategy{Strategy: LatestStrategy}, false, types.Int(1), types.Int(2), types.Int(3), types.Int(2), true},
+		{"latest theirs", MergeStrategy{Strategy: LatestStrategy}, true, types.Int(1), types.Int(2), types.Int(3), types.Int(3), true},
+		{"max", MergeStrategy{Strategy: MaxStrategy}, false, types.Int(1), types.Int(5), types.Int(3), types.Int(5), true},
+		{"min", MergeStrategy{Strategy: MinStrategy}, false, types.Int(1), types.Int(5), types.Int(3), types.Int(3), true},
+		{"max with null", MergeStrategy{Strategy: MaxStrategy}, false, types.Int(1), nil, types.Int(3), nil, false},
+		{"sum delta", MergeStrategy{Strategy: SumDeltaStrategy}, false, types.Int(10), types.Int(12), types.Int(15), types.Int(17), true},
+		{"sum delta without base", MergeStrategy{Strategy: SumDeltaStrategy}, false, nil, types.Int(2), types.Int(5), types.Int(7), true},
+		{"sum delta with null", MergeStrategy{Strategy: SumDeltaStrategy}, false, types.Int(10), types.Int(12), nil, nil, false},
+		{"sql", MergeStrategy{Strategy: SqlStrategy, Expression: ":our * 10 + :their - :base"}, false, types.Int(1), types.Int(2), types.Int(3), types.Int(22), true},
+		{"sql null result", MergeStrategy{Strategy: SqlStrategy, Expression: "NULL"}, false, types.Int(1), types.Int(2), types.Int(3), nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := &strategyResolver{tblName: "t", theirsLatest: test.theirsLatest, vrw: types.NewValueStore((&chunks.MemoryStorage{}).NewView())}
+			actual, resolved, err := res.resolveCell(context.Background(), test.st, col, test.base, test.val, test.mergeVal)
+			require.NoError(t, err)
+			assert.Equal(t, test.resolved, resolved)
+			if test.resolved {
+				assert.Equal(t, test.expected, actual)
+			}
+		})
+	}
+}
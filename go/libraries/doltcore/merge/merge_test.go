@@ -28,6 +28,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema/encoding"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+	"github.com/dolthub/dolt/go/store/chunks"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
@@ -136,7 +137,7 @@ func TestRowMerge(t *testing.T) {
 			[]types.Value{types.String("one"), types.String("two")},
 			[]types.Value{types.String("one"), types.String("three")},
 			nil,
-			nil,
+			[]types.Value{types.String("one"), types.String("two")},
 			true,
 		),
 		createRowMergeStruct(
@@ -176,7 +177,7 @@ func TestRowMerge(t *testing.T) {
 			[]types.Value{types.String("two"), types.Uint(2), types.UUID(uuid.MustParse("99999999-9999-9999-9999-999999999999"))},
 			[]types.Value{types.String("one"), types.Uint(3), types.UUID(uuid.MustParse("ffffffff-ffff-ffff-ffff-ffffffffffff"))},
 			[]types.Value{types.String("one"), types.Uint(2), types.UUID(uuid.MustParse("00000000-0000-0000-0000-000000000000"))},
-			nil,
+			[]types.Value{types.String("two"), types.Uint(3), types.UUID(uuid.MustParse("99999999-9999-9999-9999-999999999999"))},
 			true,
 		),
 		createRowMergeStruct(
@@ -187,12 +188,20 @@ func TestRowMerge(t *testing.T) {
 			[]types.Value{types.String("two"), types.Uint(3), types.UUID(uuid.MustParse("ffffffff-ffff-ffff-ffff-ffffffffffff"))},
 			false,
 		),
+		createRowMergeStruct(
+			"modify rows where one adds a column and both modify another",
+			[]types.Value{types.String("two"), types.Uint(2)},
+			[]types.Value{types.String("three"), types.Uint(3), types.UUID(uuid.MustParse("ffffffff-ffff-ffff-ffff-ffffffffffff"))},
+			[]types.Value{types.String("one"), types.Uint(2)},
+			[]types.Value{types.String("two"), types.Uint(3), types.UUID(uuid.MustParse("ffffffff-ffff-ffff-ffff-ffffffffffff"))},
+			true,
+		),
 		createRowMergeStruct(
 			"modify row where values added in different columns",
 			[]types.Value{types.String("one"), types.Uint(2), types.String(""), types.UUID(uuid.MustParse("ffffffff-ffff-ffff-ffff-ffffffffffff"))},
 			[]types.Value{types.String("one"), types.Uint(2), types.UUID(uuid.MustParse("ffffffff-ffff-ffff-ffff-ffffffffffff")), types.String("")},
 			[]types.Value{types.String("one"), types.Uint(2), types.NullValue, types.NullValue},
-			nil,
+			[]types.Value{types.String("one"), types.Uint(2), types.String(""), types.UUID(uuid.MustParse("ffffffff-ffff-ffff-ffff-ffffffffffff"))},
 			true,
 		),
 		createRowMergeStruct(
@@ -200,14 +209,15 @@ func TestRowMerge(t *testing.T) {
 			[]types.Value{mustTuple(types.NewTuple(types.Format_7_18, types.String("one"), types.Uint(2), types.String("a")))},
 			[]types.Value{mustTuple(types.NewTuple(types.Format_7_18, types.String("one"), types.Uint(2), types.String("b")))},
 			[]types.Value{mustTuple(types.NewTuple(types.Format_7_18, types.String("one"), types.Uint(2), types.NullValue))},
-			nil,
+			[]types.Value{mustTuple(types.NewTuple(types.Format_7_18, types.String("one"), types.Uint(2), types.String("a")))},
 			true,
 		),
 	}
 
+	vrw := types.NewValueStore((&chunks.MemoryStorage{}).NewView())
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			actualResult, isConflict, err := pkRowMerge(context.Background(), types.Format_7_18, test.sch, test.row, test.mergeRow, test.ancRow)
+			actualResult, isConflict, err := pkRowMerge(context.Background(), vrw, test.sch, test.row, test.mergeRow, test.ancRow)
 			assert.NoError(t, err)
 			assert.Equal(t, test.expectedResult, actualResult, "expected "+mustString(types.EncodedValue(context.Background(), test.expectedResult))+"got "+mustString(types.EncodedValue(context.Background(), actualResult)))
 			assert.Equal(t, test.expectConflict, isConflict)
@@ -246,6 +256,7 @@ var uuids = []types.UUID{
 	types.UUID(uuid.MustParse("00000000-0000-0000-0000-00000000000a")),
 	types.UUID(uuid.MustParse("00000000-0000-0000-0000-00000000000b")),
 	types.UUID(uuid.MustParse("00000000-0000-0000-0000-00000000000c")),
+	types.UUID(uuid.MustParse("00000000-0000-0000-0000-00000000000d")),
 }
 
 var keyTuples = make([]types.Tuple, len(uuids))
@@ -283,33 +294,36 @@ func setupMergeTest(t *testing.T) (types.ValueReadWriter, *doltdb.Commit, *doltd
 		keyTuples[6], valsToTestTupleWithoutPks([]types.Value{types.String("person 7"), types.String("madam")}),
 		keyTuples[7], valsToTestTupleWithoutPks([]types.Value{types.String("person 8"), types.String("miss")}),
 		keyTuples[8], valsToTestTupleWithoutPks([]types.Value{types.String("person 9"), types.NullValue}),
+		keyTuples[13], valsToTestTupleWithoutPks([]types.Value{types.String("person 14"), types.String("sir")}),
 	)
 	require.NoError(t, err)
 
-	updateRowEditor := initialRows.Edit()                                                                                          // leave 0 as is
-	updateRowEditor.Remove(keyTuples[1])                                                                                           // remove 1 from both
-	updateRowEditor.Remove(keyTuples[2])                                                                                           // remove 2 from update
-	updateRowEditor.Set(keyTuples[4], valsToTestTupleWithoutPks([]types.Value{types.String("person five"), types.NullValue}))      // modify 4 only in update
-	updateRowEditor.Set(keyTuples[6], valsToTestTupleWithoutPks([]types.Value{types.String("person 7"), types.String("dr")}))      // modify 6 in both without overlap
-	updateRowEditor.Set(keyTuples[7], valsToTestTupleWithoutPks([]types.Value{types.String("person eight"), types.NullValue}))     // modify 7 in both with equal overlap
-	updateRowEditor.Set(keyTuples[8], valsToTestTupleWithoutPks([]types.Value{types.String("person nine"), types.NullValue}))      // modify 8 in both with conflicting overlap
-	updateRowEditor.Set(keyTuples[9], valsToTestTupleWithoutPks([]types.Value{types.String("person ten"), types.NullValue}))       // add 9 in update
-	updateRowEditor.Set(keyTuples[11], valsToTestTupleWithoutPks([]types.Value{types.String("person twelve"), types.NullValue}))   // add 11 in both without difference
-	updateRowEditor.Set(keyTuples[12], valsToTestTupleWithoutPks([]types.Value{types.String("person thirteen"), types.NullValue})) // add 12 in both with differences
+	updateRowEditor := initialRows.Edit()                                                                                              // leave 0 as is
+	updateRowEditor.Remove(keyTuples[1])                                                                                               // remove 1 from both
+	updateRowEditor.Remove(keyTuples[2])                                                                                               // remove 2 from update
+	updateRowEditor.Set(keyTuples[4], valsToTestTupleWithoutPks([]types.Value{types.String("person five"), types.NullValue}))          // modify 4 only in update
+	updateRowEditor.Set(keyTuples[6], valsToTestTupleWithoutPks([]types.Value{types.String("person 7"), types.String("dr")}))          // modify 6 in both without overlap
+	updateRowEditor.Set(keyTuples[7], valsToTestTupleWithoutPks([]types.Value{types.String("person eight"), types.NullValue}))         // modify 7 in both with equal overlap
+	updateRowEditor.Set(keyTuples[8], valsToTestTupleWithoutPks([]types.Value{types.String("person nine"), types.NullValue}))          // modify 8 in both with conflicting overlap
+	updateRowEditor.Set(keyTuples[9], valsToTestTupleWithoutPks([]types.Value{types.String("person ten"), types.NullValue}))           // add 9 in update
+	updateRowEditor.Set(keyTuples[11], valsToTestTupleWithoutPks([]types.Value{types.String("person twelve"), types.NullValue}))       // add 11 in both without difference
+	updateRowEditor.Set(keyTuples[12], valsToTestTupleWithoutPks([]types.Value{types.String("person thirteen"), types.NullValue}))     // add 12 in both with differences
+	updateRowEditor.Set(keyTuples[13], valsToTestTupleWithoutPks([]types.Value{types.String("person fourteen"), types.String("sir")})) // modify 13 in both with conflicting overlap in one cell
 
 	updatedRows, err := updateRowEditor.Map(context.Background())
 	require.NoError(t, err)
 
-	mergeRowEditor := initialRows.Edit()                                                                                                 // leave 0 as is
-	mergeRowEditor.Remove(keyTuples[1])                                                                                                  // remove 1 from both
-	mergeRowEditor.Remove(keyTuples[3])                                                                                                  // remove 3 from merge
-	mergeRowEditor.Set(keyTuples[5], valsToTestTupleWithoutPks([]types.Value{types.String("person six"), types.NullValue}))              // modify 5 only in merge
-	mergeRowEditor.Set(keyTuples[6], valsToTestTupleWithoutPks([]types.Value{types.String("person seven"), types.String("madam")}))      // modify 6 in both without overlap
-	mergeRowEditor.Set(keyTuples[7], valsToTestTupleWithoutPks([]types.Value{types.String("person eight"), types.NullValue}))            // modify 7 in both with equal overlap
-	mergeRowEditor.Set(keyTuples[8], valsToTestTupleWithoutPks([]types.Value{types.String("person number nine"), types.NullValue}))      // modify 8 in both with conflicting overlap
-	mergeRowEditor.Set(keyTuples[10], valsToTestTupleWithoutPks([]types.Value{types.String("person eleven"), types.NullValue}))          // add 10 in merge
-	mergeRowEditor.Set(keyTuples[11], valsToTestTupleWithoutPks([]types.Value{types.String("person twelve"), types.NullValue}))          // add 11 in both without difference
-	mergeRowEditor.Set(keyTuples[12], valsToTestTupleWithoutPks([]types.Value{types.String("person number thirteen"), types.NullValue})) // add 12 in both with differences
+	mergeRowEditor := initialRows.Edit()                                                                                                      // leave 0 as is
+	mergeRowEditor.Remove(keyTuples[1])                                                                                                       // remove 1 from both
+	mergeRowEditor.Remove(keyTuples[3])                                                                                                       // remove 3 from merge
+	mergeRowEditor.Set(keyTuples[5], valsToTestTupleWithoutPks([]types.Value{types.String("person six"), types.NullValue}))                   // modify 5 only in merge
+	mergeRowEditor.Set(keyTuples[6], valsToTestTupleWithoutPks([]types.Value{types.String("person seven"), types.String("madam")}))           // modify 6 in both without overlap
+	mergeRowEditor.Set(keyTuples[7], valsToTestTupleWithoutPks([]types.Value{types.String("person eight"), types.NullValue}))                 // modify 7 in both with equal overlap
+	mergeRowEditor.Set(keyTuples[8], valsToTestTupleWithoutPks([]types.Value{types.String("person number nine"), types.NullValue}))           // modify 8 in both with conflicting overlap
+	mergeRowEditor.Set(keyTuples[10], valsToTestTupleWithoutPks([]types.Value{types.String("person eleven"), types.NullValue}))               // add 10 in merge
+	mergeRowEditor.Set(keyTuples[11], valsToTestTupleWithoutPks([]types.Value{types.String("person twelve"), types.NullValue}))               // add 11 in both without difference
+	mergeRowEditor.Set(keyTuples[12], valsToTestTupleWithoutPks([]types.Value{types.String("person number thirteen"), types.NullValue}))      // add 12 in both with differences
+	mergeRowEditor.Set(keyTuples[13], valsToTestTupleWithoutPks([]types.Value{types.String("person number fourteen"), types.String("lord")})) // modify 13 in both with conflicting overlap in one cell
 
 	mergeRows, err := mergeRowEditor.Map(context.Background())
 	require.NoError(t, err)
@@ -325,6 +339,7 @@ func setupMergeTest(t *testing.T) (types.ValueReadWriter, *doltdb.Commit, *doltd
 		keyTuples[10], mustGetValue(mergeRows.MaybeGet(context.Background(), keyTuples[10])), // added in merge
 		keyTuples[11], mustGetValue(updatedRows.MaybeGet(context.Background(), keyTuples[11])), // added same in both
 		keyTuples[12], mustGetValue(updatedRows.MaybeGet(context.Background(), keyTuples[12])), // conflict
+		keyTuples[13], valsToTestTupleWithoutPks([]types.Value{types.String("person fourteen"), types.String("lord")}), // conflict with the other cell merged
 	)
 
 	updateConflict := doltdb.NewConflict(
@@ -338,9 +353,16 @@ func setupMergeTest(t *testing.T) (types.ValueReadWriter, *doltdb.Commit, *doltd
 		valsToTestTupleWithoutPks([]types.Value{types.String("person number thirteen"), types.NullValue}),
 	)
 
+	// our value of the conflict is the row written to the table, with the cells which are not in conflict merged
+	cellConflict := doltdb.NewConflict(
+		mustGetValue(initialRows.MaybeGet(context.Background(), keyTuples[13])),
+		mustGetValue(expectedRows.MaybeGet(context.Background(), keyTuples[13])),
+		mustGetValue(mergeRows.MaybeGet(context.Background(), keyTuples[13])))
+
 	expectedConflicts, err := types.NewMap(context.Background(), vrw,
 		keyTuples[8], mustTuple(updateConflict.ToNomsList(vrw)),
 		keyTuples[12], mustTuple(addConflict.ToNomsList(vrw)),
+		keyTuples[13], mustTuple(cellConflict.ToNomsList(vrw)),
 	)
 	require.NoError(t, err)
 
@@ -426,7 +448,7 @@ func TestMergeCommits(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	if stats.Adds != 2 || stats.Deletes != 2 || stats.Modifications != 3 || stats.Conflicts != 2 {
+	if stats.Adds != 2 || stats.Deletes != 2 || stats.Modifications != 4 || stats.Conflicts != 3 {
 		t.Error("Actual stats differ from expected")
 	}
 
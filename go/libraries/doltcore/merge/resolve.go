@@ -23,6 +23,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+	"github.com/dolthub/dolt/go/libraries/utils/valutil"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
@@ -36,6 +37,43 @@ func Theirs(key types.Value, cnf doltdb.Conflict) (types.Value, error) {
 	return cnf.MergeValue, nil
 }
 
+// SchemaConflictResolver resolves the schema conflict of a table, returning the table which replaces it.
+type SchemaConflictResolver func(ctx context.Context, tbl *doltdb.Table) (*doltdb.Table, error)
+
+// OursSchema resolves a schema conflict by keeping our schema and rows.
+func OursSchema(ctx context.Context, tbl *doltdb.Table) (*doltdb.Table, error) {
+	return tbl.ClearSchemaConflict()
+}
+
+// TheirsSchema resolves a schema conflict by replacing the table with their version of it, including their rows and
+// indexes. Our changes to the table's rows since the merge base are not kept.
+func TheirsSchema(ctx context.Context, tbl *doltdb.Table) (*doltdb.Table, error) {
+	return tbl.GetSchemaConflictTheirTable(ctx)
+}
+
+// ResolveSchemaConflict resolves the schema conflict of the table |tblName| in |root| using |resolver|.
+func ResolveSchemaConflict(ctx context.Context, root *doltdb.RootValue, tblName string, resolver SchemaConflictResolver) (*doltdb.RootValue, error) {
+	tbl, ok, err := root.GetTable(ctx, tblName)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, doltdb.ErrTableNotFound
+	}
+
+	if has, err := tbl.HasSchemaConflict(); err != nil {
+		return nil, err
+	} else if !has {
+		return nil, doltdb.ErrNoConflicts
+	}
+
+	tbl, err = resolver(ctx, tbl)
+	if err != nil {
+		return nil, err
+	}
+
+	return root.PutTable(ctx, tblName, tbl)
+}
+
 func ResolveTable(ctx context.Context, vrw types.ValueReadWriter, tblName string, tbl *doltdb.Table, autoResFunc AutoResolver, sess *editor.TableEditSession) error {
 	if has, err := tbl.HasConflicts(); err != nil {
 		return err
@@ -51,7 +89,7 @@ func ResolveTable(ctx context.Context, vrw types.ValueReadWriter, tblName string
 	if schema.IsKeyless(tblSch) {
 		tbl, err = resolveKeylessTable(ctx, tbl, autoResFunc)
 	} else {
-		tbl, err = resolvePkTable(ctx, sess, tbl, tblName, autoResFunc)
+		tbl, err = resolvePkTable(ctx, vrw, sess, tbl, tblName, autoResFunc)
 	}
 	if err != nil {
 		return err
@@ -77,7 +115,7 @@ func ResolveTable(ctx context.Context, vrw types.ValueReadWriter, tblName string
 	})
 }
 
-func resolvePkTable(ctx context.Context, sess *editor.TableEditSession, tbl *doltdb.Table, tblName string, auto AutoResolver) (*doltdb.Table, error) {
+func resolvePkTable(ctx context.Context, vrw types.ValueReadWriter, sess *editor.TableEditSession, tbl *doltdb.Table, tblName string, auto AutoResolver) (*doltdb.Table, error) {
 	tblSch, err := tbl.GetSchema(ctx)
 	if err != nil {
 		return nil, err
@@ -99,13 +137,23 @@ func resolvePkTable(ctx context.Context, sess *editor.TableEditSession, tbl *dol
 			return false, err
 		}
 
-		updated, err := auto(key, cnf)
+		chosen, err := auto(key, cnf)
+		if err != nil {
+			return false, err
+		}
+
+		updated, err := resolveConflictingCells(ctx, vrw, tblSch, cnf, chosen)
 		if err != nil {
 			return false, err
 		}
 
+		// our value of the conflict is the row in the table
+		if valutil.NilSafeEqCheck(updated, cnf.Value) {
+			return false, nil
+		}
+
 		if types.IsNull(updated) {
-			originalRow, err := row.FromNoms(tblSch, key.(types.Tuple), cnf.Base.(types.Tuple))
+			originalRow, err := row.FromNoms(tblSch, key.(types.Tuple), cnf.Value.(types.Tuple))
 			if err != nil {
 				return false, err
 			}
@@ -165,6 +213,62 @@ func resolvePkTable(ctx context.Context, sess *editor.TableEditSession, tbl *dol
 	return newTbl, nil
 }
 
+// resolveConflictingCells returns the row which resolves |cnf| to the row chosen by an AutoResolver. When the row was
+// modified on both sides of the merge only the cells which were changed on both sides take the chosen row's values, and
+// every other cell keeps the value it was given by the merge.
+func resolveConflictingCells(ctx context.Context, vrw types.ValueReadWriter, sch schema.Schema, cnf doltdb.Conflict, chosen types.Value) (types.Value, error) {
+	if types.IsNull(chosen) || types.IsNull(cnf.Value) || types.IsNull(cnf.MergeValue) {
+		return chosen, nil
+	}
+
+	var baseVals row.TaggedValues
+	if !types.IsNull(cnf.Base) {
+		var err error
+		baseVals, err = row.ParseTaggedValues(cnf.Base.(types.Tuple))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	vals, err := row.ParseTaggedValues(cnf.Value.(types.Tuple))
+	if err != nil {
+		return nil, err
+	}
+
+	mergeVals, err := row.ParseTaggedValues(cnf.MergeValue.(types.Tuple))
+	if err != nil {
+		return nil, err
+	}
+
+	chosenVals, err := row.ParseTaggedValues(chosen.(types.Tuple))
+	if err != nil {
+		return nil, err
+	}
+
+	resultVals := make(row.TaggedValues)
+	err = sch.GetNonPKCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		baseVal, _ := baseVals.Get(tag)
+		val, _ := vals.Get(tag)
+		mergeVal, _ := mergeVals.Get(tag)
+
+		resultVal, isConflict, err := mergeColumnCell(ctx, vrw, col, val, mergeVal, baseVal)
+		if err != nil {
+			return true, err
+		}
+		if isConflict {
+			resultVal, _ = chosenVals.Get(tag)
+		}
+
+		resultVals[tag] = resultVal
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resultVals.NomsTupleForNonPKCols(vrw.Format(), sch.GetNonPKCols()).Value(ctx)
+}
+
 func resolveKeylessTable(ctx context.Context, tbl *doltdb.Table, auto AutoResolver) (*doltdb.Table, error) {
 	_, conflicts, err := tbl.GetConflicts(ctx)
 	if err != nil {
@@ -0,0 +1,34 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+)
+
+// Revert undoes the changes introduced by |cm| relative to its parent on |root|. The inverse changes are applied using
+// a three-way merge of the parent of |cm| into |root| where |cm| is the ancestor, so rows which were modified by |cm| and
+// which have been modified again since are returned as conflicts on the resulting root.
+func Revert(ctx context.Context, ddb *doltdb.DoltDB, root *doltdb.RootValue, cm *doltdb.Commit, opts MergeOpts) (*doltdb.RootValue, map[string]*MergeStats, error) {
+	cmRoot, parentRoot, err := getCommitAndParentRoots(ctx, ddb, cm)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return MergeRoots(ctx, root, parentRoot, cmRoot, opts)
+}
@@ -0,0 +1,138 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmd "github.com/dolthub/dolt/go/cmd/dolt/commands"
+	dtu "github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestRevert(t *testing.T) {
+	tests := []struct {
+		name      string
+		setup     []testCommand
+		revert    []string
+		conflicts bool
+		expected  tupleSet
+	}{
+		{
+			name: "revert the head commit",
+			setup: []testCommand{
+				{cmd.SqlCmd{}, []string{"-q", "insert into noKey values (1,2);"}},
+				{cmd.CommitCmd{}, []string{"-am", "added (1,2)"}},
+				{cmd.SqlCmd{}, []string{"-q", "insert into noKey values (3,4);"}},
+				{cmd.CommitCmd{}, []string{"-am", "added (3,4)"}},
+			},
+			revert: []string{"HEAD"},
+			expected: mustTupleSet(
+				dtu.MustTuple(cardTag, types.Uint(1), c1Tag, types.Int(1), c2Tag, types.Int(2)),
+			),
+		},
+		{
+			name: "revert an older commit",
+			setup: []testCommand{
+				{cmd.SqlCmd{}, []string{"-q", "insert into noKey values (1,2);"}},
+				{cmd.CommitCmd{}, []string{"-am", "added (1,2)"}},
+				{cmd.SqlCmd{}, []string{"-q", "insert into noKey values (3,4);"}},
+				{cmd.CommitCmd{}, []string{"-am", "added (3,4)"}},
+			},
+			revert: []string{"HEAD~1"},
+			expected: mustTupleSet(
+				dtu.MustTuple(cardTag, types.Uint(1), c1Tag, types.Int(3), c2Tag, types.Int(4)),
+			),
+		},
+		{
+			name: "revert multiple commits",
+			setup: []testCommand{
+				{cmd.SqlCmd{}, []string{"-q", "insert into noKey values (1,2);"}},
+				{cmd.CommitCmd{}, []string{"-am", "added (1,2)"}},
+				{cmd.SqlCmd{}, []string{"-q", "insert into noKey values (3,4);"}},
+				{cmd.CommitCmd{}, []string{"-am", "added (3,4)"}},
+				{cmd.SqlCmd{}, []string{"-q", "insert into noKey values (5,6);"}},
+				{cmd.CommitCmd{}, []string{"-am", "added (5,6)"}},
+			},
+			revert: []string{"HEAD", "HEAD~1"},
+			expected: mustTupleSet(
+				dtu.MustTuple(cardTag, types.Uint(1), c1Tag, types.Int(1), c2Tag, types.Int(2)),
+			),
+		},
+		{
+			name: "revert with conflicts",
+			setup: []testCommand{
+				{cmd.SqlCmd{}, []string{"-q", "insert into noKey values (1,2);"}},
+				{cmd.CommitCmd{}, []string{"-am", "added (1,2)"}},
+				{cmd.SqlCmd{}, []string{"-q", "insert into noKey values (1,2);"}},
+				{cmd.CommitCmd{}, []string{"-am", "added (1,2) again"}},
+			},
+			revert:    []string{"HEAD~1"},
+			conflicts: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			dEnv := dtu.CreateTestEnv()
+
+			root, err := dEnv.WorkingRoot(ctx)
+			require.NoError(t, err)
+			root, err = root.CreateEmptyTable(ctx, tblName, sch)
+			require.NoError(t, err)
+			err = dEnv.UpdateWorkingRoot(ctx, root)
+			require.NoError(t, err)
+			_, err = dEnv.UpdateStagedRoot(ctx, root)
+			require.NoError(t, err)
+			exitCode := cmd.CommitCmd{}.Exec(ctx, "commit", []string{"-m", "created table"}, dEnv)
+			require.Equal(t, 0, exitCode)
+
+			for _, c := range test.setup {
+				exitCode := c.cmd.Exec(ctx, c.cmd.Name(), c.args, dEnv)
+				require.Equal(t, 0, exitCode)
+			}
+
+			revert := cmd.RevertCmd{}
+			exitCode = revert.Exec(ctx, revert.Name(), test.revert, dEnv)
+
+			root, err = dEnv.WorkingRoot(ctx)
+			require.NoError(t, err)
+
+			if test.conflicts {
+				assert.Equal(t, 1, exitCode)
+				inConflict, err := root.TablesInConflict(ctx)
+				require.NoError(t, err)
+				assert.Equal(t, []string{tblName}, inConflict)
+				return
+			}
+
+			require.Equal(t, 0, exitCode)
+			tbl, _, err := root.GetTable(ctx, tblName)
+			require.NoError(t, err)
+			assertKeylessRows(t, ctx, tbl, test.expected)
+
+			headRoot, err := dEnv.HeadRoot(ctx)
+			require.NoError(t, err)
+			headHash, err := headRoot.HashOf()
+			require.NoError(t, err)
+			assert.Equal(t, headHash, dEnv.RepoState.WorkingHash())
+		})
+	}
+}
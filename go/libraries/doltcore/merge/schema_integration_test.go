@@ -29,6 +29,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
 )
 
 type testCommand struct {
@@ -245,6 +246,29 @@ var mergeSchemaTests = []mergeSchemaTest{
 			schema.NewIndex("c3_idx", []uint64{4696}, []uint64{4696, 3228}, nil, schema.IndexProperties{IsUserDefined: true}),
 		),
 	},
+	{
+		name: "widen columns on both branches, merge",
+		setup: []testCommand{
+			{commands.SqlCmd{}, []string{"-q", "alter table test modify c2 bigint;"}},
+			{commands.SqlCmd{}, []string{"-q", "alter table test modify c3 bigint;"}},
+			{commands.AddCmd{}, []string{"."}},
+			{commands.CommitCmd{}, []string{"-m", "modified branch master"}},
+			{commands.CheckoutCmd{}, []string{"other"}},
+			{commands.SqlCmd{}, []string{"-q", "alter table test modify c2 bigint;"}},
+			{commands.SqlCmd{}, []string{"-q", "alter table test rename column c3 to c33;"}},
+			{commands.AddCmd{}, []string{"."}},
+			{commands.CommitCmd{}, []string{"-m", "modified branch other"}},
+			{commands.CheckoutCmd{}, []string{"master"}},
+		},
+		sch: schemaFromColsAndIdxs(
+			colCollection(
+				newColTypeInfo("pk", uint64(3228), typeinfo.Int32Type, true, schema.NotNullConstraint{}),
+				newColTypeInfo("c1", uint64(8201), typeinfo.Int32Type, false, schema.NotNullConstraint{}),
+				newColTypeInfo("c2", uint64(8539), typeinfo.Int64Type, false),
+				newColTypeInfo("c33", uint64(4696), typeinfo.Int64Type, false)),
+			schema.NewIndex("c1_idx", []uint64{8201}, []uint64{8201, 3228}, nil, schema.IndexProperties{IsUserDefined: true}),
+		),
+	},
 }
 
 var mergeSchemaConflictTests = []mergeSchemaConflictTest{
@@ -338,6 +362,29 @@ var mergeSchemaConflictTests = []mergeSchemaConflictTest{
 			},
 		},
 	},
+	{
+		name: "column renamed on both branches",
+		setup: []testCommand{
+			{commands.SqlCmd{}, []string{"-q", "alter table test rename column c3 to c4;"}},
+			{commands.AddCmd{}, []string{"."}},
+			{commands.CommitCmd{}, []string{"-m", "modified branch master"}},
+			{commands.CheckoutCmd{}, []string{"other"}},
+			{commands.SqlCmd{}, []string{"-q", "alter table test rename column c3 to c5;"}},
+			{commands.AddCmd{}, []string{"."}},
+			{commands.CommitCmd{}, []string{"-m", "modified branch other"}},
+			{commands.CheckoutCmd{}, []string{"master"}},
+		},
+		expConflict: merge.SchemaConflict{
+			TableName: "test",
+			ColConflicts: []merge.ColConflict{
+				{
+					Kind:   merge.TagCollision,
+					Ours:   newColTypeInfo("c4", uint64(4696), typeinfo.Int32Type, false),
+					Theirs: newColTypeInfo("c5", uint64(4696), typeinfo.Int32Type, false),
+				},
+			},
+		},
+	},
 	{
 		name: "index definition collision",
 		setup: []testCommand{
@@ -521,7 +568,7 @@ func testMergeSchemasWithConflicts(t *testing.T, test mergeSchemaConflictTest) {
 
 	otherSch := getSchema(t, dEnv)
 
-	_, actConflicts, err := merge.SchemaMerge(masterSch, otherSch, ancSch, "test")
+	_, actConflicts, err := merge.SchemaMerge(ctx, masterSch, otherSch, ancSch, "test", sqlutil.NewCheckEvaluator)
 	require.NoError(t, err)
 	assert.Equal(t, actConflicts.TableName, "test")
 
@@ -538,6 +585,39 @@ func testMergeSchemasWithConflicts(t *testing.T, test mergeSchemaConflictTest) {
 		assert.True(t, test.expConflict.IdxConflicts[i].Ours.Equals(icc.Ours))
 		assert.True(t, test.expConflict.IdxConflicts[i].Theirs.Equals(icc.Theirs))
 	}
+
+	// merging records the schema conflict on the table, which keeps our schema
+	exitCode = commands.CheckoutCmd{}.Exec(ctx, "checkout", []string{"master"}, dEnv)
+	require.Equal(t, 0, exitCode)
+	exitCode = commands.MergeCmd{}.Exec(ctx, "merge", []string{"other"}, dEnv)
+	require.Equal(t, 0, exitCode)
+
+	wr, err := dEnv.WorkingRoot(ctx)
+	require.NoError(t, err)
+	tbl, _, err := wr.GetTable(ctx, "test")
+	require.NoError(t, err)
+	has, err := tbl.HasSchemaConflict()
+	require.NoError(t, err)
+	assert.Equal(t, test.expConflict.Count() > 0, has)
+	if has {
+		_, ourSch, theirSch, desc, err := tbl.GetSchemaConflict(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, masterSch, ourSch)
+		assert.Equal(t, otherSch, theirSch)
+		assert.Equal(t, actConflicts.Description(), desc)
+		assert.Equal(t, masterSch, getSchema(t, dEnv))
+
+		resolved, err := merge.ResolveSchemaConflict(ctx, wr, "test", merge.TheirsSchema)
+		require.NoError(t, err)
+		tbl, _, err = resolved.GetTable(ctx, "test")
+		require.NoError(t, err)
+		has, err = tbl.HasSchemaConflict()
+		require.NoError(t, err)
+		assert.False(t, has)
+		sch, err := tbl.GetSchema(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, otherSch, sch)
+	}
 }
 
 func testMergeForeignKeys(t *testing.T, test mergeForeignKeyTest) {
@@ -569,7 +649,7 @@ func testMergeForeignKeys(t *testing.T, test mergeForeignKeyTest) {
 	otherRoot, err := dEnv.WorkingRoot(ctx)
 	require.NoError(t, err)
 
-	mergedRoot, _, err := merge.MergeRoots(ctx, masterRoot, otherRoot, ancRoot)
+	mergedRoot, _, err := merge.MergeRoots(ctx, masterRoot, otherRoot, ancRoot, merge.MergeOpts{Checks: sqlutil.NewCheckEvaluator})
 	assert.NoError(t, err)
 
 	fkc, err := mergedRoot.GetForeignKeyCollection(ctx)
@@ -23,6 +23,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/row"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/typed/noms"
@@ -82,7 +83,7 @@ func (dl TableDataLocation) NewCreatingWriter(ctx context.Context, _ DataMoverOp
 		return nil, err
 	}
 
-	sess := editor.CreateTableEditSession(updatedRoot, editor.TableEditSessionProps{})
+	sess := editor.CreateTableEditSession(updatedRoot, editor.TableEditSessionProps{Checks: sqlutil.NewCheckEvaluator})
 	tableEditor, err := sess.GetTableEditor(ctx, dl.Name, outSch)
 	if err != nil {
 		return nil, err
@@ -120,7 +121,7 @@ func (dl TableDataLocation) NewUpdatingWriter(ctx context.Context, _ DataMoverOp
 		return nil, err
 	}
 
-	sess := editor.CreateTableEditSession(root, editor.TableEditSessionProps{})
+	sess := editor.CreateTableEditSession(root, editor.TableEditSessionProps{Checks: sqlutil.NewCheckEvaluator})
 	tableEditor, err := sess.GetTableEditor(ctx, dl.Name, tblSch)
 	if err != nil {
 		return nil, err
@@ -163,7 +164,7 @@ func (dl TableDataLocation) NewReplacingWriter(ctx context.Context, _ DataMoverO
 		return nil, err
 	}
 
-	sess := editor.CreateTableEditSession(updatedRoot, editor.TableEditSessionProps{})
+	sess := editor.CreateTableEditSession(updatedRoot, editor.TableEditSessionProps{Checks: sqlutil.NewCheckEvaluator})
 	tableEditor, err := sess.GetTableEditor(ctx, dl.Name, tblSch)
 	if err != nil {
 		return nil, err
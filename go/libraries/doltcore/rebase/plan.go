@@ -0,0 +1,120 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rebase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// PlanAction is the action a rebase plan takes for a single commit.
+type PlanAction string
+
+const (
+	// PickAction replays the commit as is.
+	PickAction PlanAction = "pick"
+	// SquashAction melds the commit into the previous commit, combining their messages.
+	SquashAction PlanAction = "squash"
+	// FixupAction melds the commit into the previous commit, keeping the message of the previous commit.
+	FixupAction PlanAction = "fixup"
+	// DropAction removes the commit from the history.
+	DropAction PlanAction = "drop"
+	// RewordAction replays the commit with a new commit message.
+	RewordAction PlanAction = "reword"
+)
+
+var planActions = map[string]PlanAction{
+	"pick":   PickAction,
+	"p":      PickAction,
+	"squash": SquashAction,
+	"s":      SquashAction,
+	"fixup":  FixupAction,
+	"f":      FixupAction,
+	"drop":   DropAction,
+	"d":      DropAction,
+	"reword": RewordAction,
+	"r":      RewordAction,
+}
+
+var ErrEmptyPlan = errors.New("rebase plan is empty")
+
+// PlanStep is a single line of a rebase plan.
+type PlanStep struct {
+	Action PlanAction
+	// CommitSpec is the commit the action applies to, usually a commit hash
+	CommitSpec string
+	// Message is the new commit message for a RewordAction
+	Message string
+}
+
+// ParsePlan parses a rebase plan. Each non-empty line of a plan which does not start with '#' has the form
+//   <action> <commit> [<text>]
+// and plan steps are replayed in the order they are listed. For the reword action the text is the new commit message,
+// for all other actions it is ignored.
+func ParsePlan(plan string) ([]PlanStep, error) {
+	var steps []PlanStep
+	for i, line := range strings.Split(plan, "\n") {
+		line = strings.TrimSpace(line)
+
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		action, ok := planActions[strings.ToLower(fields[0])]
+
+		if !ok {
+			return nil, fmt.Errorf("line %d: unknown rebase action '%s'", i+1, fields[0])
+		}
+
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("line %d: missing commit for action '%s'", i+1, fields[0])
+		}
+
+		step := PlanStep{Action: action, CommitSpec: fields[1]}
+
+		if action == RewordAction {
+			rest := strings.TrimSpace(line[len(fields[0]):])
+			step.Message = strings.TrimSpace(rest[len(fields[1]):])
+
+			if len(step.Message) == 0 {
+				return nil, fmt.Errorf("line %d: missing commit message for action '%s'", i+1, fields[0])
+			}
+		}
+
+		if (action == SquashAction || action == FixupAction) && !hasCommitBefore(steps) {
+			return nil, fmt.Errorf("line %d: cannot '%s' without a previous commit", i+1, fields[0])
+		}
+
+		steps = append(steps, step)
+	}
+
+	if len(steps) == 0 {
+		return nil, ErrEmptyPlan
+	}
+
+	return steps, nil
+}
+
+func hasCommitBefore(steps []PlanStep) bool {
+	for _, step := range steps {
+		if step.Action != DropAction {
+			return true
+		}
+	}
+
+	return false
+}
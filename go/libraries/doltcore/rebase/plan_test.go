@@ -0,0 +1,90 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rebase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePlan(t *testing.T) {
+	tests := []struct {
+		name     string
+		plan     string
+		expected []PlanStep
+		expErr   bool
+	}{
+		{
+			name: "all actions",
+			plan: `
+# a comment
+pick a1 first commit
+squash a2 second commit
+f a3
+drop a4 fourth commit
+
+reword a5   a new   message
+`,
+			expected: []PlanStep{
+				{Action: PickAction, CommitSpec: "a1"},
+				{Action: SquashAction, CommitSpec: "a2"},
+				{Action: FixupAction, CommitSpec: "a3"},
+				{Action: DropAction, CommitSpec: "a4"},
+				{Action: RewordAction, CommitSpec: "a5", Message: "a new   message"},
+			},
+		},
+		{
+			name:   "empty plan",
+			plan:   "# nothing to do\n\n",
+			expErr: true,
+		},
+		{
+			name:   "unknown action",
+			plan:   "edit a1",
+			expErr: true,
+		},
+		{
+			name:   "missing commit",
+			plan:   "pick",
+			expErr: true,
+		},
+		{
+			name:   "reword without message",
+			plan:   "reword a1",
+			expErr: true,
+		},
+		{
+			name:   "squash without previous commit",
+			plan:   "drop a1\nsquash a2",
+			expErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			steps, err := ParsePlan(test.plan)
+
+			if test.expErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, steps)
+		})
+	}
+}
@@ -291,6 +291,13 @@ func replayCommitWithNewTag(ctx context.Context, root, parentRoot, rebasedParent
 			}
 		}
 
+		for _, check := range sch.Checks().AllChecks() {
+			_, err = rebasedSch.Checks().AddCheck(check.Name, check.Expression)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		// super schema rebase
 		ss, _, err := root.GetSuperSchema(ctx, tblName)
 
@@ -40,6 +40,10 @@ func TestJsonMarshalAndUnmarshal(t *testing.T) {
 			NewInternalRef("create"),
 			`{"test":"refs/internal/create"}`,
 		},
+		{
+			NewStashRef("abc"),
+			`{"test":"refs/stashes/abc"}`,
+		},
 	}
 
 	for _, test := range tests {
@@ -134,6 +138,11 @@ func TestEqualsStr(t *testing.T) {
 			"refs/internal/create",
 			true,
 		},
+		{
+			NewStashRef("refs/stashes/abc"),
+			"refs/stashes/abc",
+			true,
+		},
 	}
 
 	for _, test := range tests {
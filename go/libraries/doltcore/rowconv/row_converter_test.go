@@ -27,6 +27,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/untyped"
+	"github.com/dolthub/dolt/go/store/chunks"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
@@ -42,12 +43,14 @@ var srcCols, _ = schema.NewColCollection(
 
 var srcSch = schema.MustSchemaFromCols(srcCols)
 
+var vrw = types.NewValueStore((&chunks.MemoryStorage{}).NewView())
+
 func TestRowConverter(t *testing.T) {
 	mapping, err := TypedToUntypedMapping(srcSch)
 
 	assert.NoError(t, err)
 
-	rConv, err := NewRowConverter(mapping)
+	rConv, err := NewRowConverter(context.Background(), vrw, mapping)
 
 	if err != nil {
 		t.Fatal("Error creating row converter")
@@ -94,7 +97,7 @@ func TestUnneccessaryConversion(t *testing.T) {
 		t.Error(err)
 	}
 
-	rconv, err := NewRowConverter(mapping)
+	rconv, err := NewRowConverter(context.Background(), vrw, mapping)
 
 	if !rconv.IdentityConverter {
 		t.Error("expected identity converter")
@@ -114,7 +117,7 @@ func TestSpecialBoolHandling(t *testing.T) {
 
 	mapping, err := TagMapping(untypedSch, sch)
 	require.NoError(t, err)
-	rconv, err := NewImportRowConverter(mapping)
+	rconv, err := NewImportRowConverter(context.Background(), vrw, mapping)
 	require.NoError(t, err)
 	inRow, err := row.New(types.Format_7_18, untypedSch, row.TaggedValues{
 		0: types.String("76"),
@@ -132,7 +135,7 @@ func TestSpecialBoolHandling(t *testing.T) {
 	require.NoError(t, err)
 	assert.True(t, row.AreEqual(outData, expected, mapping.DestSch))
 
-	rconvNoHandle, err := NewRowConverter(mapping)
+	rconvNoHandle, err := NewRowConverter(context.Background(), vrw, mapping)
 	require.NoError(t, err)
 	results, errStr := rconvNoHandle.Convert(inRow)
 	assert.Nil(t, results)
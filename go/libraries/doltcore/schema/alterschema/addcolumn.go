@@ -112,7 +112,7 @@ func updateTableWithNewSchema(ctx context.Context, tblName string, tbl *doltdb.T
 		if err != nil {
 			return true, err
 		}
-		newRow, err := sqlutil.ApplyDefaults(ctx, newSchema, newSqlSchema, []int{columnIndex}, oldRow)
+		newRow, err := sqlutil.ApplyDefaults(ctx, vrw, newSchema, newSqlSchema, []int{columnIndex}, oldRow)
 		if err != nil {
 			return true, err
 		}
@@ -164,6 +164,10 @@ func addColumnToSchema(sch schema.Schema, tag uint64, newColName string, typeInf
 		return nil, err
 	}
 	newSch.Indexes().AddIndex(sch.Indexes().AllIndexes()...)
+	err = copyChecks(sch, newSch)
+	if err != nil {
+		return nil, err
+	}
 
 	return newSch, nil
 }
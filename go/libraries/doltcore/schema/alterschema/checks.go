@@ -0,0 +1,114 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alterschema
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table"
+)
+
+// AddCheck adds a CHECK constraint to a table. The expression must only reference the columns of the table, and every
+// existing row of the table must satisfy it.
+func AddCheck(ctx context.Context, tblName string, tbl *doltdb.Table, name, expression string) (*doltdb.Table, error) {
+	if tbl == nil {
+		panic("invalid parameters")
+	}
+
+	tblSch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	check, err := tblSch.Checks().AddCheck(name, expression)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlCtx, ok := ctx.(*sql.Context)
+	if !ok {
+		sqlCtx = sql.NewContext(ctx)
+	}
+	_, err = sqlutil.ParseCheckExpression(sqlCtx, tblSch, check)
+	if err != nil {
+		return nil, err
+	}
+
+	newTbl, err := tbl.UpdateSchema(ctx, tblSch)
+	if err != nil {
+		return nil, err
+	}
+
+	err = table.ChecksAreSatisfied(ctx, tblName, newTbl, sqlutil.NewCheckEvaluator)
+	if err != nil {
+		return nil, err
+	}
+
+	return newTbl, nil
+}
+
+// DropCheck removes the CHECK constraint with the given name from a table.
+func DropCheck(ctx context.Context, tbl *doltdb.Table, name string) (*doltdb.Table, error) {
+	if tbl == nil {
+		panic("invalid parameters")
+	}
+
+	tblSch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tblSch.Checks().RemoveCheck(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return tbl.UpdateSchema(ctx, tblSch)
+}
+
+// copyChecks adds the CHECK constraints of one schema to another.
+func copyChecks(from, to schema.Schema) error {
+	for _, check := range from.Checks().AllChecks() {
+		_, err := to.Checks().AddCheck(check.Name, check.Expression)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateColumnNotInChecks returns an error if the column with the name given is referenced by a CHECK constraint of
+// the schema, as the constraint's expression would no longer be valid if the column was dropped or renamed.
+func validateColumnNotInChecks(ctx context.Context, sch schema.Schema, colName, operation string) error {
+	for _, check := range sch.Checks().AllChecks() {
+		cols, err := sqlutil.CheckColumns(ctx, sch, check)
+		if err != nil {
+			return err
+		}
+		for _, col := range cols {
+			if strings.EqualFold(col, colName) {
+				return fmt.Errorf("cannot %s column `%s` as it is used in CHECK constraint `%s`", operation, colName, check.Name)
+			}
+		}
+	}
+	return nil
+}
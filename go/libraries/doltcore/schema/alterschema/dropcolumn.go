@@ -65,6 +65,11 @@ func DropColumn(ctx context.Context, tbl *doltdb.Table, colName string, foreignK
 		}
 	}
 
+	err = validateColumnNotInChecks(ctx, tblSch, colName, "drop")
+	if err != nil {
+		return nil, err
+	}
+
 	for _, index := range tblSch.Indexes().IndexesWithColumn(colName) {
 		_, err = tblSch.Indexes().RemoveIndex(index.Name())
 		if err != nil {
@@ -98,6 +103,10 @@ func DropColumn(ctx context.Context, tbl *doltdb.Table, colName string, foreignK
 		return nil, err
 	}
 	newSch.Indexes().AddIndex(tblSch.Indexes().AllIndexes()...)
+	err = copyChecks(tblSch, newSch)
+	if err != nil {
+		return nil, err
+	}
 
 	vrw := tbl.ValueReadWriter()
 	schemaVal, err := encoding.MarshalSchemaAsNomsValue(ctx, vrw, newSch)
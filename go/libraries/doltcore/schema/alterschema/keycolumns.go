@@ -0,0 +1,126 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alterschema
+
+import (
+	"context"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/encoding"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// StoreColumnsAsKeys changes the columns with the tags given to store their values the way that the values of keys are
+// stored, so that the columns can be indexed. TEXT columns store their values as blobs, which are not ordered by their
+// contents, so their values are rewritten as inline strings. Columns which already store their values as keys are left
+// unchanged.
+func StoreColumnsAsKeys(ctx context.Context, tbl *doltdb.Table, tags []uint64) (*doltdb.Table, error) {
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	changed := make(map[uint64]schema.Column)
+	for _, tag := range tags {
+		col, ok := sch.GetAllCols().GetByTag(tag)
+		if !ok {
+			return nil, schema.ErrColNotFound
+		}
+		keyTi := typeinfo.KeyType(col.TypeInfo)
+		if !keyTi.Equals(col.TypeInfo) {
+			changed[tag] = col
+		}
+	}
+
+	if len(changed) == 0 {
+		return tbl, nil
+	}
+
+	newCols, err := schema.MapColCollection(sch.GetAllCols(), func(col schema.Column) (schema.Column, error) {
+		if _, ok := changed[col.Tag]; ok {
+			col.TypeInfo = typeinfo.KeyType(col.TypeInfo)
+			col.Kind = col.TypeInfo.NomsKind()
+		}
+		return col, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	newSch, err := schema.SchemaFromCols(newCols)
+	if err != nil {
+		return nil, err
+	}
+	newSch.Indexes().AddIndex(sch.Indexes().AllIndexes()...)
+	err = copyChecks(sch, newSch)
+	if err != nil {
+		return nil, err
+	}
+
+	vrw := tbl.ValueReadWriter()
+	rowData, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	me := rowData.Edit()
+	err = rowData.Iter(ctx, func(key, value types.Value) (stop bool, err error) {
+		r, err := row.FromNoms(sch, key.(types.Tuple), value.(types.Tuple))
+		if err != nil {
+			return true, err
+		}
+
+		for tag, col := range changed {
+			val, ok := r.GetColVal(tag)
+			if !ok || types.IsNull(val) {
+				continue
+			}
+			newVal, err := typeinfo.Convert(ctx, vrw, val, col.TypeInfo, typeinfo.KeyType(col.TypeInfo))
+			if err != nil {
+				return true, err
+			}
+			r, err = r.SetColVal(tag, newVal, newSch)
+			if err != nil {
+				return true, err
+			}
+		}
+
+		me.Set(key, r.NomsMapValue(newSch))
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	newRowData, err := me.Map(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	newSchemaVal, err := encoding.MarshalSchemaAsNomsValue(ctx, vrw, newSch)
+	if err != nil {
+		return nil, err
+	}
+
+	indexData, err := tbl.GetIndexData(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return doltdb.NewTable(ctx, vrw, newSchemaVal, newRowData, indexData)
+}
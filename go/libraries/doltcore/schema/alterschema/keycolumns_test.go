@@ -0,0 +1,89 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alterschema
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/encoding"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestStoreColumnsAsKeys(t *testing.T) {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnv()
+	vrw := dEnv.DoltDB.ValueReadWriter()
+
+	textTi, err := typeinfo.FromSqlType(sql.Text)
+	require.NoError(t, err)
+	textCol, err := schema.NewColumnWithTypeInfo("body", 1, textTi, false, "", false, "")
+	require.NoError(t, err)
+	sch := dtestutils.CreateSchema(schema.NewColumn("id", 0, types.IntKind, true, schema.NotNullConstraint{}), textCol)
+	require.Equal(t, types.BlobKind, textCol.Kind)
+
+	body, err := textTi.ConvertValueToNomsValue(ctx, vrw, "hello")
+	require.NoError(t, err)
+	rowData := dtestutils.MustRowData(t, ctx, vrw, sch, []row.TaggedValues{
+		{0: types.Int(1), 1: body},
+		{0: types.Int(2)},
+	})
+	schVal, err := encoding.MarshalSchemaAsNomsValue(ctx, vrw, sch)
+	require.NoError(t, err)
+	empty, err := types.NewMap(ctx, vrw)
+	require.NoError(t, err)
+	tbl, err := doltdb.NewTable(ctx, vrw, schVal, *rowData, empty)
+	require.NoError(t, err)
+
+	updatedTbl, err := StoreColumnsAsKeys(ctx, tbl, []uint64{0, 1})
+	require.NoError(t, err)
+
+	updatedSch, err := updatedTbl.GetSchema(ctx)
+	require.NoError(t, err)
+	col, ok := updatedSch.GetAllCols().GetByTag(1)
+	require.True(t, ok)
+	assert.Equal(t, types.StringKind, col.Kind)
+	assert.Equal(t, textTi.ToSqlType(), col.TypeInfo.ToSqlType())
+
+	updatedRowData, err := updatedTbl.GetRowData(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), updatedRowData.Len())
+	err = updatedRowData.Iter(ctx, func(key, value types.Value) (stop bool, err error) {
+		r, err := row.FromNoms(updatedSch, key.(types.Tuple), value.(types.Tuple))
+		require.NoError(t, err)
+		val, ok := r.GetColVal(1)
+		if id, _ := r.GetColVal(0); id == types.Int(1) {
+			assert.Equal(t, types.String("hello"), val)
+		} else {
+			assert.False(t, ok)
+		}
+		return false, nil
+	})
+	require.NoError(t, err)
+
+	// columns already stored as keys are left unchanged
+	unchangedTbl, err := StoreColumnsAsKeys(ctx, updatedTbl, []uint64{1})
+	require.NoError(t, err)
+	assert.Equal(t, updatedTbl, unchangedTbl)
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/row"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema/encoding"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
@@ -86,10 +87,19 @@ func validateModifyColumn(ctx context.Context, tbl *doltdb.Table, existingCol sc
 		return err
 	}
 
-	if existingCol.Kind != modifiedCol.Kind || !existingCol.TypeInfo.Equals(modifiedCol.TypeInfo) {
+	// a column may be widened to a larger type of the same kind, as the stored values remain valid
+	if existingCol.Kind != modifiedCol.Kind ||
+		!(existingCol.TypeInfo.Equals(modifiedCol.TypeInfo) || typeinfo.IsWidening(existingCol.TypeInfo, modifiedCol.TypeInfo)) {
 		return errors.New("unsupported feature: column types cannot be changed")
 	}
 
+	if existingCol.Name != modifiedCol.Name {
+		err = validateColumnNotInChecks(ctx, sch, existingCol.Name, "rename")
+		if err != nil {
+			return err
+		}
+	}
+
 	cols := sch.GetAllCols()
 	err = cols.Iter(func(currColTag uint64, currCol schema.Column) (stop bool, err error) {
 		if currColTag == modifiedCol.Tag {
@@ -109,7 +119,6 @@ func validateModifyColumn(ctx context.Context, tbl *doltdb.Table, existingCol sc
 }
 
 // updateTableWithModifiedColumn updates the existing table with the new schema. No data is changed.
-// TODO: type changes
 func updateTableWithModifiedColumn(ctx context.Context, tbl *doltdb.Table, newSchema schema.Schema, modifiedCol schema.Column) (*doltdb.Table, error) {
 	vrw := tbl.ValueReadWriter()
 	newSchemaVal, err := encoding.MarshalSchemaAsNomsValue(ctx, vrw, newSchema)
@@ -202,5 +211,9 @@ func replaceColumnInSchema(sch schema.Schema, oldCol schema.Column, newCol schem
 		return nil, err
 	}
 	newSch.Indexes().AddIndex(sch.Indexes().AllIndexes()...)
+	err = copyChecks(sch, newSch)
+	if err != nil {
+		return nil, err
+	}
 	return newSch, nil
 }
@@ -0,0 +1,120 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Check is a CHECK constraint on a table. Expression is a SQL expression over the columns of the table, and a row
+// violates the constraint when the expression evaluates to false. A NULL result satisfies the constraint.
+type Check struct {
+	Name       string
+	Expression string
+}
+
+type CheckCollection interface {
+	// AddCheck adds a CHECK constraint with the given name and expression.
+	AddCheck(name, expression string) (Check, error)
+	// AllChecks returns a slice containing all of the CHECK constraints in this collection, in the order they were added.
+	AllChecks() []Check
+	// Contains returns whether a CHECK constraint with the given case-insensitive name exists in this collection.
+	Contains(name string) bool
+	// Count returns the number of CHECK constraints in this collection.
+	Count() int
+	// Equals returns whether this collection contains the same CHECK constraints as another.
+	Equals(other CheckCollection) bool
+	// GetByNameCaseInsensitive returns the CHECK constraint with a matching case-insensitive name, the bool return value
+	// indicates if a match was found.
+	GetByNameCaseInsensitive(name string) (Check, bool)
+	// RemoveCheck removes the CHECK constraint with the given case-insensitive name from this collection.
+	RemoveCheck(name string) (Check, error)
+}
+
+type checkCollectionImpl struct {
+	checks []Check
+}
+
+func NewCheckCollection() CheckCollection {
+	return &checkCollectionImpl{}
+}
+
+func (cc *checkCollectionImpl) AddCheck(name, expression string) (Check, error) {
+	if name == "" {
+		return Check{}, fmt.Errorf("CHECK constraints must be named")
+	}
+	if strings.TrimSpace(expression) == "" {
+		return Check{}, fmt.Errorf("the expression of CHECK constraint `%s` is empty", name)
+	}
+	if cc.Contains(name) {
+		return Check{}, fmt.Errorf("`%s` already exists as a CHECK constraint for this table", name)
+	}
+
+	check := Check{Name: name, Expression: expression}
+	cc.checks = append(cc.checks, check)
+	return check, nil
+}
+
+func (cc *checkCollectionImpl) AllChecks() []Check {
+	checks := make([]Check, len(cc.checks))
+	copy(checks, cc.checks)
+	return checks
+}
+
+func (cc *checkCollectionImpl) Contains(name string) bool {
+	_, ok := cc.GetByNameCaseInsensitive(name)
+	return ok
+}
+
+func (cc *checkCollectionImpl) Count() int {
+	return len(cc.checks)
+}
+
+func (cc *checkCollectionImpl) Equals(other CheckCollection) bool {
+	if cc.Count() != other.Count() {
+		return false
+	}
+
+	for _, check := range cc.checks {
+		otherCheck, ok := other.GetByNameCaseInsensitive(check.Name)
+		if !ok || check != otherCheck {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (cc *checkCollectionImpl) GetByNameCaseInsensitive(name string) (Check, bool) {
+	for _, check := range cc.checks {
+		if strings.EqualFold(check.Name, name) {
+			return check, true
+		}
+	}
+
+	return Check{}, false
+}
+
+func (cc *checkCollectionImpl) RemoveCheck(name string) (Check, error) {
+	for i, check := range cc.checks {
+		if strings.EqualFold(check.Name, name) {
+			cc.checks = append(cc.checks[:i:i], cc.checks[i+1:]...)
+			return check, nil
+		}
+	}
+
+	return Check{}, fmt.Errorf("`%s` does not exist as a CHECK constraint for this table", name)
+}
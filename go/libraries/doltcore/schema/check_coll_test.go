@@ -0,0 +1,67 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckCollection(t *testing.T) {
+	checkColl := NewCheckCollection()
+	assert.Equal(t, 0, checkColl.Count())
+
+	chk1, err := checkColl.AddCheck("chk_price", "price > 0")
+	require.NoError(t, err)
+	assert.Equal(t, Check{Name: "chk_price", Expression: "price > 0"}, chk1)
+	chk2, err := checkColl.AddCheck("chk_name", "length(name) < 10")
+	require.NoError(t, err)
+
+	_, err = checkColl.AddCheck("CHK_PRICE", "price > 1")
+	assert.Error(t, err)
+	_, err = checkColl.AddCheck("", "price > 1")
+	assert.Error(t, err)
+	_, err = checkColl.AddCheck("chk_empty", "  ")
+	assert.Error(t, err)
+
+	assert.Equal(t, 2, checkColl.Count())
+	assert.Equal(t, []Check{chk1, chk2}, checkColl.AllChecks())
+	assert.True(t, checkColl.Contains("Chk_Name"))
+	check, ok := checkColl.GetByNameCaseInsensitive("CHK_NAME")
+	assert.True(t, ok)
+	assert.Equal(t, chk2, check)
+
+	otherColl := NewCheckCollection()
+	_, err = otherColl.AddCheck("chk_name", "length(name) < 10")
+	require.NoError(t, err)
+	_, err = otherColl.AddCheck("chk_price", "price > 0")
+	require.NoError(t, err)
+	assert.True(t, checkColl.Equals(otherColl))
+	_, err = otherColl.RemoveCheck("chk_price")
+	require.NoError(t, err)
+	_, err = otherColl.AddCheck("chk_price", "price >= 0")
+	require.NoError(t, err)
+	assert.False(t, checkColl.Equals(otherColl))
+
+	removed, err := checkColl.RemoveCheck("CHK_PRICE")
+	require.NoError(t, err)
+	assert.Equal(t, chk1, removed)
+	assert.False(t, checkColl.Contains("chk_price"))
+	assert.Equal(t, []Check{chk2}, checkColl.AllChecks())
+	_, err = checkColl.RemoveCheck("chk_price")
+	assert.Error(t, err)
+}
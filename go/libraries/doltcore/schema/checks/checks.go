@@ -0,0 +1,39 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package checks declares the evaluation of CHECK constraints. Evaluating their expressions requires the sql engine,
+// so the packages which write rows are given an EvaluatorFactory, such as sqlutil.NewCheckEvaluator, instead of
+// depending on the engine themselves.
+package checks
+
+import (
+	"context"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+)
+
+// Evaluator evaluates the CHECK constraints of a schema against rows of that schema.
+type Evaluator interface {
+	// Count returns the number of CHECK constraints that this evaluator evaluates.
+	Count() int
+	// ValidateRow returns an error for the first CHECK constraint that the given row violates.
+	ValidateRow(ctx context.Context, r row.Row) error
+	// Violations returns every CHECK constraint that the given row violates.
+	Violations(ctx context.Context, r row.Row) ([]schema.Check, error)
+}
+
+// EvaluatorFactory returns the Evaluator of the CHECK constraints of a schema, or an error if the expression of any of
+// them is invalid for the schema.
+type EvaluatorFactory func(ctx context.Context, sch schema.Schema) (Evaluator, error)
@@ -16,6 +16,7 @@ package schema
 
 import (
 	"errors"
+	"fmt"
 	"math"
 	"strings"
 
@@ -149,3 +150,14 @@ func (c Column) Equals(other Column) bool {
 func (c Column) KindString() string {
 	return KindToLwrStr[c.Kind]
 }
+
+// validateKeyColumn returns an error if the column cannot be part of a primary key or index. BLOB values are stored as
+// Noms blobs, which are not ordered by their contents, and JSON documents have no order at all.
+func validateKeyColumn(c Column) error {
+	if c.Kind == types.BlobKind {
+		return fmt.Errorf("BLOB column `%s` cannot be used in a primary key or index", c.Name)
+	} else if c.TypeInfo.GetTypeIdentifier() == typeinfo.JSONTypeIdentifier {
+		return fmt.Errorf("JSON column `%s` cannot be used in a primary key or index", c.Name)
+	}
+	return nil
+}
@@ -146,9 +146,15 @@ type encodedIndex struct {
 	IsSystemDefined bool     `noms:"hidden,omitempty" json:"hidden,omitempty"` // Was previously named Hidden, do not change noms name
 }
 
+type encodedCheck struct {
+	Name       string `noms:"name" json:"name"`
+	Expression string `noms:"expression" json:"expression"`
+}
+
 type schemaData struct {
 	Columns         []encodedColumn `noms:"columns" json:"columns"`
 	IndexCollection []encodedIndex  `noms:"idxColl,omitempty" json:"idxColl,omitempty"`
+	CheckCollection []encodedCheck  `noms:"checkColl,omitempty" json:"checkColl,omitempty"`
 }
 
 func toSchemaData(sch schema.Schema) (schemaData, error) {
@@ -178,7 +184,15 @@ func toSchemaData(sch schema.Schema) (schemaData, error) {
 		}
 	}
 
-	return schemaData{encCols, encodedIndexes}, nil
+	var encodedChecks []encodedCheck
+	for _, check := range sch.Checks().AllChecks() {
+		encodedChecks = append(encodedChecks, encodedCheck{
+			Name:       check.Name,
+			Expression: check.Expression,
+		})
+	}
+
+	return schemaData{encCols, encodedIndexes, encodedChecks}, nil
 }
 
 func (sd schemaData) decodeSchema() (schema.Schema, error) {
@@ -219,6 +233,13 @@ func (sd schemaData) decodeSchema() (schema.Schema, error) {
 		}
 	}
 
+	for _, encodedCheck := range sd.CheckCollection {
+		_, err = sch.Checks().AddCheck(encodedCheck.Name, encodedCheck.Expression)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return sch, nil
 }
 
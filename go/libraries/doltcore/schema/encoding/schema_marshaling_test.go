@@ -45,6 +45,7 @@ func createTestSchema() schema.Schema {
 	colColl, _ := schema.NewColCollection(columns...)
 	sch := schema.MustSchemaFromCols(colColl)
 	_, _ = sch.Indexes().AddIndexByColTags("idx_age", []uint64{3}, schema.IndexProperties{IsUnique: false, Comment: ""})
+	_, _ = sch.Checks().AddCheck("chk_age", "age < 150")
 	return sch
 }
 
@@ -223,9 +224,15 @@ type testEncodedIndex struct {
 	Hidden  bool     `noms:"hidden,omitempty" json:"hidden,omitempty"`
 }
 
+type testEncodedCheck struct {
+	Name       string `noms:"name" json:"name"`
+	Expression string `noms:"expression" json:"expression"`
+}
+
 type testSchemaData struct {
 	Columns         []testEncodedColumn `noms:"columns" json:"columns"`
 	IndexCollection []testEncodedIndex  `noms:"idxColl,omitempty" json:"idxColl,omitempty"`
+	CheckCollection []testEncodedCheck  `noms:"checkColl,omitempty" json:"checkColl,omitempty"`
 }
 
 func (tec testEncodedColumn) decodeColumn() (schema.Column, error) {
@@ -275,5 +282,12 @@ func (tsd testSchemaData) decodeSchema() (schema.Schema, error) {
 		}
 	}
 
+	for _, encodedCheck := range tsd.CheckCollection {
+		_, err = sch.Checks().AddCheck(encodedCheck.Name, encodedCheck.Expression)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return sch, nil
 }
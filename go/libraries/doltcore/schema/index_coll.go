@@ -139,6 +139,11 @@ func (ixc *indexCollectionImpl) AddIndexByColTags(indexName string, tags []uint6
 	if ixc.hasIndexOnTags(tags...) {
 		return nil, fmt.Errorf("cannot create a duplicate index on this table")
 	}
+	for _, tag := range tags {
+		if err := validateKeyColumn(ixc.colColl.TagToCol[tag]); err != nil {
+			return nil, err
+		}
+	}
 	index := &indexImpl{
 		indexColl:     ixc,
 		name:          indexName,
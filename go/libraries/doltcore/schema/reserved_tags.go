@@ -82,3 +82,27 @@ const (
 	KeylessRowIdTag = iota + SystemTableReservedMin + uint64(5000)
 	KeylessRowCardinalityTag
 )
+
+// Tags for dolt_merge_strategies table
+const (
+	MergeStrategiesTableTag = iota + SystemTableReservedMin + uint64(6000)
+	MergeStrategiesColumnTag
+	MergeStrategiesStrategyTag
+	MergeStrategiesExpressionTag
+)
+
+// Tags for dolt_branch_protection table
+const (
+	BranchProtectionBranchTag = iota + SystemTableReservedMin + uint64(7000)
+	BranchProtectionMergeOnlyTag
+	BranchProtectionNoForcePushTag
+	BranchProtectionVerifyConstraintsTag
+	BranchProtectionMessagePatternTag
+)
+
+// Tags for dolt_hooks table
+const (
+	HooksNameTag = iota + SystemTableReservedMin + uint64(8000)
+	HooksEventTag
+	HooksQueryTag
+)
@@ -27,6 +27,9 @@ type Schema interface {
 
 	// Indexes returns a collection of all indexes on the table that this schema belongs to.
 	Indexes() IndexCollection
+
+	// Checks returns a collection of all CHECK constraints on the table that this schema belongs to.
+	Checks() CheckCollection
 }
 
 // ColFromTag returns a schema.Column from a schema and a tag
@@ -71,7 +74,7 @@ func SchemasAreEqual(sch1, sch2 Schema) (bool, error) {
 	if !colCollIsEqual {
 		return false, nil
 	}
-	return sch1.Indexes().Equals(sch2.Indexes()), nil
+	return sch1.Indexes().Equals(sch2.Indexes()) && sch1.Checks().Equals(sch2.Checks()), nil
 }
 
 // TODO: this function never returns an error
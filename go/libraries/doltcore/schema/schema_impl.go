@@ -27,11 +27,13 @@ var EmptySchema = &schemaImpl{
 	nonPKCols:       EmptyColColl,
 	allCols:         EmptyColColl,
 	indexCollection: NewIndexCollection(nil),
+	checkCollection: NewCheckCollection(),
 }
 
 type schemaImpl struct {
 	pkCols, nonPKCols, allCols *ColCollection
 	indexCollection            IndexCollection
+	checkCollection            CheckCollection
 }
 
 // SchemaFromCols creates a Schema from a collection of columns
@@ -59,6 +61,7 @@ func SchemaFromCols(allCols *ColCollection) (Schema, error) {
 		nonPKCols:       nonPKColColl,
 		allCols:         allCols,
 		indexCollection: NewIndexCollection(allCols),
+		checkCollection: NewCheckCollection(),
 	}, nil
 }
 
@@ -98,6 +101,12 @@ func ValidateForInsert(allCols *ColCollection) error {
 		}
 		colNames[col.Name] = true
 
+		if col.IsPartOfPK {
+			if err := validateKeyColumn(col); err != nil {
+				return true, err
+			}
+		}
+
 		return false, nil
 	})
 
@@ -123,6 +132,7 @@ func UnkeyedSchemaFromCols(allCols *ColCollection) Schema {
 		nonPKCols:       nonPKColColl,
 		allCols:         nonPKColColl,
 		indexCollection: NewIndexCollection(nil),
+		checkCollection: NewCheckCollection(),
 	}
 }
 
@@ -160,6 +170,7 @@ func SchemaFromPKAndNonPKCols(pkCols, nonPKCols *ColCollection) (Schema, error)
 		nonPKCols:       nonPKCols,
 		allCols:         allColColl,
 		indexCollection: NewIndexCollection(allColColl),
+		checkCollection: NewCheckCollection(),
 	}, nil
 }
 
@@ -211,3 +222,7 @@ func (si *schemaImpl) String() string {
 func (si *schemaImpl) Indexes() IndexCollection {
 	return si.indexCollection
 }
+
+func (si *schemaImpl) Checks() CheckCollection {
+	return si.checkCollection
+}
@@ -124,6 +124,20 @@ func TestValidateForInsert(t *testing.T) {
 		assert.Error(t, err)
 		assert.Equal(t, err, ErrColNameCollision)
 	})
+
+	t.Run("Blob primary key", func(t *testing.T) {
+		cols := append(allCols, Column{"blob", 100, types.BlobKind, true, typeinfo.FromKind(types.BlobKind), "", false, "", nil})
+		colColl, err := NewColCollection(cols...)
+		require.NoError(t, err)
+
+		err = ValidateForInsert(colColl)
+		assert.Error(t, err)
+
+		sch, err := SchemaFromCols(colColl)
+		require.NoError(t, err)
+		_, err = sch.Indexes().AddIndexByColNames("idx_blob", []string{"blob"}, IndexProperties{})
+		assert.Error(t, err)
+	})
 }
 
 func testSchema(method string, sch Schema, t *testing.T) {
@@ -18,6 +18,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
 	"github.com/dolthub/dolt/go/libraries/utils/set"
 )
 
@@ -62,13 +63,25 @@ func (ss *SuperSchema) AddColumn(col Column) (err error) {
 	ac := ss.allCols
 	existingCol, found := ac.GetByTag(ct)
 	if found {
+		// a column may be widened to a larger type of the same kind, in which case the SuperSchema
+		// keeps the widest type seen for the column
+		widerTi, isWider := typeinfo.WiderType(existingCol.TypeInfo, col.TypeInfo)
 		if col.IsPartOfPK != existingCol.IsPartOfPK ||
 			col.Kind != existingCol.Kind ||
-			!col.TypeInfo.Equals(existingCol.TypeInfo) {
+			!isWider {
 			ecName := ss.tagNames[col.Tag][0]
 			return fmt.Errorf("tag collision for columns %s and %s, different definitions (tag: %d)",
 				ecName, col.Name, col.Tag)
 		}
+
+		if !widerTi.Equals(existingCol.TypeInfo) {
+			widened := existingCol
+			widened.TypeInfo = widerTi
+			ss.allCols, err = ss.allCols.Replace(existingCol, widened)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	names, found := ss.tagNames[col.Tag]
@@ -57,6 +57,16 @@ var tagCollisionWithSch1 = mustSchema([]Column{
 	{"collision", 2, types.IntKind, false, typeinfo.Int32Type, "", false, "", nil},
 })
 
+var widenedTypeOfSch1 = mustSchema([]Column{
+	strCol("a", 1, true),
+	{"b", 2, types.IntKind, false, typeinfo.Int64Type, "", false, "", nil},
+})
+
+var narrowTypeOfSch1 = mustSchema([]Column{
+	strCol("a", 1, true),
+	{"b", 2, types.IntKind, false, typeinfo.Int16Type, "", false, "", nil},
+})
+
 type SuperSchemaTest struct {
 	// Name of the test
 	Name string
@@ -144,6 +154,21 @@ var SuperSchemaTests = []SuperSchemaTest{
 			strCol("b_22", 22, false),
 		}),
 	},
+	{
+		Name:    "SuperSchema keeps the widest type of a column",
+		Schemas: []Schema{narrowTypeOfSch1, widenedTypeOfSch1, narrowTypeOfSch1},
+		ExpectedSuperSchema: SuperSchema{
+			allCols: mustColColl([]Column{
+				strCol("", 1, true),
+				{"", 2, types.IntKind, false, typeinfo.Int64Type, "", false, "", nil},
+			}),
+			tagNames: map[uint64][]string{1: {"a"}, 2: {"b"}},
+		},
+		ExpectedGeneratedSchema: mustSchema([]Column{
+			strCol("a", 1, true),
+			{"b", 2, types.IntKind, false, typeinfo.Int64Type, "", false, "", nil},
+		}),
+	},
 	{
 		Name:              "SuperSchema errors on tag collision",
 		Schemas:           []Schema{sch1, tagCollisionWithSch1},
@@ -0,0 +1,95 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typeinfo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestBlobBinaryConvertValueToNomsValue(t *testing.T) {
+	tests := []struct {
+		typ         *blobBinaryType
+		input       interface{}
+		output      string
+		expectedErr bool
+	}{
+		{
+			&blobBinaryType{sql.TinyBlob},
+			"  This is a sentence.  ",
+			"  This is a sentence.  ",
+			false,
+		},
+		{
+			&blobBinaryType{sql.Blob},
+			[]byte{1, 32, 235, 64},
+			string([]byte{1, 32, 235, 64}),
+			false,
+		},
+		{
+			&blobBinaryType{sql.LongBlob},
+			int64(28354),
+			"28354",
+			false,
+		},
+		{
+			&blobBinaryType{sql.TinyBlob},
+			strings.Repeat("a", 256),
+			"",
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf(`%v %v`, test.typ.String(), test.input), func(t *testing.T) {
+			val, err := test.typ.ConvertValueToNomsValue(context.Background(), testVRW, test.input)
+			if test.expectedErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, types.BlobKind, val.Kind())
+				output, err := test.typ.ConvertNomsValueToValue(val)
+				require.NoError(t, err)
+				require.Equal(t, test.output, output)
+			}
+		})
+	}
+}
+
+func TestBlobBinaryFromSqlType(t *testing.T) {
+	ti, err := FromSqlType(sql.MediumBlob)
+	require.NoError(t, err)
+	assert.True(t, ti.Equals(&blobBinaryType{sql.MediumBlob}))
+	assert.Equal(t, BlobBinaryTypeIdentifier, ti.GetTypeIdentifier())
+
+	// varbinary type infos keep storing their values as strings
+	varBinTi, err := CreateVarBinaryTypeFromParams(map[string]string{
+		varBinaryTypeParam_Length: "65535",
+		varBinaryTypeParam_SQL:    varBinaryTypeParam_SQL_Blob,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, types.StringKind, varBinTi.NomsKind())
+	output, err := varBinTi.ConvertNomsValueToValue(types.String("abc"))
+	require.NoError(t, err)
+	assert.Equal(t, "abc", output)
+}
@@ -0,0 +1,117 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typeinfo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestBlobStringConvertValueToNomsValue(t *testing.T) {
+	tests := []struct {
+		typ         *blobStringType
+		input       interface{}
+		output      string
+		expectedErr bool
+	}{
+		{
+			&blobStringType{sql.CreateTinyText(sql.Collation_Default)},
+			"  This is a sentence.  ",
+			"  This is a sentence.  ",
+			false,
+		},
+		{
+			&blobStringType{sql.CreateText(sql.Collation_Default)},
+			[]byte("some bytes"),
+			"some bytes",
+			false,
+		},
+		{
+			&blobStringType{sql.CreateLongText(sql.Collation_Default)},
+			int64(28354),
+			"28354",
+			false,
+		},
+		{
+			&blobStringType{sql.CreateTinyText(sql.Collation_Default)},
+			strings.Repeat("a", 256),
+			"",
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf(`%v %v`, test.typ.String(), test.input), func(t *testing.T) {
+			val, err := test.typ.ConvertValueToNomsValue(context.Background(), testVRW, test.input)
+			if test.expectedErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, types.BlobKind, val.Kind())
+				output, err := test.typ.ConvertNomsValueToValue(val)
+				require.NoError(t, err)
+				require.Equal(t, test.output, output)
+			}
+		})
+	}
+}
+
+func TestBlobStringLargeValues(t *testing.T) {
+	ctx := context.Background()
+	typ := &blobStringType{sql.CreateLongText(sql.Collation_Default)}
+
+	text := strings.Repeat("the quick brown fox jumps over the lazy dog. ", 100000)
+	val, err := typ.ConvertValueToNomsValue(ctx, testVRW, text)
+	require.NoError(t, err)
+	output, err := typ.ConvertNomsValueToValue(val)
+	require.NoError(t, err)
+	assert.Equal(t, text, output)
+
+	// editing a large value only changes a small range of its bytes
+	edited := text[:len(text)/2] + "!" + text[len(text)/2:]
+	editedVal, err := typ.ConvertValueToNomsValue(ctx, testVRW, edited)
+	require.NoError(t, err)
+
+	changes := make(chan types.Splice, 16)
+	require.NoError(t, editedVal.(types.Blob).Diff(ctx, val.(types.Blob), changes))
+	close(changes)
+	var added, removed uint64
+	for splice := range changes {
+		added += splice.SpAdded
+		removed += splice.SpRemoved
+	}
+	assert.Equal(t, uint64(1), added)
+	assert.Equal(t, uint64(0), removed)
+}
+
+func TestBlobStringKeyType(t *testing.T) {
+	typ := &blobStringType{sql.CreateText(sql.Collation_Default)}
+	keyTyp := KeyType(typ)
+	assert.Equal(t, types.StringKind, keyTyp.NomsKind())
+	assert.Equal(t, typ.ToSqlType(), keyTyp.ToSqlType())
+	assert.True(t, Int64Type.Equals(KeyType(Int64Type)))
+
+	ti, err := FromSqlType(sql.CreateMediumText(sql.Collation_Default))
+	require.NoError(t, err)
+	assert.True(t, ti.Equals(&blobStringType{sql.CreateMediumText(sql.Collation_Default)}))
+}
@@ -15,8 +15,10 @@
 package typeinfo
 
 import (
+	"context"
 	"math"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -24,8 +26,12 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/dolthub/dolt/go/libraries/utils/mathutil"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/types"
 )
 
+var testVRW = types.NewValueStore((&chunks.MemoryStorage{}).NewView())
+
 func generateBitTypes(t *testing.T, numOfTypes uint16) []TypeInfo {
 	var res []TypeInfo
 	loop(t, 1, 64, numOfTypes, func(i int64) {
@@ -101,6 +107,18 @@ func generateSetTypes(t *testing.T, numOfTypes int64) []TypeInfo {
 	return res
 }
 
+func generateBlobValue(t *testing.T, data string) types.Value {
+	val, err := types.NewBlob(context.Background(), testVRW, strings.NewReader(data))
+	require.NoError(t, err)
+	return val
+}
+
+func generateJSONValue(t *testing.T, text string) types.Value {
+	val, err := JSONType.ParseValue(context.Background(), testVRW, &text)
+	require.NoError(t, err)
+	return val
+}
+
 func generateSetType(t *testing.T, numOfElements int) *setType {
 	require.True(t, numOfElements >= 1 && numOfElements <= sql.SetTypeMaxElements)
 	vals := make([]string, numOfElements)
@@ -158,6 +176,16 @@ func generateVarStringType(t *testing.T, length int64, rts bool) *varStringType
 	return &varStringType{sql.MustCreateStringWithDefaults(sqltypes.VarChar, length)}
 }
 
+// humanReadableString returns a readable form of the given value, including the values of blobs and structs.
+func humanReadableString(t *testing.T, val types.Value) string {
+	if val.Kind() != types.StructKind && val.Kind() != types.BlobKind {
+		return val.HumanReadableString()
+	}
+	str, err := types.EncodedValue(context.Background(), val)
+	require.NoError(t, err)
+	return str
+}
+
 func loop(t *testing.T, start int64, endInclusive int64, numOfSteps uint16, loopedFunc func(int64)) {
 	require.True(t, endInclusive > start)
 	maxNumOfSteps := endInclusive - start + 1
@@ -0,0 +1,384 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typeinfo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/sqltypes"
+
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+const (
+	jsonStructName = "JSON"
+	jsonDocField   = "doc"
+)
+
+// This is a dolt implementation of the MySQL type JSON. Documents are stored as a Noms struct named JSON, whose doc
+// field holds the document: objects are stored as maps from strings to values, arrays as lists, numbers as ints when
+// they are integral and as floats otherwise, and strings, booleans and nulls as their Noms counterparts. Storing the
+// structure of documents, rather than their text, allows them to be diffed and merged key by key.
+type jsonType struct{}
+
+var _ TypeInfo = (*jsonType)(nil)
+
+var JSONType = &jsonType{}
+
+// ConvertNomsValueToValue implements TypeInfo interface.
+func (ti *jsonType) ConvertNomsValueToValue(v types.Value) (interface{}, error) {
+	if val, ok := v.(types.Struct); ok {
+		doc, err := JSONDocFromNomsValue(val)
+		if err != nil {
+			return nil, err
+		}
+		goDoc, err := jsonDocToGo(context.Background(), doc)
+		if err != nil {
+			return nil, err
+		}
+		return marshalJSON(goDoc)
+	}
+	if _, ok := v.(types.Null); ok || v == nil {
+		return nil, nil
+	}
+	return nil, fmt.Errorf(`"%v" cannot convert NomsKind "%v" to a value`, ti.String(), v.Kind())
+}
+
+// ConvertValueToNomsValue implements TypeInfo interface.
+func (ti *jsonType) ConvertValueToNomsValue(ctx context.Context, vrw types.ValueReadWriter, v interface{}) (types.Value, error) {
+	switch val := v.(type) {
+	case nil:
+		return types.NullValue, nil
+	case types.Null:
+		return types.NullValue, nil
+	case types.Struct:
+		if !ti.IsValid(val) {
+			return nil, fmt.Errorf(`"%v" cannot convert the struct "%v" as it is not a JSON document`, ti.String(), val.Name())
+		}
+		return val, nil
+	}
+
+	text, err := jsonSqlType.Convert(v)
+	if err != nil {
+		return nil, err
+	}
+	return ti.parseJSON(ctx, vrw, text.([]byte))
+}
+
+// Equals implements TypeInfo interface.
+func (ti *jsonType) Equals(other TypeInfo) bool {
+	if other == nil {
+		return false
+	}
+	_, ok := other.(*jsonType)
+	return ok
+}
+
+// FormatValue implements TypeInfo interface.
+func (ti *jsonType) FormatValue(v types.Value) (*string, error) {
+	if _, ok := v.(types.Null); ok || v == nil {
+		return nil, nil
+	}
+	val, err := ti.ConvertNomsValueToValue(v)
+	if err != nil {
+		return nil, err
+	}
+	str := string(val.([]byte))
+	return &str, nil
+}
+
+// GetTypeIdentifier implements TypeInfo interface.
+func (ti *jsonType) GetTypeIdentifier() Identifier {
+	return JSONTypeIdentifier
+}
+
+// GetTypeParams implements TypeInfo interface.
+func (ti *jsonType) GetTypeParams() map[string]string {
+	return nil
+}
+
+// IsValid implements TypeInfo interface.
+func (ti *jsonType) IsValid(v types.Value) bool {
+	if val, ok := v.(types.Struct); ok {
+		_, err := JSONDocFromNomsValue(val)
+		return err == nil
+	}
+	if _, ok := v.(types.Null); ok || v == nil {
+		return true
+	}
+	return false
+}
+
+// NomsKind implements TypeInfo interface.
+func (ti *jsonType) NomsKind() types.NomsKind {
+	return types.StructKind
+}
+
+// ParseValue implements TypeInfo interface.
+func (ti *jsonType) ParseValue(ctx context.Context, vrw types.ValueReadWriter, str *string) (types.Value, error) {
+	if str == nil {
+		return types.NullValue, nil
+	}
+	text, err := jsonSqlType.Convert(*str)
+	if err != nil {
+		return nil, err
+	}
+	return ti.parseJSON(ctx, vrw, text.([]byte))
+}
+
+// Promote implements TypeInfo interface.
+func (ti *jsonType) Promote() TypeInfo {
+	return ti
+}
+
+// String implements TypeInfo interface.
+func (ti *jsonType) String() string {
+	return "JSON"
+}
+
+// ToSqlType implements TypeInfo interface.
+func (ti *jsonType) ToSqlType() sql.Type {
+	return jsonSqlType
+}
+
+// parseJSON converts the valid JSON text given into a Noms value. Objects and arrays are written as Noms collections
+// using the given ValueReadWriter.
+func (ti *jsonType) parseJSON(ctx context.Context, vrw types.ValueReadWriter, text []byte) (types.Value, error) {
+	goDoc, err := unmarshalJSON(text)
+	if err != nil {
+		return nil, err
+	}
+	if vrw == nil {
+		return nil, fmt.Errorf(`"%v" cannot write a document without a value store`, ti.String())
+	}
+	doc, err := jsonDocFromGo(ctx, vrw, goDoc)
+	if err != nil {
+		return nil, err
+	}
+	return NewJSONNomsValue(vrw.Format(), doc)
+}
+
+// NewJSONNomsValue wraps the Noms encoding of a JSON document into the value stored in a JSON column.
+func NewJSONNomsValue(nbf *types.NomsBinFormat, doc types.Value) (types.Struct, error) {
+	return types.NewStruct(nbf, jsonStructName, types.StructData{jsonDocField: doc})
+}
+
+// JSONDocFromNomsValue returns the Noms encoding of the JSON document stored in a JSON column.
+func JSONDocFromNomsValue(v types.Struct) (types.Value, error) {
+	if v.Name() != jsonStructName {
+		return nil, fmt.Errorf(`the struct "%v" is not a JSON document`, v.Name())
+	}
+	doc, ok, err := v.MaybeGet(jsonDocField)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf(`the JSON document is missing the field "%v"`, jsonDocField)
+	}
+	return doc, nil
+}
+
+func jsonDocFromGo(ctx context.Context, vrw types.ValueReadWriter, v interface{}) (types.Value, error) {
+	switch val := v.(type) {
+	case nil:
+		return types.NullValue, nil
+	case bool:
+		return types.Bool(val), nil
+	case string:
+		return types.String(val), nil
+	case json.Number:
+		if i, err := val.Int64(); err == nil {
+			return types.Int(i), nil
+		}
+		f, err := val.Float64()
+		if err != nil {
+			return nil, err
+		}
+		return types.Float(f), nil
+	case []interface{}:
+		elems := make([]types.Value, len(val))
+		for i, elem := range val {
+			var err error
+			elems[i], err = jsonDocFromGo(ctx, vrw, elem)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return types.NewList(ctx, vrw, elems...)
+	case map[string]interface{}:
+		kvs := make([]types.Value, 0, 2*len(val))
+		for k, elem := range val {
+			nomsElem, err := jsonDocFromGo(ctx, vrw, elem)
+			if err != nil {
+				return nil, err
+			}
+			kvs = append(kvs, types.String(k), nomsElem)
+		}
+		return types.NewMap(ctx, vrw, kvs...)
+	default:
+		return nil, fmt.Errorf(`unexpected JSON value of type "%T"`, v)
+	}
+}
+
+func jsonDocToGo(ctx context.Context, v types.Value) (interface{}, error) {
+	switch val := v.(type) {
+	case types.Null:
+		return nil, nil
+	case types.Bool:
+		return bool(val), nil
+	case types.String:
+		return string(val), nil
+	case types.Int:
+		return int64(val), nil
+	case types.Float:
+		return float64(val), nil
+	case types.List:
+		elems := make([]interface{}, 0, val.Len())
+		err := val.IterAll(ctx, func(elem types.Value, _ uint64) error {
+			goElem, err := jsonDocToGo(ctx, elem)
+			if err != nil {
+				return err
+			}
+			elems = append(elems, goElem)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return elems, nil
+	case types.Map:
+		obj := make(map[string]interface{}, val.Len())
+		err := val.IterAll(ctx, func(k, elem types.Value) error {
+			key, ok := k.(types.String)
+			if !ok {
+				return fmt.Errorf(`unexpected JSON object key of kind "%v"`, k.Kind())
+			}
+			goElem, err := jsonDocToGo(ctx, elem)
+			if err != nil {
+				return err
+			}
+			obj[string(key)] = goElem
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return obj, nil
+	default:
+		return nil, fmt.Errorf(`unexpected JSON value of kind "%v"`, v.Kind())
+	}
+}
+
+// unmarshalJSON decodes the given JSON text, keeping numbers as json.Number so that integers aren't rounded.
+func unmarshalJSON(text []byte) (interface{}, error) {
+	if !json.Valid(text) {
+		var goDoc interface{}
+		err := json.Unmarshal(text, &goDoc)
+		return nil, fmt.Errorf("invalid JSON text: %v", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(text))
+	dec.UseNumber()
+	var goDoc interface{}
+	if err := dec.Decode(&goDoc); err != nil {
+		return nil, err
+	}
+	return goDoc, nil
+}
+
+// marshalJSON returns the canonical text of a JSON document, which has no insignificant whitespace and has the keys
+// of each object in sorted order.
+func marshalJSON(v interface{}) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte{'\n'}), nil
+}
+
+// jsonSqlType is the JSON type of go-mysql-server, except that strings must be valid JSON text rather than being
+// converted into JSON strings, and that values are compared by their canonical text.
+var jsonSqlType = validatingJSONType{sql.JSON}
+
+type validatingJSONType struct {
+	sql.JsonType
+}
+
+// Compare implements sql.Type interface.
+func (t validatingJSONType) Compare(a interface{}, b interface{}) (int, error) {
+	if a == nil || b == nil {
+		return t.JsonType.Compare(a, b)
+	}
+	aText, err := t.Convert(a)
+	if err != nil {
+		return 0, err
+	}
+	bText, err := t.Convert(b)
+	if err != nil {
+		return 0, err
+	}
+	return bytes.Compare(aText.([]byte), bText.([]byte)), nil
+}
+
+// Convert implements sql.Type interface.
+func (t validatingJSONType) Convert(v interface{}) (interface{}, error) {
+	var goDoc interface{}
+	var err error
+	switch val := v.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		goDoc, err = unmarshalJSON([]byte(val))
+	case []byte:
+		goDoc, err = unmarshalJSON(val)
+	default:
+		goDoc = v
+	}
+	if err != nil {
+		return nil, err
+	}
+	return marshalJSON(goDoc)
+}
+
+// MustConvert implements sql.Type interface.
+func (t validatingJSONType) MustConvert(v interface{}) interface{} {
+	value, err := t.Convert(v)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// Promote implements sql.Type interface.
+func (t validatingJSONType) Promote() sql.Type {
+	return t
+}
+
+// SQL implements sql.Type interface.
+func (t validatingJSONType) SQL(v interface{}) (sqltypes.Value, error) {
+	if v == nil {
+		return sqltypes.NULL, nil
+	}
+	text, err := t.Convert(v)
+	if err != nil {
+		return sqltypes.Value{}, err
+	}
+	return sqltypes.MakeTrusted(sqltypes.TypeJSON, text.([]byte)), nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typeinfo
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestJSONConvertValueToNomsValue(t *testing.T) {
+	tests := []struct {
+		input       interface{}
+		output      string
+		expectedErr bool
+	}{
+		{
+			`{"b": 2, "a": [1, 2.5, "x"]}`,
+			`{"a":[1,2.5,"x"],"b":2}`,
+			false,
+		},
+		{
+			[]byte(` [true, false, null] `),
+			`[true,false,null]`,
+			false,
+		},
+		{
+			`"<tag> & more"`,
+			`"<tag> & more"`,
+			false,
+		},
+		{
+			`9007199254740993`,
+			`9007199254740993`,
+			false,
+		},
+		{
+			map[string]interface{}{"k": []interface{}{"v", 1.5}},
+			`{"k":["v",1.5]}`,
+			false,
+		},
+		{
+			`{"a": 1`,
+			``,
+			true,
+		},
+		{
+			`not json`,
+			``,
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf(`%v`, test.input), func(t *testing.T) {
+			val, err := JSONType.ConvertValueToNomsValue(context.Background(), testVRW, test.input)
+			if test.expectedErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, types.StructKind, val.Kind())
+				output, err := JSONType.ConvertNomsValueToValue(val)
+				require.NoError(t, err)
+				require.Equal(t, test.output, string(output.([]byte)))
+			}
+		})
+	}
+}
+
+func TestJSONDocumentStructure(t *testing.T) {
+	ctx := context.Background()
+	val := generateJSONValue(t, `{"a": {"b": [1, 2.5]}, "c": null}`)
+
+	doc, err := JSONDocFromNomsValue(val.(types.Struct))
+	require.NoError(t, err)
+	obj, ok := doc.(types.Map)
+	require.True(t, ok)
+	require.Equal(t, uint64(2), obj.Len())
+
+	inner, ok, err := obj.MaybeGet(ctx, types.String("a"))
+	require.NoError(t, err)
+	require.True(t, ok)
+	arr, ok, err := inner.(types.Map).MaybeGet(ctx, types.String("b"))
+	require.NoError(t, err)
+	require.True(t, ok)
+	expectedArr, err := types.NewList(ctx, testVRW, types.Int(1), types.Float(2.5))
+	require.NoError(t, err)
+	assert.True(t, expectedArr.Equals(arr))
+
+	null, ok, err := obj.MaybeGet(ctx, types.String("c"))
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, types.NullValue, null)
+
+	// the same document has the same value regardless of how its text is formatted
+	assert.True(t, val.Equals(generateJSONValue(t, `{"c":null,"a":{"b":[1,2.5]}}`)))
+}
+
+func TestJSONSqlType(t *testing.T) {
+	sqlType := JSONType.ToSqlType()
+
+	converted, err := sqlType.Convert(`{"b":1, "a":2}`)
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":2,"b":1}`, string(converted.([]byte)))
+
+	_, err = sqlType.Convert(`{"b":`)
+	assert.Error(t, err)
+
+	cmp, err := sqlType.Compare(`{"a": 1, "b": 2}`, []byte(`{"b":2,"a":1}`))
+	require.NoError(t, err)
+	assert.Equal(t, 0, cmp)
+
+	ti, err := FromSqlType(sqlType)
+	require.NoError(t, err)
+	assert.True(t, JSONType.Equals(ti))
+}
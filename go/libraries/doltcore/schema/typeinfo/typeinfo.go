@@ -15,10 +15,12 @@
 package typeinfo
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/dolthub/vitess/go/vt/proto/query"
 
 	"github.com/dolthub/dolt/go/store/types"
 )
@@ -28,6 +30,8 @@ type Identifier string
 const (
 	UnknownTypeIdentifier    Identifier = "unknown"
 	BitTypeIdentifier        Identifier = "bit"
+	BlobBinaryTypeIdentifier Identifier = "blobbinary"
+	BlobStringTypeIdentifier Identifier = "blobstring"
 	BoolTypeIdentifier       Identifier = "bool"
 	DatetimeTypeIdentifier   Identifier = "datetime"
 	DecimalTypeIdentifier    Identifier = "decimal"
@@ -35,6 +39,7 @@ const (
 	FloatTypeIdentifier      Identifier = "float"
 	InlineBlobTypeIdentifier Identifier = "inlineblob"
 	IntTypeIdentifier        Identifier = "int"
+	JSONTypeIdentifier       Identifier = "json"
 	SetTypeIdentifier        Identifier = "set"
 	TimeTypeIdentifier       Identifier = "time"
 	TupleTypeIdentifier      Identifier = "tuple"
@@ -48,6 +53,8 @@ const (
 var Identifiers = map[Identifier]struct{}{
 	UnknownTypeIdentifier:    {},
 	BitTypeIdentifier:        {},
+	BlobBinaryTypeIdentifier: {},
+	BlobStringTypeIdentifier: {},
 	BoolTypeIdentifier:       {},
 	DatetimeTypeIdentifier:   {},
 	DecimalTypeIdentifier:    {},
@@ -55,6 +62,7 @@ var Identifiers = map[Identifier]struct{}{
 	FloatTypeIdentifier:      {},
 	InlineBlobTypeIdentifier: {},
 	IntTypeIdentifier:        {},
+	JSONTypeIdentifier:       {},
 	SetTypeIdentifier:        {},
 	TimeTypeIdentifier:       {},
 	TupleTypeIdentifier:      {},
@@ -74,8 +82,9 @@ type TypeInfo interface {
 	ConvertNomsValueToValue(v types.Value) (interface{}, error)
 
 	// ConvertValueToNomsValue converts a go value or Noms value to a Noms value. The type of the Noms
-	// value will be equivalent to the NomsKind returned from NomsKind.
-	ConvertValueToNomsValue(v interface{}) (types.Value, error)
+	// value will be equivalent to the NomsKind returned from NomsKind. Types whose Noms values are made of
+	// collections write them using the given ValueReadWriter.
+	ConvertValueToNomsValue(ctx context.Context, vrw types.ValueReadWriter, v interface{}) (types.Value, error)
 
 	// Equals returns whether the given TypeInfo is equivalent to this TypeInfo.
 	Equals(other TypeInfo) bool
@@ -97,7 +106,7 @@ type TypeInfo interface {
 	NomsKind() types.NomsKind
 
 	// ParseValue parses a string and returns a go value that represents it according to this type.
-	ParseValue(str *string) (types.Value, error)
+	ParseValue(ctx context.Context, vrw types.ValueReadWriter, str *string) (types.Value, error)
 
 	// Promote will promote the current TypeInfo to the largest representing TypeInfo of the same kind, such as Int8 to Int64.
 	Promote() TypeInfo
@@ -160,17 +169,13 @@ func FromSqlType(sqlType sql.Type) (TypeInfo, error) {
 		if !ok {
 			return nil, fmt.Errorf(`expected "StringType" from SQL basetype "Text"`)
 		}
-		return &varStringType{stringType}, nil
+		return &blobStringType{stringType}, nil
 	case sqltypes.Blob:
-		//TODO: determine the storage format
-		if fmt.Sprintf("a") != "" { // always evaluates to true, compiler won't complain about unreachable code
-			return nil, fmt.Errorf(`"%v" has not yet been implemented`, sqlType.String())
-		}
 		stringType, ok := sqlType.(sql.StringType)
 		if !ok {
 			return nil, fmt.Errorf(`expected "StringType" from SQL basetype "Blob"`)
 		}
-		return &varBinaryType{stringType}, nil
+		return &blobBinaryType{stringType}, nil
 	case sqltypes.VarChar:
 		stringType, ok := sqlType.(sql.StringType)
 		if !ok {
@@ -221,6 +226,8 @@ func FromSqlType(sqlType sql.Type) (TypeInfo, error) {
 			return nil, fmt.Errorf(`expected "SetTypeIdentifier" from SQL basetype "Set"`)
 		}
 		return &setType{setSQLType}, nil
+	case sqltypes.TypeJSON:
+		return JSONType, nil
 	default:
 		return nil, fmt.Errorf(`no type info can be created from SQL base type "%v"`, sqlType.String())
 	}
@@ -231,6 +238,10 @@ func FromTypeParams(id Identifier, params map[string]string) (TypeInfo, error) {
 	switch id {
 	case BitTypeIdentifier:
 		return CreateBitTypeFromParams(params)
+	case BlobBinaryTypeIdentifier:
+		return CreateBlobBinaryTypeFromParams(params)
+	case BlobStringTypeIdentifier:
+		return CreateBlobStringTypeFromParams(params)
 	case BoolTypeIdentifier:
 		return BoolType, nil
 	case DatetimeTypeIdentifier:
@@ -245,6 +256,8 @@ func FromTypeParams(id Identifier, params map[string]string) (TypeInfo, error) {
 		return InlineBlobType, nil
 	case IntTypeIdentifier:
 		return CreateIntTypeFromParams(params)
+	case JSONTypeIdentifier:
+		return JSONType, nil
 	case SetTypeIdentifier:
 		return CreateSetTypeFromParams(params)
 	case TimeTypeIdentifier:
@@ -269,6 +282,8 @@ func FromTypeParams(id Identifier, params map[string]string) (TypeInfo, error) {
 // FromKind returns the default TypeInfo for a given types.Value.
 func FromKind(kind types.NomsKind) TypeInfo {
 	switch kind {
+	case types.BlobKind:
+		return &blobBinaryType{sql.LongBlob}
 	case types.BoolKind:
 		return BoolType
 	case types.FloatKind:
@@ -298,24 +313,108 @@ func FromKind(kind types.NomsKind) TypeInfo {
 
 // Convert takes in a types.Value, as well as the source and destination TypeInfos, and
 // converts the TypeInfo into the applicable types.Value.
-func Convert(v types.Value, srcTi TypeInfo, destTi TypeInfo) (types.Value, error) {
+func Convert(ctx context.Context, vrw types.ValueReadWriter, v types.Value, srcTi TypeInfo, destTi TypeInfo) (types.Value, error) {
 	str, err := srcTi.FormatValue(v)
 	if err != nil {
 		return nil, err
 	}
-	val, err := destTi.ParseValue(str)
+	val, err := destTi.ParseValue(ctx, vrw, str)
 	if err != nil {
 		return nil, err
 	}
 	return val, nil
 }
 
-// IsStringType returns whether the given TypeInfo represents a CHAR, VARCHAR, or TEXT-derivative.
+// IsStringType returns whether the given TypeInfo represents a CHAR, VARCHAR, or TEXT-derivative whose values are
+// stored as Noms strings.
 func IsStringType(ti TypeInfo) bool {
 	_, ok := ti.(*varStringType)
 	return ok
 }
 
+// KeyType returns the TypeInfo used to store the given type within a primary key or index. Keys are ordered by their
+// values, which blobs are not, so TEXT types are stored inline as strings when they are part of a key.
+func KeyType(ti TypeInfo) TypeInfo {
+	if blobTi, ok := ti.(*blobStringType); ok {
+		return &varStringType{blobTi.sqlStringType}
+	}
+	return ti
+}
+
+// numberTypeWidths orders the integer and float types by the range of values they can hold.
+var numberTypeWidths = map[query.Type]int{
+	sqltypes.Int8:    1,
+	sqltypes.Int16:   2,
+	sqltypes.Int24:   3,
+	sqltypes.Int32:   4,
+	sqltypes.Int64:   5,
+	sqltypes.Uint8:   1,
+	sqltypes.Uint16:  2,
+	sqltypes.Uint24:  3,
+	sqltypes.Uint32:  4,
+	sqltypes.Uint64:  5,
+	sqltypes.Float32: 1,
+	sqltypes.Float64: 2,
+}
+
+// IsWidening returns whether changing a column from the type |from| to the type |to| keeps every value that |from|
+// can hold, without changing how the value is stored. This holds for larger integer, unsigned integer and float
+// types, for longer strings and byte strings of the same SQL type and collation, and for decimals which gain
+// precision without losing integer digits or scale. A type is not a widening of itself.
+func IsWidening(from, to TypeInfo) bool {
+	if from.Equals(to) || from.NomsKind() != to.NomsKind() {
+		return false
+	}
+
+	switch fromTi := from.(type) {
+	case *intType:
+		toTi, ok := to.(*intType)
+		return ok && numberTypeWidths[fromTi.sqlIntType.Type()] < numberTypeWidths[toTi.sqlIntType.Type()]
+	case *uintType:
+		toTi, ok := to.(*uintType)
+		return ok && numberTypeWidths[fromTi.sqlUintType.Type()] < numberTypeWidths[toTi.sqlUintType.Type()]
+	case *floatType:
+		toTi, ok := to.(*floatType)
+		return ok && numberTypeWidths[fromTi.sqlFloatType.Type()] < numberTypeWidths[toTi.sqlFloatType.Type()]
+	case *varStringType:
+		toTi, ok := to.(*varStringType)
+		return ok && fromTi.sqlStringType.Type() == toTi.sqlStringType.Type() &&
+			fromTi.sqlStringType.Collation() == toTi.sqlStringType.Collation() &&
+			fromTi.sqlStringType.MaxCharacterLength() < toTi.sqlStringType.MaxCharacterLength()
+	case *blobBinaryType:
+		toTi, ok := to.(*blobBinaryType)
+		return ok && fromTi.sqlBinaryType.MaxCharacterLength() < toTi.sqlBinaryType.MaxCharacterLength()
+	case *blobStringType:
+		toTi, ok := to.(*blobStringType)
+		return ok && fromTi.sqlStringType.Collation() == toTi.sqlStringType.Collation() &&
+			fromTi.sqlStringType.MaxCharacterLength() < toTi.sqlStringType.MaxCharacterLength()
+	case *varBinaryType:
+		toTi, ok := to.(*varBinaryType)
+		return ok && fromTi.sqlBinaryType.Type() == toTi.sqlBinaryType.Type() &&
+			fromTi.sqlBinaryType.MaxCharacterLength() < toTi.sqlBinaryType.MaxCharacterLength()
+	case *decimalType:
+		toTi, ok := to.(*decimalType)
+		if !ok {
+			return false
+		}
+		fromPrecision, fromScale := int(fromTi.sqlDecimalType.Precision()), int(fromTi.sqlDecimalType.Scale())
+		toPrecision, toScale := int(toTi.sqlDecimalType.Precision()), int(toTi.sqlDecimalType.Scale())
+		return toScale >= fromScale && toPrecision-toScale >= fromPrecision-fromScale
+	}
+
+	return false
+}
+
+// WiderType returns whichever of the two types can hold every value of the other, and whether there is one.
+func WiderType(ti1, ti2 TypeInfo) (TypeInfo, bool) {
+	if ti1.Equals(ti2) || IsWidening(ti2, ti1) {
+		return ti1, true
+	} else if IsWidening(ti1, ti2) {
+		return ti2, true
+	}
+	return nil, false
+}
+
 // ParseIdentifier takes in an Identifier in string form and returns the matching Identifier.
 // Returns UnknownTypeIdentifier when the string match is not found.
 func ParseIdentifier(name string) Identifier {
@@ -15,6 +15,7 @@
 package typeinfo
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -110,12 +111,12 @@ func testTypeInfoConvertRoundTrip(t *testing.T, tiArrays [][]TypeInfo, vaArrays
 				atLeastOneValid := false
 				t.Run(ti.String(), func(t *testing.T) {
 					for _, val := range vaArrays[rowIndex] {
-						t.Run(fmt.Sprintf(`types.%v(%v)`, val.Kind().String(), val.HumanReadableString()), func(t *testing.T) {
+						t.Run(fmt.Sprintf(`types.%v(%v)`, val.Kind().String(), humanReadableString(t, val)), func(t *testing.T) {
 							vInterface, err := ti.ConvertNomsValueToValue(val)
 							if ti.IsValid(val) {
 								atLeastOneValid = true
 								require.NoError(t, err)
-								outVal, err := ti.ConvertValueToNomsValue(vInterface)
+								outVal, err := ti.ConvertValueToNomsValue(context.Background(), testVRW, vInterface)
 								require.NoError(t, err)
 								if ti == DateType { // Special case as DateType removes the hh:mm:ss
 									val = types.Timestamp(time.Time(val.(types.Timestamp)).Truncate(24 * time.Hour))
@@ -184,7 +185,7 @@ func testTypeInfoForeignKindHandling(t *testing.T, tiArrays [][]TypeInfo, vaArra
 				t.Run(ti.String(), func(t *testing.T) {
 					for _, vaArray := range vaArrays {
 						for _, val := range vaArray {
-							t.Run(fmt.Sprintf(`types.%v(%v)`, val.Kind().String(), val.HumanReadableString()), func(t *testing.T) {
+							t.Run(fmt.Sprintf(`types.%v(%v)`, val.Kind().String(), humanReadableString(t, val)), func(t *testing.T) {
 								if ti.NomsKind() != val.Kind() {
 									_, err := ti.ConvertNomsValueToValue(val)
 									assert.Error(t, err)
@@ -208,12 +209,12 @@ func testTypeInfoFormatParseRoundTrip(t *testing.T, tiArrays [][]TypeInfo, vaArr
 				atLeastOneValid := false
 				t.Run(ti.String(), func(t *testing.T) {
 					for _, val := range vaArrays[rowIndex] {
-						t.Run(fmt.Sprintf(`types.%v(%v)`, val.Kind().String(), val.HumanReadableString()), func(t *testing.T) {
+						t.Run(fmt.Sprintf(`types.%v(%v)`, val.Kind().String(), humanReadableString(t, val)), func(t *testing.T) {
 							str, err := ti.FormatValue(val)
 							if ti.IsValid(val) {
 								atLeastOneValid = true
 								require.NoError(t, err)
-								outVal, err := ti.ParseValue(str)
+								outVal, err := ti.ParseValue(context.Background(), testVRW, str)
 								require.NoError(t, err)
 								if ti == DateType { // special case as DateType removes the hh:mm:ss
 									val = types.Timestamp(time.Time(val.(types.Timestamp)).Truncate(24 * time.Hour))
@@ -261,7 +262,7 @@ func testTypeInfoNullHandling(t *testing.T, tiArrays [][]TypeInfo) {
 						require.Nil(t, val)
 					})
 					t.Run("ConvertValueToNomsValue", func(t *testing.T) {
-						tVal, err := ti.ConvertValueToNomsValue(nil)
+						tVal, err := ti.ConvertValueToNomsValue(context.Background(), nil, nil)
 						require.NoError(t, err)
 						require.Equal(t, types.NullValue, tVal)
 					})
@@ -278,7 +279,7 @@ func testTypeInfoNullHandling(t *testing.T, tiArrays [][]TypeInfo) {
 						require.True(t, ti.IsValid(nil))
 					})
 					t.Run("ParseValue", func(t *testing.T) {
-						tVal, err := ti.ParseValue(nil)
+						tVal, err := ti.ParseValue(context.Background(), nil, nil)
 						require.NoError(t, err)
 						require.Equal(t, types.NullValue, tVal)
 					})
@@ -324,6 +325,10 @@ func testTypeInfoToSqlType(t *testing.T, tiArrays [][]TypeInfo) {
 func generateTypeInfoArrays(t *testing.T) ([][]TypeInfo, [][]types.Value) {
 	return [][]TypeInfo{
 			generateBitTypes(t, 16),
+			{&blobBinaryType{sql.TinyBlob}, &blobBinaryType{sql.Blob},
+				&blobBinaryType{sql.MediumBlob}, &blobBinaryType{sql.LongBlob}},
+			{&blobStringType{sql.CreateTinyText(sql.Collation_Default)}, &blobStringType{sql.CreateText(sql.Collation_Default)},
+				&blobStringType{sql.CreateMediumText(sql.Collation_Default)}, &blobStringType{sql.CreateLongText(sql.Collation_Default)}},
 			{BoolType},
 			{DateType, DatetimeType, TimestampType},
 			generateDecimalTypes(t, 16),
@@ -331,6 +336,7 @@ func generateTypeInfoArrays(t *testing.T) ([][]TypeInfo, [][]types.Value) {
 			{Float32Type, Float64Type},
 			{InlineBlobType},
 			{Int8Type, Int16Type, Int24Type, Int32Type, Int64Type},
+			{JSONType},
 			generateSetTypes(t, 16),
 			{TimeType},
 			{Uint8Type, Uint16Type, Uint24Type, Uint32Type, Uint64Type},
@@ -345,6 +351,10 @@ func generateTypeInfoArrays(t *testing.T) ([][]TypeInfo, [][]types.Value) {
 		},
 		[][]types.Value{
 			{types.Uint(1), types.Uint(207), types.Uint(79147), types.Uint(34845728), types.Uint(9274618927)}, //Bit
+			{generateBlobValue(t, string([]byte{1})), generateBlobValue(t, string([]byte{42, 52})), generateBlobValue(t, string([]byte{84, 32, 13, 63, 12, 86})), //BlobBinary
+				generateBlobValue(t, string([]byte{1, 32, 235, 64, 32, 23, 45, 76})), generateBlobValue(t, string([]byte{123, 234, 34, 223, 76, 35, 32, 12, 84, 26, 15, 34, 65, 86, 45, 23, 43, 12, 76, 154, 234, 76, 34}))},
+			{generateBlobValue(t, ""), generateBlobValue(t, "a"), generateBlobValue(t, "abc"), //BlobString
+				generateBlobValue(t, "abcdefghijklmnopqrstuvwxyz"), generateBlobValue(t, "هذا هو بعض نماذج النص التي أستخدمها لاختبار عناصر")},
 			{types.Bool(false), types.Bool(true)}, //Bool
 			{types.Timestamp(time.Date(1000, 1, 1, 0, 0, 0, 0, time.UTC)), //Datetime
 				types.Timestamp(time.Date(1970, 1, 1, 0, 0, 1, 0, time.UTC)),
@@ -360,6 +370,7 @@ func generateTypeInfoArrays(t *testing.T) ([][]TypeInfo, [][]types.Value) {
 			{types.Float(1.0), types.Float(65513.75), types.Float(4293902592), types.Float(4.58e71), types.Float(7.172e285)},                                                               //Float
 			{types.InlineBlob{0}, types.InlineBlob{21}, types.InlineBlob{1, 17}, types.InlineBlob{72, 42}, types.InlineBlob{21, 122, 236}},                                                 //InlineBlob
 			{types.Int(20), types.Int(215), types.Int(237493), types.Int(2035753568), types.Int(2384384576063)},                                                                            //Int
+			{generateJSONValue(t, `null`), generateJSONValue(t, `"abc"`), generateJSONValue(t, `[1,2.5]`), generateJSONValue(t, `{"a":[{"b":null}]}`), generateJSONValue(t, `{"z":1e30}`)}, //JSON
 			{types.Uint(1), types.Uint(5), types.Uint(64), types.Uint(42), types.Uint(192)},                                                                                                //Set
 			{types.Int(0), types.Int(1000000 /*"00:00:01"*/), types.Int(113000000 /*"00:01:53"*/), types.Int(247019000000 /*"68:36:59"*/), types.Int(458830485214 /*"127:27:10.485214"*/)}, //Time
 			{types.Uint(20), types.Uint(275), types.Uint(328395), types.Uint(630257298), types.Uint(93897259874)},                                                                          //Uint
@@ -371,3 +382,36 @@ func generateTypeInfoArrays(t *testing.T) ([][]TypeInfo, [][]types.Value) {
 			{types.Int(1901), types.Int(1950), types.Int(2000), types.Int(2080), types.Int(2155)}, //Year
 		}
 }
+
+func TestIsWidening(t *testing.T) {
+	tests := []struct {
+		from     TypeInfo
+		to       TypeInfo
+		widening bool
+	}{
+		{Int32Type, Int64Type, true},
+		{Int8Type, Int24Type, true},
+		{Int64Type, Int32Type, false},
+		{Int32Type, Int32Type, false},
+		{Uint16Type, Uint32Type, true},
+		{Int32Type, Uint64Type, false},
+		{Float32Type, Float64Type, true},
+		{Float64Type, Float32Type, false},
+		{generateVarStringType(t, 10, false), generateVarStringType(t, 100, false), true},
+		{generateVarStringType(t, 100, false), generateVarStringType(t, 10, false), false},
+		{generateVarStringType(t, 10, true), generateVarStringType(t, 100, false), false},
+		{&blobStringType{sql.CreateText(sql.Collation_Default)}, &blobStringType{sql.CreateLongText(sql.Collation_Default)}, true},
+		{&blobStringType{sql.CreateLongText(sql.Collation_Default)}, &blobStringType{sql.CreateText(sql.Collation_Default)}, false},
+		{&varStringType{sql.CreateText(sql.Collation_Default)}, &blobStringType{sql.CreateLongText(sql.Collation_Default)}, false},
+		{generateDecimalType(t, 10, 2), generateDecimalType(t, 12, 4), true},
+		{generateDecimalType(t, 10, 2), generateDecimalType(t, 10, 4), false},
+		{generateDecimalType(t, 10, 4), generateDecimalType(t, 10, 2), false},
+		{Int32Type, StringDefaultType, false},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%v to %v", test.from, test.to), func(t *testing.T) {
+			assert.Equal(t, test.widening, IsWidening(test.from, test.to))
+		})
+	}
+}
@@ -0,0 +1,280 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/analyzer"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// BranchDbSeparator separates the name of a database from the name of a branch in the name of a branch database,
+// e.g. `mydb/feature-x`
+const BranchDbSeparator = "/"
+
+// BranchDatabaseName returns the name of the database for the branch of the database given.
+func BranchDatabaseName(dbName string, branch ref.BranchRef) string {
+	return dbName + BranchDbSeparator + branch.GetPath()
+}
+
+// repoDbName returns the name of the repository of the database given, which is the database's own name unless it is
+// a branch database.
+func repoDbName(dbName string) string {
+	return strings.SplitN(dbName, BranchDbSeparator, 2)[0]
+}
+
+// NewBranchDatabase returns a database for a branch of the database given, named <db>/<branch>, which lets a
+// connection work on that branch regardless of the branch checked out in the repository. The database of the checked
+// out branch shares the working set of the database given. The working set of any other branch is kept by each session
+// using its database, starting at the branch's head commit.
+func NewBranchDatabase(db Database, branch ref.BranchRef) Database {
+	name := BranchDatabaseName(db.Name(), branch)
+
+	if ref.Equals(db.rsr.CWBHeadRef(), branch) {
+		return NewDatabase(name, env.DbData{Ddb: db.ddb, Rsr: db.rsr, Rsw: db.rsw, Drw: db.drw})
+	}
+
+	bs := &branchRepoState{branch: branch}
+	return NewDatabase(name, env.DbData{Ddb: db.ddb, Rsr: bs, Rsw: bs, Drw: branchDocsReadWriter{}})
+}
+
+// ResolveBranchDatabasesRule is the name of the ResolveBranchDatabases analyzer rule.
+const ResolveBranchDatabasesRule = "resolve_branch_databases"
+
+var branchDbsMu = &sync.Mutex{}
+
+// ResolveBranchDatabases is a pre-analyzer rule which adds the database of a branch to the catalog and to the session
+// when a `USE <db>/<branch>` statement selects it, before the database is resolved. A branch database selected by the
+// protocol instead, as the database of a connection or by COM_INIT_DB, must already be in the catalog, and is added to
+// the session by the first query which uses it.
+func ResolveBranchDatabases(ctx *sql.Context, a *analyzer.Analyzer, n sql.Node, _ *analyzer.Scope) (sql.Node, error) {
+	dSess, ok := ctx.Session.(*DoltSession)
+
+	if !ok {
+		return n, nil
+	}
+
+	dbName := ctx.GetCurrentDatabase()
+
+	if use, ok := n.(*plan.Use); ok {
+		dbName = use.Database().Name()
+	} else if _, ok := dSess.dbDatas[dbName]; ok {
+		return n, nil
+	}
+
+	err := AddBranchDatabase(ctx, a.Catalog, dSess, dbName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
+
+// AddBranchDatabase adds the database of the branch named by |dbName|, of the form <db>/<branch>, to the catalog if it
+// isn't there yet, and to the session given if it hasn't used it yet. Does nothing for a name which isn't the name of
+// a branch database. Returns sql.ErrDatabaseNotFound if the branch doesn't exist, which is the case for the databases
+// of deleted branches, which stay in the catalog as it can't remove them.
+func AddBranchDatabase(ctx *sql.Context, catalog *sql.Catalog, dSess *DoltSession, dbName string) error {
+	parts := strings.SplitN(dbName, BranchDbSeparator, 2)
+
+	if len(parts) != 2 {
+		return nil
+	}
+
+	repoDb, err := catalog.Database(parts[0])
+
+	if err != nil {
+		return err
+	}
+
+	db, ok := repoDb.(Database)
+
+	if !ok {
+		return sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	branch := ref.NewBranchRef(parts[1])
+	exists, err := db.ddb.HasRef(ctx, branch)
+
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	branchDb, err := catalogBranchDatabase(catalog, db, branch)
+
+	if err != nil {
+		return err
+	}
+
+	if _, ok := dSess.dbDatas[branchDb.Name()]; ok {
+		return nil
+	}
+
+	err = dSess.AddDB(ctx, branchDb)
+
+	if err != nil {
+		return err
+	}
+
+	err = branchDb.LoadRootFromRepoState(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	root, err := branchDb.GetRoot(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	return RegisterSchemaFragments(ctx, branchDb, root)
+}
+
+// catalogBranchDatabase returns the database of a branch of the database given from the catalog, adding it first if
+// it isn't there yet.
+func catalogBranchDatabase(catalog *sql.Catalog, db Database, branch ref.BranchRef) (Database, error) {
+	branchDbsMu.Lock()
+	defer branchDbsMu.Unlock()
+
+	name := BranchDatabaseName(db.Name(), branch)
+
+	if catalog.HasDB(name) {
+		existing, err := catalog.Database(name)
+
+		if err != nil {
+			return Database{}, err
+		}
+
+		branchDb, ok := existing.(Database)
+
+		if !ok {
+			return Database{}, fmt.Errorf("database '%s' is not the database of a branch", name)
+		}
+
+		return branchDb, nil
+	}
+
+	branchDb := NewBranchDatabase(db, branch)
+	catalog.AddDatabase(branchDb)
+	return branchDb, nil
+}
+
+// branchRepoState is the repo state of a branch which is not checked out. The working and staged roots it holds belong
+// to a single session, and are never persisted.
+type branchRepoState struct {
+	branch  ref.BranchRef
+	working hash.Hash
+	staged  hash.Hash
+}
+
+var _ env.RepoStateReader = (*branchRepoState)(nil)
+var _ env.RepoStateWriter = (*branchRepoState)(nil)
+
+// newBranchRepoState returns the repo state of a session working on the branch given, whose working and staged roots
+// start at the branch's head commit.
+func newBranchRepoState(ctx context.Context, ddb *doltdb.DoltDB, branch ref.BranchRef) (*branchRepoState, error) {
+	cm, err := ddb.ResolveRef(ctx, branch)
+
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := cm.GetRootValue()
+
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := root.HashOf()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &branchRepoState{branch: branch, working: h, staged: h}, nil
+}
+
+func (bs *branchRepoState) CWBHeadRef() ref.DoltRef {
+	return bs.branch
+}
+
+func (bs *branchRepoState) CWBHeadSpec() *doltdb.CommitSpec {
+	spec, _ := doltdb.NewCommitSpec("HEAD")
+	return spec
+}
+
+func (bs *branchRepoState) WorkingHash() hash.Hash {
+	return bs.working
+}
+
+func (bs *branchRepoState) StagedHash() hash.Hash {
+	return bs.staged
+}
+
+func (bs *branchRepoState) IsMergeActive() bool {
+	return false
+}
+
+func (bs *branchRepoState) GetMergeCommit() string {
+	return ""
+}
+
+func (bs *branchRepoState) SetStagedHash(_ context.Context, h hash.Hash) error {
+	bs.staged = h
+	return nil
+}
+
+func (bs *branchRepoState) SetWorkingHash(_ context.Context, h hash.Hash) error {
+	bs.working = h
+	return nil
+}
+
+func (bs *branchRepoState) ClearMerge() error {
+	return nil
+}
+
+// branchDocsReadWriter is the DocsReadWriter of a branch which is not checked out. The docs of such a branch are only
+// found in its dolt_docs table, and are never written to the filesystem.
+type branchDocsReadWriter struct{}
+
+var _ env.DocsReadWriter = branchDocsReadWriter{}
+
+func (branchDocsReadWriter) GetAllValidDocDetails() ([]doltdb.DocDetails, error) {
+	return nil, nil
+}
+
+func (branchDocsReadWriter) PutDocsToWorking(context.Context, []doltdb.DocDetails) error {
+	return nil
+}
+
+func (branchDocsReadWriter) ResetWorkingDocsToStagedDocs(context.Context) error {
+	return nil
+}
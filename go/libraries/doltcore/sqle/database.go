@@ -22,6 +22,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/dolthub/go-mysql-server/auth"
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/parse"
 	"github.com/dolthub/go-mysql-server/sql/plan"
@@ -31,6 +32,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/commitwalk"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/doltcore/row"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema/alterschema"
@@ -200,9 +202,27 @@ func (db Database) GetDocsReadWriter() env.DocsReadWriter {
 	return db.drw
 }
 
+// sessionStateReader returns the RepoStateReader the session uses for this database, which holds the session's own
+// working set for the database of a branch which isn't checked out.
+func (db Database) sessionStateReader(ctx *sql.Context) env.RepoStateReader {
+	if dSess, ok := ctx.Session.(*DoltSession); ok {
+		if rsr, ok := dSess.GetDoltDBRepoStateReader(db.name); ok {
+			return rsr
+		}
+	}
+
+	return db.rsr
+}
+
 // GetTableInsensitive is used when resolving tables in queries. It returns a best-effort case-insensitive match for
 // the table name given.
 func (db Database) GetTableInsensitive(ctx *sql.Context, tblName string) (sql.Table, bool, error) {
+	err := DSessFromSess(ctx.Session).CheckPermission(db.name, auth.ReadPerm)
+
+	if err != nil {
+		return nil, false, err
+	}
+
 	root, err := db.GetRoot(ctx)
 
 	if err != nil {
@@ -220,6 +240,14 @@ func (db Database) GetTableInsensitiveWithRoot(ctx *sql.Context, root *doltdb.Ro
 		return nil, false, err
 	}
 
+	if name, args, ok := parseTableFunctionName(tblName); ok {
+		dt, err = db.tableFunction(ctx, name, args)
+		if err != nil {
+			return nil, false, err
+		}
+		return dt, true, nil
+	}
+
 	// NOTE: system tables are not suitable for caching
 	switch {
 	case strings.HasPrefix(lwrName, doltdb.DoltDiffTablePrefix):
@@ -229,7 +257,7 @@ func (db Database) GetTableInsensitiveWithRoot(ctx *sql.Context, root *doltdb.Ro
 	case strings.HasPrefix(lwrName, doltdb.DoltCommitDiffTablePrefix):
 		suffix := tblName[len(doltdb.DoltCommitDiffTablePrefix):]
 		found = true
-		dt, err = dtables.NewCommitDiffTable(ctx, suffix, db.ddb, root)
+		dt, err = dtables.NewCommitDiffTable(ctx, suffix, db.ddb, root, db.sessionStateReader(ctx), db)
 	case strings.HasPrefix(lwrName, doltdb.DoltHistoryTablePrefix):
 		suffix := tblName[len(doltdb.DoltHistoryTablePrefix):]
 		found = true
@@ -250,10 +278,14 @@ func (db Database) GetTableInsensitiveWithRoot(ctx *sql.Context, root *doltdb.Ro
 	switch lwrName {
 	case doltdb.LogTableName:
 		dt, found = dtables.NewLogTable(ctx, db.ddb, head), true
+	case doltdb.DiffTableName:
+		dt, found = dtables.NewUnscopedDiffTable(ctx, db.ddb, head, root), true
 	case doltdb.TableOfTablesInConflictName:
 		dt, found = dtables.NewTableOfTablesInConflict(ctx, db.ddb, root), true
+	case doltdb.SchemaConflictsTableName:
+		dt, found = dtables.NewSchemaConflictsTable(ctx, root, dtables.RootSetter(db)), true
 	case doltdb.BranchesTableName:
-		dt, found = dtables.NewBranchesTable(ctx, db.ddb), true
+		dt, found = dtables.NewBranchesTable(ctx, db.ddb, dtables.BranchAuthorizer(db)), true
 	case doltdb.CommitsTableName:
 		dt, found = dtables.NewCommitsTable(ctx, db.ddb), true
 	case doltdb.CommitAncestorsTableName:
@@ -263,6 +295,18 @@ func (db Database) GetTableInsensitiveWithRoot(ctx *sql.Context, root *doltdb.Ro
 		return dt, found, nil
 	}
 
+	if sch, ok := emptySystemTableSchemas[lwrName]; ok {
+		if has, err := root.HasTable(ctx, lwrName); err != nil {
+			return nil, false, err
+		} else if !has {
+			dt, err = newEmptySystemTable(db, lwrName, sch)
+			if err != nil {
+				return nil, false, err
+			}
+			return dt, true, nil
+		}
+	}
+
 	return db.getTable(ctx, root, tblName)
 }
 
@@ -281,6 +325,13 @@ func (db Database) GetTableInsensitiveAsOf(ctx *sql.Context, tableName string, a
 // rootAsOf returns the root of the DB as of the expression given, which may be nil in the case that it refers to an
 // expression before the first commit.
 func (db Database) rootAsOf(ctx *sql.Context, asOf interface{}) (*doltdb.RootValue, error) {
+	dSess := DSessFromSess(ctx.Session)
+	err := dSess.CheckPermission(db.name, auth.ReadPerm)
+
+	if err != nil {
+		return nil, err
+	}
+
 	switch x := asOf.(type) {
 	case string:
 		return db.getRootForCommitRef(ctx, x)
@@ -344,6 +395,11 @@ func (db Database) getRootForCommitRef(ctx *sql.Context, commitRef string) (*dol
 		return nil, err
 	}
 
+	err = db.CheckCommitRead(ctx, commitRef, cm)
+	if err != nil {
+		return nil, err
+	}
+
 	root, err := cm.GetRootValue()
 	if err != nil {
 		return nil, err
@@ -352,6 +408,79 @@ func (db Database) getRootForCommitRef(ctx *sql.Context, commitRef string) (*dol
 	return root, nil
 }
 
+// CheckCommitRead returns an error if the session may not read the commit the commit spec given resolved to. A spec
+// based on HEAD or on a branch needs the read permission on that branch. Any other commit, such as one given by its
+// hash or by a tag, must be part of a branch the session may read.
+func (db Database) CheckCommitRead(ctx *sql.Context, spec string, cm *doltdb.Commit) error {
+	dSess := DSessFromSess(ctx.Session)
+
+	if dSess.grants == nil {
+		return nil
+	}
+
+	name, _, err := doltdb.SplitAncestorSpec(spec)
+
+	if err != nil {
+		return err
+	}
+
+	if strings.EqualFold(name, "head") {
+		return dSess.CheckBranchPermission(db.name, db.rsr.CWBHeadRef().GetPath(), auth.ReadPerm)
+	}
+
+	// a ref is resolved to a branch before a tag or a remote ref of the same name
+	branch := strings.TrimPrefix(strings.TrimPrefix(name, "refs/"), "heads/")
+	if isBranch, err := db.ddb.HasRef(ctx, ref.NewBranchRef(branch)); err != nil {
+		return err
+	} else if isBranch {
+		return dSess.CheckBranchPermission(db.name, branch, auth.ReadPerm)
+	}
+
+	h, err := cm.HashOf()
+
+	if err != nil {
+		return err
+	}
+
+	branches, err := db.ddb.GetBranches(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	for _, br := range branches {
+		if dSess.CheckBranchPermission(db.name, br.GetPath(), auth.ReadPerm) != nil {
+			continue
+		}
+
+		head, err := db.ddb.ResolveRef(ctx, br)
+
+		if err != nil {
+			return err
+		}
+
+		ancestor, err := doltdb.GetCommitAncestor(ctx, cm, head)
+
+		if err == doltdb.ErrNoCommonAncestor {
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		ancestorHash, err := ancestor.HashOf()
+
+		if err != nil {
+			return err
+		}
+
+		if ancestorHash == h {
+			return nil
+		}
+	}
+
+	return ErrCommitPermissionDenied.New(dSess.Client().User, auth.ReadPerm, h.String(), repoDbName(db.name))
+}
+
 // GetTableNamesAsOf implements sql.VersionedDatabase
 func (db Database) GetTableNamesAsOf(ctx *sql.Context, time interface{}) ([]string, error) {
 	root, err := db.rootAsOf(ctx, time)
@@ -368,6 +497,18 @@ func (db Database) GetTableNamesAsOf(ctx *sql.Context, time interface{}) ([]stri
 	return filterDoltInternalTables(tblNames), nil
 }
 
+// DiffTableFunction returns the table of DOLT_DIFF(tblName, fromRef, toRef): the row diff of the table given between
+// two commit specs, which may also be WORKING or STAGED.
+func (db Database) DiffTableFunction(ctx *sql.Context, tblName, fromRef, toRef string) (sql.Table, error) {
+	root, err := db.GetRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	roots := dtables.NewRootResolver(db.ddb, db.sessionStateReader(ctx), root, db)
+	return dtables.NewDiffTableFunction(ctx, tblName, fromRef, toRef, db.ddb, roots)
+}
+
 // getTable gets the table with the exact name given at the root value given. The database caches tables for all root
 // values to avoid doing schema lookups on every table lookup, which are expensive.
 func (db Database) getTable(ctx context.Context, root *doltdb.RootValue, tableName string) (sql.Table, bool, error) {
@@ -429,6 +570,12 @@ func (db Database) GetTableNames(ctx *sql.Context) ([]string, error) {
 // GetAllTableNames returns all user-space tables, including system tables in user space
 // (e.g. dolt_docs, dolt_query_catalog).
 func (db Database) GetAllTableNames(ctx *sql.Context) ([]string, error) {
+	err := DSessFromSess(ctx.Session).CheckPermission(db.name, auth.ReadPerm)
+
+	if err != nil {
+		return nil, err
+	}
+
 	root, err := db.GetRoot(ctx)
 
 	if err != nil {
@@ -471,9 +618,9 @@ func (db Database) GetRoot(ctx *sql.Context) (*doltdb.RootValue, error) {
 
 	if val == nil {
 		if !dbRootOk {
-			return nil, fmt.Errorf("value for '%s' not found", key)
+			return nil, sql.ErrDatabaseNotFound.New(db.name)
 		} else {
-			err := db.SetRoot(ctx, currRoot.root)
+			err := dsess.SetRoot(ctx, db.name, currRoot.root)
 
 			if err != nil {
 				return nil, err
@@ -513,42 +660,31 @@ func (db Database) GetRoot(ctx *sql.Context) (*doltdb.RootValue, error) {
 // Set a new root value for the database. Can be used if the dolt working
 // set value changes outside of the basic SQL execution engine.
 func (db Database) SetRoot(ctx *sql.Context, newRoot *doltdb.RootValue) error {
-	h, err := newRoot.HashOf()
+	dSess := DSessFromSess(ctx.Session)
+	err := dSess.CheckPermission(db.name, auth.WritePerm)
 
 	if err != nil {
 		return err
 	}
 
-	hashStr := h.String()
-	key := db.WorkingKey()
-
-	err = ctx.Session.Set(ctx, key, hashType, hashStr)
-
-	if err != nil {
-		return err
-	}
-
-	dsess := DSessFromSess(ctx.Session)
-	dsess.dbRoots[db.name] = dbRoot{hashStr, newRoot}
-
-	err = dsess.dbEditors[db.name].SetRoot(ctx, newRoot)
-	if err != nil {
-		return err
-	}
+	return dSess.SetRoot(ctx, db.name, newRoot)
+}
 
-	return nil
+// CheckBranchWrite returns an error if the session isn't allowed to write to the branch of this database given.
+func (db Database) CheckBranchWrite(ctx *sql.Context, branch string) error {
+	return DSessFromSess(ctx.Session).CheckBranchPermission(db.name, branch, auth.WritePerm)
 }
 
 // LoadRootFromRepoState loads the root value from the repo state's working hash, then calls SetRoot with the loaded
 // root value.
 func (db Database) LoadRootFromRepoState(ctx *sql.Context) error {
-	workingHash := db.rsr.WorkingHash()
+	workingHash := db.sessionStateReader(ctx).WorkingHash()
 	root, err := db.ddb.ReadRootValue(ctx, workingHash)
 	if err != nil {
 		return err
 	}
 
-	return db.SetRoot(ctx, root)
+	return DSessFromSess(ctx.Session).SetRoot(ctx, db.name, root)
 }
 
 // DropTable drops the table with the name given
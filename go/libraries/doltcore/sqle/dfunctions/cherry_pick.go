@@ -0,0 +1,153 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dfunctions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+)
+
+const DoltCherryPickFuncName = "dolt_cherry_pick"
+
+type DoltCherryPickFunc struct {
+	children []sql.Expression
+}
+
+// NewDoltCherryPickFunc creates a new DoltCherryPickFunc expression whose children represents the args passed in
+// DOLT_CHERRY_PICK.
+func NewDoltCherryPickFunc(args ...sql.Expression) (sql.Expression, error) {
+	return &DoltCherryPickFunc{children: args}, nil
+}
+
+// Eval implements the Expression interface. Applies the changes introduced by the given commit to the working root of
+// the current session and returns the hash of the new working root. Conflicting changes are left in the working root
+// where they can be queried and resolved through the dolt_conflicts_<table> tables. The changes are not committed.
+func (d DoltCherryPickFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	dbName := ctx.GetCurrentDatabase()
+	dSess := sqle.DSessFromSess(ctx.Session)
+	dbData, ok := dSess.GetDbData(dbName)
+
+	if !ok {
+		return nil, sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	args, err := getDoltArgs(ctx, row, d.Children())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(args) != 1 {
+		return nil, sql.ErrInvalidArgumentNumber.New(DoltCherryPickFuncName, 1, len(args))
+	}
+
+	root, ok := dSess.GetRoot(dbName)
+
+	if !ok {
+		return nil, sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	_, _, parentRoot, err := getParent(ctx, err, dSess, dbName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkForUncommittedChanges(root, parentRoot, "cherry-pick")
+
+	if err != nil {
+		return nil, err
+	}
+
+	cs, err := doltdb.NewCommitSpec(args[0])
+
+	if err != nil {
+		return nil, err
+	}
+
+	cm, err := dbData.Ddb.Resolve(ctx, cs, dbData.Rsr.CWBHeadRef())
+
+	if err != nil {
+		return nil, err
+	}
+
+	mergedRoot, _, err := merge.CherryPick(ctx, dbData.Ddb, root, cm, merge.MergeOpts{Checks: sqlutil.NewCheckEvaluator})
+
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := dbData.Ddb.WriteRootValue(ctx, mergedRoot)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = dSess.SetRoot(ctx, dbName, mergedRoot)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return h.String(), nil
+}
+
+// String implements the Stringer interface.
+func (d DoltCherryPickFunc) String() string {
+	childrenStrings := make([]string, len(d.children))
+
+	for i, child := range d.children {
+		childrenStrings[i] = child.String()
+	}
+
+	return fmt.Sprintf("DOLT_CHERRY_PICK(%s)", strings.Join(childrenStrings, ","))
+}
+
+// Type implements the Expression interface.
+func (d DoltCherryPickFunc) Type() sql.Type {
+	return sql.Text
+}
+
+// IsNullable implements the Expression interface.
+func (d DoltCherryPickFunc) IsNullable() bool {
+	return false
+}
+
+// WithChildren implements the Expression interface.
+func (d DoltCherryPickFunc) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewDoltCherryPickFunc(children...)
+}
+
+// Resolved implements the Expression interface.
+func (d DoltCherryPickFunc) Resolved() bool {
+	for _, child := range d.Children() {
+		if !child.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// Children implements the Expression interface.
+func (d DoltCherryPickFunc) Children() []sql.Expression {
+	return d.children
+}
@@ -17,6 +17,7 @@ package dfunctions
 import (
 	"fmt"
 
+	"github.com/dolthub/go-mysql-server/auth"
 	"github.com/dolthub/go-mysql-server/sql"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
@@ -48,6 +49,12 @@ func (d DoltCommitFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error)
 		return nil, fmt.Errorf("Could not load %s", dbName)
 	}
 
+	err := dSess.CheckPermission(dbName, auth.WritePerm)
+
+	if err != nil {
+		return nil, err
+	}
+
 	ddb := dbData.Ddb
 	rsr := dbData.Rsr
 
@@ -124,6 +131,7 @@ func (d DoltCommitFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error)
 		CheckForeignKeys: !apr.Contains(cli.ForceFlag),
 		Name:             name,
 		Email:            email,
+		Hooks:            actions.NewHooks(dSess.HookConfig(dbName), sqle.NewHookQueryFunc(dbName, dbData), cli.CliErr),
 	})
 
 	return h, err
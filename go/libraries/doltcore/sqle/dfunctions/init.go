@@ -23,4 +23,6 @@ var DoltFunctions = []sql.Function{
 	sql.Function1{Name: resetFuncName, Fn: NewDoltResetFunc},
 	sql.Function0{Name: VersionFuncName, Fn: NewVersion},
 	sql.FunctionN{Name: DoltCommitFuncName, Fn: NewDoltCommitFunc},
+	sql.FunctionN{Name: DoltCherryPickFuncName, Fn: NewDoltCherryPickFunc},
+	sql.FunctionN{Name: DoltResolveSchemaConflictsFuncName, Fn: NewDoltResolveSchemaConflictsFunc},
 }
@@ -25,6 +25,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
 	"github.com/dolthub/dolt/go/store/hash"
 )
 
@@ -83,7 +84,7 @@ func (cf *MergeFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return nil, err
 	}
 
-	err = checkForUncommittedChanges(root, parentRoot)
+	err = checkForUncommittedChanges(root, parentRoot, "merge")
 	if err != nil {
 		return nil, err
 	}
@@ -103,12 +104,22 @@ func (cf *MergeFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return cmh.String(), nil
 	}
 
-	mergeRoot, _, err := merge.MergeCommits(ctx, parent, cm)
+	mergeRoot, _, err := merge.MergeCommits(ctx, parent, cm, merge.MergeOpts{Checks: sqlutil.NewCheckEvaluator})
 
 	if err != nil {
 		return nil, err
 	}
 
+	schConflicts, err := mergeRoot.TablesWithSchemaConflicts(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(schConflicts) > 0 {
+		return nil, fmt.Errorf("schema conflicts in tables %s", strings.Join(schConflicts, ", "))
+	}
+
 	h, err := ddb.WriteRootValue(ctx, mergeRoot)
 	if err != nil {
 		return nil, err
@@ -133,7 +144,7 @@ func (cf *MergeFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	return h.String(), nil
 }
 
-func checkForUncommittedChanges(root *doltdb.RootValue, parentRoot *doltdb.RootValue) error {
+func checkForUncommittedChanges(root *doltdb.RootValue, parentRoot *doltdb.RootValue, op string) error {
 	rh, err := root.HashOf()
 
 	if err != nil {
@@ -147,7 +158,7 @@ func checkForUncommittedChanges(root *doltdb.RootValue, parentRoot *doltdb.RootV
 	}
 
 	if rh != prh {
-		return errors.New("cannot merge with uncommitted changes")
+		return fmt.Errorf("cannot %s with uncommitted changes", op)
 	}
 
 	return nil
@@ -0,0 +1,140 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dfunctions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+)
+
+const DoltResolveSchemaConflictsFuncName = "dolt_resolve_schema_conflicts"
+
+var schemaConflictResolvers = map[string]merge.SchemaConflictResolver{
+	"--ours":   merge.OursSchema,
+	"--theirs": merge.TheirsSchema,
+}
+
+type DoltResolveSchemaConflictsFunc struct {
+	children []sql.Expression
+}
+
+// NewDoltResolveSchemaConflictsFunc creates a new DoltResolveSchemaConflictsFunc expression whose children represents
+// the args passed in DOLT_RESOLVE_SCHEMA_CONFLICTS.
+func NewDoltResolveSchemaConflictsFunc(args ...sql.Expression) (sql.Expression, error) {
+	return &DoltResolveSchemaConflictsFunc{children: args}, nil
+}
+
+// Eval implements the Expression interface. The first argument is either '--ours', which keeps our schema and rows for
+// each table given, or '--theirs', which replaces each table given with their version of it. Returns the hash of the
+// new working root.
+func (d DoltResolveSchemaConflictsFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	dbName := ctx.GetCurrentDatabase()
+	dSess := sqle.DSessFromSess(ctx.Session)
+	dbData, ok := dSess.GetDbData(dbName)
+
+	if !ok {
+		return nil, sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	args, err := getDoltArgs(ctx, row, d.Children())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(args) < 2 {
+		return nil, sql.ErrInvalidArgumentNumber.New(DoltResolveSchemaConflictsFuncName, 2, len(args))
+	}
+
+	resolver, ok := schemaConflictResolvers[strings.ToLower(args[0])]
+
+	if !ok {
+		return nil, fmt.Errorf("invalid argument to %s(): %s, expected --ours or --theirs", DoltResolveSchemaConflictsFuncName, args[0])
+	}
+
+	root, ok := dSess.GetRoot(dbName)
+
+	if !ok {
+		return nil, sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	for _, tblName := range args[1:] {
+		root, err = merge.ResolveSchemaConflict(ctx, root, tblName, resolver)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	h, err := dbData.Ddb.WriteRootValue(ctx, root)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = dSess.SetRoot(ctx, dbName, root)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return h.String(), nil
+}
+
+// String implements the Stringer interface.
+func (d DoltResolveSchemaConflictsFunc) String() string {
+	childrenStrings := make([]string, len(d.children))
+
+	for i, child := range d.children {
+		childrenStrings[i] = child.String()
+	}
+
+	return fmt.Sprintf("DOLT_RESOLVE_SCHEMA_CONFLICTS(%s)", strings.Join(childrenStrings, ","))
+}
+
+// Type implements the Expression interface.
+func (d DoltResolveSchemaConflictsFunc) Type() sql.Type {
+	return sql.Text
+}
+
+// IsNullable implements the Expression interface.
+func (d DoltResolveSchemaConflictsFunc) IsNullable() bool {
+	return false
+}
+
+// WithChildren implements the Expression interface.
+func (d DoltResolveSchemaConflictsFunc) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewDoltResolveSchemaConflictsFunc(children...)
+}
+
+// Resolved implements the Expression interface.
+func (d DoltResolveSchemaConflictsFunc) Resolved() bool {
+	for _, child := range d.Children() {
+		if !child.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// Children implements the Expression interface.
+func (d DoltResolveSchemaConflictsFunc) Children() []sql.Expression {
+	return d.children
+}
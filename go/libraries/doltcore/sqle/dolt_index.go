@@ -15,6 +15,7 @@
 package sqle
 
 import (
+	"context"
 	"errors"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -257,7 +258,7 @@ func (di *doltIndex) keysToTuple(keys []interface{}) (types.Tuple, error) {
 	for i, col := range di.cols {
 		// As an example, if our TypeInfo is Int8, we should not fail to create a tuple if we are returning all keys
 		// that have a value of less than 9001, thus we promote the TypeInfo to the widest type.
-		val, err := col.TypeInfo.Promote().ConvertValueToNomsValue(keys[i])
+		val, err := col.TypeInfo.Promote().ConvertValueToNomsValue(context.Background(), di.table.ValueReadWriter(), keys[i])
 		if err != nil {
 			return types.EmptyTuple(nbf), err
 		}
@@ -18,11 +18,14 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/dolthub/go-mysql-server/auth"
 	"github.com/dolthub/go-mysql-server/sql"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+	"github.com/dolthub/dolt/go/libraries/utils/config"
 	"github.com/dolthub/dolt/go/store/hash"
 )
 
@@ -39,6 +42,9 @@ type DoltSession struct {
 	dbRoots   map[string]dbRoot
 	dbDatas   map[string]env.DbData
 	dbEditors map[string]*editor.TableEditSession
+	dbTxs     map[string]*DoltTransaction
+	grants    Grants
+	hookCfgs  map[string]config.ReadableConfig
 
 	Username string
 	Email    string
@@ -51,6 +57,7 @@ func DefaultDoltSession() *DoltSession {
 		dbRoots:   make(map[string]dbRoot),
 		dbDatas:   make(map[string]env.DbData),
 		dbEditors: make(map[string]*editor.TableEditSession),
+		dbTxs:     make(map[string]*DoltTransaction),
 		Username:  "",
 		Email:     "",
 	}
@@ -64,10 +71,10 @@ func NewDoltSession(ctx context.Context, sqlSess sql.Session, username, email st
 	dbEditors := make(map[string]*editor.TableEditSession)
 	for _, db := range dbs {
 		dbDatas[db.Name()] = env.DbData{Rsw: db.rsw, Ddb: db.ddb, Rsr: db.rsr, Drw: db.drw}
-		dbEditors[db.Name()] = editor.CreateTableEditSession(nil, editor.TableEditSessionProps{})
+		dbEditors[db.Name()] = editor.CreateTableEditSession(nil, editor.TableEditSessionProps{Checks: sqlutil.NewCheckEvaluator})
 	}
 
-	sess := &DoltSession{sqlSess, dbRoots, dbDatas, dbEditors, username, email}
+	sess := &DoltSession{sqlSess, dbRoots, dbDatas, dbEditors, make(map[string]*DoltTransaction), nil, nil, username, email}
 	for _, db := range dbs {
 		err := sess.AddDB(ctx, db)
 
@@ -84,26 +91,218 @@ func DSessFromSess(sess sql.Session) *DoltSession {
 	return sess.(*DoltSession)
 }
 
+// CommitTransaction commits the transaction of the current database, merging its changes with the changes committed
+// by other transactions since it started. It is called after each COMMIT statement, and after each statement in
+// autocommit mode. Transactions started with BEGIN are only committed once a COMMIT statement ends them, so this is a
+// no-op for them until then, as it is when no transaction is open.
 func (sess *DoltSession) CommitTransaction(ctx *sql.Context) error {
 	currentDb := sess.GetCurrentDatabase()
 	if currentDb == "" {
 		return sql.ErrNoDatabaseSelected.New()
 	}
 
-	dbRoot, ok := sess.dbRoots[currentDb]
+	if tx, ok := sess.dbTxs[currentDb]; ok && tx.explicit {
+		return nil
+	}
+
+	return sess.commitTransaction(ctx, currentDb)
+}
+
+// StartTransaction starts a transaction on the database given which lasts until it is committed or rolled back. Its
+// queries see the working root of the database at the time it started along with their own changes. An open
+// transaction with uncommitted changes is committed first.
+func (sess *DoltSession) StartTransaction(ctx *sql.Context, dbName string) error {
+	err := sess.commitTransaction(ctx, dbName)
+
+	if err != nil {
+		return err
+	}
+
+	return sess.startTransaction(ctx, dbName, true)
+}
+
+// RollbackTransaction discards the changes of the transaction open on the database given.
+func (sess *DoltSession) RollbackTransaction(ctx *sql.Context, dbName string) error {
+	tx, ok := sess.dbTxs[dbName]
+
 	if !ok {
-		return sql.ErrDatabaseNotFound.New(currentDb)
+		return nil
 	}
 
-	dbData := sess.dbDatas[currentDb]
+	delete(sess.dbTxs, dbName)
+	return sess.SetRoot(ctx, dbName, tx.startRoot)
+}
+
+func (sess *DoltSession) commitTransaction(ctx *sql.Context, dbName string) error {
+	dbRoot, ok := sess.dbRoots[dbName]
+	if !ok {
+		return sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	tx, ok := sess.dbTxs[dbName]
+	if !ok {
+		return nil
+	}
+
+	if dbRoot.hashStr != tx.startHash.String() {
+		err := sess.CheckPermission(dbName, auth.WritePerm)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	delete(sess.dbTxs, dbName)
+	newRoot, err := tx.Commit(ctx, dbRoot.root)
+
+	if ErrSerializationFailure.Is(err) {
+		// the transaction's changes are discarded, so it can be retried from the current working root
+		workingRoot, rbErr := tx.dbData.Ddb.ReadRootValue(ctx, tx.dbData.Rsr.WorkingHash())
+
+		if rbErr != nil {
+			return rbErr
+		}
+
+		rbErr = sess.SetRoot(ctx, dbName, workingRoot)
+
+		if rbErr != nil {
+			return rbErr
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return sess.SetRoot(ctx, dbName, newRoot)
+}
+
+func (sess *DoltSession) startTransaction(ctx *sql.Context, dbName string, explicit bool) error {
+	dbData := sess.dbDatas[dbName]
+	root, err := dbData.Ddb.ReadRootValue(ctx, dbData.Rsr.WorkingHash())
+
+	if err != nil {
+		return err
+	}
+
+	tx, err := NewDoltTransaction(root, dbData, explicit)
 
-	root := dbRoot.root
-	h, err := dbData.Ddb.WriteRootValue(ctx, root)
 	if err != nil {
 		return err
 	}
 
-	return dbData.Rsw.SetWorkingHash(ctx, h)
+	err = sess.SetRoot(ctx, dbName, root)
+
+	if err != nil {
+		return err
+	}
+
+	sess.dbTxs[dbName] = tx
+	return nil
+}
+
+// endExplicitTransaction marks the transaction started with BEGIN on the database given as ended by a COMMIT, so that
+// it is committed by the next call to CommitTransaction.
+func (sess *DoltSession) endExplicitTransaction(dbName string) {
+	if tx, ok := sess.dbTxs[dbName]; ok {
+		tx.explicit = false
+	}
+}
+
+// BeginStatement is called before each query of the session. It starts a transaction on the current database when
+// none is open. In autocommit mode every statement runs in its own transaction, so a transaction without changes, left
+// open by a statement which doesn't commit, is restarted to see the changes committed by others since.
+func (sess *DoltSession) BeginStatement(ctx *sql.Context) error {
+	dbName := sess.GetCurrentDatabase()
+
+	if _, ok := sess.dbDatas[dbName]; !ok {
+		return nil
+	}
+
+	tx, ok := sess.dbTxs[dbName]
+
+	if !ok {
+		return sess.startTransaction(ctx, dbName, false)
+	}
+
+	if tx.explicit || !sess.isAutocommit() {
+		return nil
+	}
+
+	dbRoot, ok := sess.dbRoots[dbName]
+
+	if !ok || dbRoot.hashStr != tx.startHash.String() || tx.dbData.Rsr.WorkingHash() == tx.startHash {
+		return nil
+	}
+
+	return sess.startTransaction(ctx, dbName, false)
+}
+
+func (sess *DoltSession) isAutocommit() bool {
+	typ, val := sess.Session.Get(sql.AutoCommitSessionVar)
+
+	if val == nil {
+		return false
+	}
+
+	switch typ {
+	case sql.Int64:
+		return val.(int64) == 1
+	case sql.Boolean:
+		autocommit, _ := sql.ConvertToBool(val)
+		return autocommit
+	}
+
+	return false
+}
+
+// SetGrants limits the permissions of the session on each database and branch to the grants given.
+func (sess *DoltSession) SetGrants(grants Grants) {
+	sess.grants = grants
+}
+
+// SetHookConfig sets the config declaring the executables run as hooks by the commits of the session to the
+// repository given, and to the databases of its branches.
+func (sess *DoltSession) SetHookConfig(repoName string, cfg config.ReadableConfig) {
+	if sess.hookCfgs == nil {
+		sess.hookCfgs = make(map[string]config.ReadableConfig)
+	}
+
+	sess.hookCfgs[repoName] = cfg
+}
+
+// HookConfig returns the config declaring the executables run as hooks by the commits of the session to the database
+// given, which is nil if none was set.
+func (sess *DoltSession) HookConfig(dbName string) config.ReadableConfig {
+	return sess.hookCfgs[repoDbName(dbName)]
+}
+
+// CheckPermission returns an ErrPermissionDenied if the session wasn't granted the permission given on the branch
+// of the database given.
+func (sess *DoltSession) CheckPermission(dbName string, perm auth.Permission) error {
+	if sess.grants == nil {
+		return nil
+	}
+
+	dbData, ok := sess.dbDatas[dbName]
+
+	if !ok {
+		return sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	return sess.CheckBranchPermission(dbName, dbData.Rsr.CWBHeadRef().GetPath(), perm)
+}
+
+// CheckBranchPermission returns an ErrPermissionDenied if the session wasn't granted the permission given on a
+// branch of the database given.
+func (sess *DoltSession) CheckBranchPermission(dbName, branch string, perm auth.Permission) error {
+	dbName = repoDbName(dbName)
+
+	if sess.grants.Allowed(dbName, branch, perm) {
+		return nil
+	}
+
+	return ErrPermissionDenied.New(sess.Client().User, perm, branch, dbName)
 }
 
 // GetDoltDB returns the *DoltDB for a given database by name
@@ -191,6 +390,32 @@ func (sess *DoltSession) GetRoot(dbName string) (*doltdb.RootValue, bool) {
 	return dbRoot.root, true
 }
 
+// SetRoot sets a new working root value for the database with the name given. Can be used if the dolt working set
+// value changes outside of the basic SQL execution engine.
+func (sess *DoltSession) SetRoot(ctx *sql.Context, dbName string, newRoot *doltdb.RootValue) error {
+	h, err := newRoot.HashOf()
+
+	if err != nil {
+		return err
+	}
+
+	hashStr := h.String()
+	err = sess.Session.Set(ctx, dbName+WorkingKeySuffix, hashType, hashStr)
+
+	if err != nil {
+		return err
+	}
+
+	sess.dbRoots[dbName] = dbRoot{hashStr, newRoot}
+
+	err = sess.dbEditors[dbName].SetRoot(ctx, newRoot)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // GetParentCommit returns the parent commit of the current session.
 func (sess *DoltSession) GetParentCommit(ctx context.Context, dbName string) (*doltdb.Commit, hash.Hash, error) {
 	dbd, dbFound := sess.dbDatas[dbName]
@@ -312,9 +537,20 @@ func (sess *DoltSession) AddDB(ctx context.Context, db Database) error {
 	drw := db.GetDocsReadWriter()
 	ddb := db.GetDoltDB()
 
+	if bs, ok := rsr.(*branchRepoState); ok {
+		// each session keeps its own working set of a branch which isn't checked out
+		sessBs, err := newBranchRepoState(ctx, ddb, bs.branch)
+
+		if err != nil {
+			return err
+		}
+
+		rsr, rsw = sessBs, sessBs
+	}
+
 	sess.dbDatas[db.Name()] = env.DbData{Drw: drw, Rsr: rsr, Rsw: rsw, Ddb: ddb}
 
-	sess.dbEditors[db.Name()] = editor.CreateTableEditSession(nil, editor.TableEditSessionProps{})
+	sess.dbEditors[db.Name()] = editor.CreateTableEditSession(nil, editor.TableEditSessionProps{Checks: sqlutil.NewCheckEvaluator})
 
 	cs := rsr.CWBHeadSpec()
 
@@ -0,0 +1,240 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/analyzer"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// ErrSerializationFailure is returned when a transaction can't be committed because another transaction committed
+// conflicting changes to the same rows since it started.
+var ErrSerializationFailure = errors.NewKind("serialization failure: tables %s were changed by a concurrent transaction, retry the transaction")
+
+// ResolveTransactionsRule is the name of the analyzer rule which gives the queries of a DoltSession transaction
+// semantics. See ResolveTransactions.
+const ResolveTransactionsRule = "resolve_dolt_transactions"
+
+// txLocks holds a mutex for the working set of each database, keyed by txLockKey. It serializes the commits of the
+// transactions on a database, so that the working root a transaction is merged with can't change before the merged
+// root is written. Transactions on different databases are committed concurrently.
+var txLocks = &sync.Map{}
+
+// txLockKey identifies the working set of a database. The databases of a repository and of its checked out branch
+// share a working set, so they are identified by the branch rather than the database name.
+type txLockKey struct {
+	ddb    *doltdb.DoltDB
+	branch string
+}
+
+// txLock returns the mutex serializing the commits of the transactions on the working set of |dbData|.
+func txLock(dbData env.DbData) *sync.Mutex {
+	key := txLockKey{dbData.Ddb, dbData.Rsr.CWBHeadRef().String()}
+	mu, _ := txLocks.LoadOrStore(key, &sync.Mutex{})
+	return mu.(*sync.Mutex)
+}
+
+// DoltTransaction is a transaction on a single database of a DoltSession. It records the working root of the database
+// when it started, which is the common ancestor used to merge its changes with the changes committed by other
+// transactions in the meantime.
+type DoltTransaction struct {
+	startRoot *doltdb.RootValue
+	startHash hash.Hash
+	dbData    env.DbData
+	// explicit is true for transactions started with BEGIN, which are only committed by COMMIT
+	explicit bool
+}
+
+// NewDoltTransaction returns a new transaction starting from the root given.
+func NewDoltTransaction(startRoot *doltdb.RootValue, dbData env.DbData, explicit bool) (*DoltTransaction, error) {
+	h, err := startRoot.HashOf()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &DoltTransaction{startRoot: startRoot, startHash: h, dbData: dbData, explicit: explicit}, nil
+}
+
+// Commit writes the root given, which is the result of the transaction, as the working root of the database. If other
+// transactions changed the working root since this one started, the changes of both are three-way merged, and an
+// ErrSerializationFailure is returned if they edited the same rows or the schema of the same tables, whatever merge
+// strategies are declared. Commit returns the new working root.
+func (tx *DoltTransaction) Commit(ctx *sql.Context, newRoot *doltdb.RootValue) (*doltdb.RootValue, error) {
+	mu := txLock(tx.dbData)
+	mu.Lock()
+	defer mu.Unlock()
+
+	workingHash := tx.dbData.Rsr.WorkingHash()
+	newHash, err := newRoot.HashOf()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if newHash == tx.startHash || newHash == workingHash {
+		return tx.dbData.Ddb.ReadRootValue(ctx, workingHash)
+	}
+
+	if workingHash != tx.startHash {
+		workingRoot, err := tx.dbData.Ddb.ReadRootValue(ctx, workingHash)
+
+		if err != nil {
+			return nil, err
+		}
+
+		// merge strategies don't apply, as silently resolving concurrent edits of the same rows would lose one of them
+		mergedRoot, stats, err := merge.MergeRoots(ctx, workingRoot, newRoot, tx.startRoot, merge.MergeOpts{NoStrategies: true, Checks: sqlutil.NewCheckEvaluator})
+
+		if err != nil {
+			return nil, err
+		}
+
+		var conflicted []string
+		for tblName, tblStats := range stats {
+			if tblStats.HasConflicts() {
+				conflicted = append(conflicted, tblName)
+			}
+		}
+
+		if len(conflicted) > 0 {
+			sort.Strings(conflicted)
+			return nil, ErrSerializationFailure.New(strings.Join(conflicted, ", "))
+		}
+
+		newRoot = mergedRoot
+	}
+
+	h, err := tx.dbData.Ddb.WriteRootValue(ctx, newRoot)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.dbData.Rsw.SetWorkingHash(ctx, h)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return newRoot, nil
+}
+
+// ResolveTransactions is an analyzer rule which replaces the BEGIN, COMMIT and ROLLBACK statements, which are no-ops
+// in the engine, with statements acting on the transaction of the current database of a DoltSession. As it runs before
+// the tables of a query are resolved, it also begins each query in the transaction of the current database with
+// DoltSession.BeginStatement. Transactions are committed by DoltSession.CommitTransaction.
+func ResolveTransactions(ctx *sql.Context, _ *analyzer.Analyzer, n sql.Node, scope *analyzer.Scope) (sql.Node, error) {
+	// subqueries run in the transaction of the query they belong to
+	if scope != nil {
+		return n, nil
+	}
+
+	dSess, ok := ctx.Session.(*DoltSession)
+
+	if !ok {
+		return n, nil
+	}
+
+	dbName := ctx.GetCurrentDatabase()
+
+	if _, ok := dSess.dbDatas[dbName]; !ok {
+		return n, nil
+	}
+
+	err := dSess.BeginStatement(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.(type) {
+	case *plan.Begin, *plan.Commit, *plan.Rollback:
+		return &transactionStatement{stmt: n, dbName: dbName}, nil
+	}
+
+	return n, nil
+}
+
+// transactionStatement is a BEGIN, COMMIT or ROLLBACK statement on the transaction of a DoltSession.
+type transactionStatement struct {
+	stmt   sql.Node
+	dbName string
+}
+
+var _ sql.Node = (*transactionStatement)(nil)
+
+// RowIter implements the sql.Node interface.
+func (ts *transactionStatement) RowIter(ctx *sql.Context, _ sql.Row) (sql.RowIter, error) {
+	dSess := DSessFromSess(ctx.Session)
+
+	var err error
+	switch ts.stmt.(type) {
+	case *plan.Begin:
+		err = dSess.StartTransaction(ctx, ts.dbName)
+	case *plan.Commit:
+		// the transaction is committed by the CommitTransaction call which follows every COMMIT statement
+		dSess.endExplicitTransaction(ts.dbName)
+	case *plan.Rollback:
+		err = dSess.RollbackTransaction(ctx, ts.dbName)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return sql.RowsToRowIter(), nil
+}
+
+// String implements the sql.Node interface.
+func (ts *transactionStatement) String() string {
+	return ts.stmt.String()
+}
+
+// WithChildren implements the sql.Node interface.
+func (ts *transactionStatement) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 0 {
+		return nil, sql.ErrInvalidChildrenNumber.New(ts, len(children), 0)
+	}
+
+	return ts, nil
+}
+
+// Resolved implements the sql.Node interface.
+func (ts *transactionStatement) Resolved() bool {
+	return true
+}
+
+// Children implements the sql.Node interface.
+func (ts *transactionStatement) Children() []sql.Node {
+	return nil
+}
+
+// Schema implements the sql.Node interface.
+func (ts *transactionStatement) Schema() sql.Schema {
+	return nil
+}
@@ -33,12 +33,18 @@ var _ sql.ReplaceableTable = (*BranchesTable)(nil)
 
 // BranchesTable is a sql.Table implementation that implements a system table which shows the dolt branches
 type BranchesTable struct {
-	ddb *doltdb.DoltDB
+	ddb  *doltdb.DoltDB
+	auth BranchAuthorizer
+}
+
+// BranchAuthorizer checks that the session may write to a branch before it's created, moved or deleted.
+type BranchAuthorizer interface {
+	CheckBranchWrite(ctx *sql.Context, branch string) error
 }
 
 // NewBranchesTable creates a BranchesTable
-func NewBranchesTable(_ *sql.Context, ddb *doltdb.DoltDB) sql.Table {
-	return &BranchesTable{ddb}
+func NewBranchesTable(_ *sql.Context, ddb *doltdb.DoltDB, auth BranchAuthorizer) sql.Table {
+	return &BranchesTable{ddb, auth}
 }
 
 // Name is a sql.Table interface function which returns the name of the table which is defined by the constant
@@ -200,6 +206,12 @@ func (bWr branchWriter) Insert(ctx *sql.Context, r sql.Row) error {
 		return err
 	}
 
+	err = bWr.bt.auth.CheckBranchWrite(ctx, branchName)
+
+	if err != nil {
+		return err
+	}
+
 	cs, err := doltdb.NewCommitSpec(commitHash)
 
 	if err != nil {
@@ -232,6 +244,12 @@ func (bWr branchWriter) Delete(ctx *sql.Context, r sql.Row) error {
 		return err
 	}
 
+	err = bWr.bt.auth.CheckBranchWrite(ctx, branchName)
+
+	if err != nil {
+		return err
+	}
+
 	brRef := ref.NewBranchRef(branchName)
 	exists, err := bWr.bt.ddb.HasRef(ctx, brRef)
 
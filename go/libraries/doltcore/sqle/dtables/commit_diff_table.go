@@ -28,6 +28,7 @@ import (
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/rowconv"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
@@ -45,13 +46,13 @@ type CommitDiffTable struct {
 	ss                *schema.SuperSchema
 	joiner            *rowconv.Joiner
 	sqlSch            sql.Schema
-	workingRoot       *doltdb.RootValue
+	roots             RootResolver
 	fromCommitFilter  *expression.Equals
 	toCommitFilter    *expression.Equals
 	requiredFilterErr error
 }
 
-func NewCommitDiffTable(ctx *sql.Context, tblName string, ddb *doltdb.DoltDB, root *doltdb.RootValue) (sql.Table, error) {
+func NewCommitDiffTable(ctx *sql.Context, tblName string, ddb *doltdb.DoltDB, root *doltdb.RootValue, rsr env.RepoStateReader, auth CommitAuthorizer) (sql.Table, error) {
 	diffTblName := doltdb.DoltCommitDiffTablePrefix + tblName
 
 	ss, err := calcSuperDuperSchema(ctx, ddb, root, tblName)
@@ -60,17 +61,36 @@ func NewCommitDiffTable(ctx *sql.Context, tblName string, ddb *doltdb.DoltDB, ro
 		return nil, err
 	}
 
+	j, sqlSch, err := newDiffJoiner(ctx, ss, diffTblName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &CommitDiffTable{
+		name:   tblName,
+		ddb:    ddb,
+		roots:  NewRootResolver(ddb, rsr, root, auth),
+		ss:     ss,
+		joiner: j,
+		sqlSch: sqlSch,
+	}, nil
+}
+
+// newDiffJoiner adds the commit columns to the super schema given, and returns the joiner of its from_ and to_ rows
+// along with the sql.Schema of a diff table over it.
+func newDiffJoiner(ctx *sql.Context, ss *schema.SuperSchema, diffTblName string) (*rowconv.Joiner, sql.Schema, error) {
 	_ = ss.AddColumn(schema.NewColumn("commit", schema.DiffCommitTag, types.StringKind, false))
 	_ = ss.AddColumn(schema.NewColumn("commit_date", schema.DiffCommitDateTag, types.TimestampKind, false))
 
 	sch, err := ss.GenerateSchema()
 
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if sch.GetAllCols().Size() <= 1 {
-		return nil, sql.ErrTableNotFound.New(diffTblName)
+		return nil, nil, sql.ErrTableNotFound.New(diffTblName)
 	}
 
 	j, err := rowconv.NewJoiner(
@@ -81,19 +101,19 @@ func NewCommitDiffTable(ctx *sql.Context, tblName string, ddb *doltdb.DoltDB, ro
 		})
 
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	sqlSch, err := sqlutil.FromDoltSchema(diffTblName, j.GetSchema())
 
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// parses to literal, no need to pass through analyzer
 	defaultVal, err := parse.StringToColumnDefaultValue(ctx, fmt.Sprintf(`"%s"`, diffTypeModified))
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	sqlSch = append(sqlSch, &sql.Column{
@@ -104,14 +124,7 @@ func NewCommitDiffTable(ctx *sql.Context, tblName string, ddb *doltdb.DoltDB, ro
 		Source:   diffTblName,
 	})
 
-	return &CommitDiffTable{
-		name:        tblName,
-		ddb:         ddb,
-		workingRoot: root,
-		ss:          ss,
-		joiner:      j,
-		sqlSch:      sqlSch,
-	}, nil
+	return j, sqlSch, nil
 }
 
 func calcSuperDuperSchema(ctx context.Context, ddb *doltdb.DoltDB, working *doltdb.RootValue, tblName string) (*schema.SuperSchema, error) {
@@ -273,37 +286,10 @@ func (dt *CommitDiffTable) rootValForFilter(ctx *sql.Context, eqFilter *expressi
 		return nil, "", nil, fmt.Errorf("received '%v' when expecting commit hash string", val)
 	}
 
-	var root *doltdb.RootValue
-	var commitTime *types.Timestamp
-	if strings.ToLower(hashStr) == "working" {
-		root = dt.workingRoot
-	} else {
-		cs, err := doltdb.NewCommitSpec(hashStr)
-
-		if err != nil {
-			return nil, "", nil, err
-		}
-
-		cm, err := dt.ddb.Resolve(ctx, cs, nil)
-
-		if err != nil {
-			return nil, "", nil, err
-		}
-
-		root, err = cm.GetRootValue()
-
-		if err != nil {
-			return nil, "", nil, err
-		}
-
-		meta, err := cm.GetCommitMeta()
-
-		if err != nil {
-			return nil, "", nil, err
-		}
+	root, commitTime, err := dt.roots.Resolve(ctx, hashStr)
 
-		t := meta.Time()
-		commitTime = (*types.Timestamp)(&t)
+	if err != nil {
+		return nil, "", nil, err
 	}
 
 	return root, hashStr, commitTime, nil
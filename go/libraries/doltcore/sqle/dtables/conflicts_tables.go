@@ -15,6 +15,8 @@ package dtables
 // limitations under the License.
 
 import (
+	"strings"
+
 	"github.com/dolthub/go-mysql-server/sql"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
@@ -25,6 +27,9 @@ import (
 
 var _ sql.Table = ConflictsTable{}
 
+// ConflictColumnsCol is the name of the column of a conflicts table which lists the columns whose cells are in conflict
+const ConflictColumnsCol = "conflict_columns"
+
 // ConflictsTable is a sql.Table implementation that provides access to the conflicts that exist for a user table
 type ConflictsTable struct {
 	tblName string
@@ -61,6 +66,13 @@ func NewConflictsTable(ctx *sql.Context, tblName string, root *doltdb.RootValue,
 		return nil, err
 	}
 
+	sqlSch = append(sqlSch, &sql.Column{
+		Name:     ConflictColumnsCol,
+		Type:     sql.LongText,
+		Nullable: true,
+		Source:   doltdb.DoltConfTablePrefix + tblName,
+	})
+
 	return ConflictsTable{
 		tblName: tblName,
 		sqlSch:  sqlSch,
@@ -110,13 +122,24 @@ type conflictRowIter struct {
 // Next retrieves the next row. It will return io.EOF if it's the last row.
 // After retrieving the last row, Close will be automatically closed.
 func (itr conflictRowIter) Next() (sql.Row, error) {
-	cnf, _, err := itr.rd.NextConflict(itr.ctx)
+	cnf, cnfCols, err := itr.rd.NextConflictWithColumns(itr.ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := sqlutil.DoltRowToSqlRow(cnf, itr.rd.GetSchema())
 
 	if err != nil {
 		return nil, err
 	}
 
-	return sqlutil.DoltRowToSqlRow(cnf, itr.rd.GetSchema())
+	var cnfColsVal interface{}
+	if len(cnfCols) > 0 {
+		cnfColsVal = strings.Join(cnfCols, ",")
+	}
+
+	return append(r, cnfColsVal), nil
 }
 
 // Close the iterator.
@@ -137,7 +160,8 @@ type conflictDeleter struct {
 // Close is called.
 func (cd *conflictDeleter) Delete(ctx *sql.Context, r sql.Row) error {
 	cnfSch := cd.ct.rd.GetSchema()
-	cnfRow, err := sqlutil.SqlRowToDoltRow(cd.ct.tbl.Format(), r, cnfSch)
+	// the last column lists the conflicting columns, and is not part of the conflict row
+	cnfRow, err := sqlutil.SqlRowToDoltRow(ctx, cd.ct.tbl.ValueReadWriter(), r[:len(r)-1], cnfSch)
 
 	if err != nil {
 		return err
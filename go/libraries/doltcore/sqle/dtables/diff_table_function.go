@@ -0,0 +1,146 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/rowconv"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+var _ sql.Table = (*DiffTableFunction)(nil)
+
+// DiffTableFunction is the table returned by DOLT_DIFF(table, from_ref, to_ref). It has the columns of a
+// dolt_commit_diff_<table> table, and contains the row diff of the table between the two commit specs it was created
+// with.
+type DiffTableFunction struct {
+	name     string
+	ddb      *doltdb.DoltDB
+	ss       *schema.SuperSchema
+	joiner   *rowconv.Joiner
+	sqlSch   sql.Schema
+	fromSpec string
+	toSpec   string
+	from     *doltdb.Table
+	to       *doltdb.Table
+	fromDate *types.Timestamp
+	toDate   *types.Timestamp
+}
+
+// NewDiffTableFunction creates a DiffTableFunction for the table given between fromSpec and toSpec, which are resolved
+// with the RootResolver given.
+func NewDiffTableFunction(ctx *sql.Context, tblName, fromSpec, toSpec string, ddb *doltdb.DoltDB, roots RootResolver) (sql.Table, error) {
+	fromRoot, fromDate, err := roots.Resolve(ctx, fromSpec)
+
+	if err != nil {
+		return nil, err
+	}
+
+	toRoot, toDate, err := roots.Resolve(ctx, toSpec)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var superSchemas []*schema.SuperSchema
+	var tables []*doltdb.Table
+	for _, root := range []*doltdb.RootValue{fromRoot, toRoot} {
+		tbl, exactName, ok, err := root.GetTableInsensitive(ctx, tblName)
+
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			tables = append(tables, nil)
+			continue
+		}
+
+		ss, _, err := root.GetSuperSchema(ctx, exactName)
+
+		if err != nil {
+			return nil, err
+		}
+
+		superSchemas = append(superSchemas, ss)
+		tables = append(tables, tbl)
+	}
+
+	if len(superSchemas) == 0 {
+		return nil, sql.ErrTableNotFound.New(tblName)
+	}
+
+	ss, err := schema.SuperSchemaUnion(superSchemas...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	j, sqlSch, err := newDiffJoiner(ctx, ss, doltdb.DiffTableName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &DiffTableFunction{
+		name:     tblName,
+		ddb:      ddb,
+		ss:       ss,
+		joiner:   j,
+		sqlSch:   sqlSch,
+		fromSpec: fromSpec,
+		toSpec:   toSpec,
+		from:     tables[0],
+		to:       tables[1],
+		fromDate: fromDate,
+		toDate:   toDate,
+	}, nil
+}
+
+// Name returns the name of the table
+func (dtf *DiffTableFunction) Name() string {
+	return doltdb.DiffTableName
+}
+
+// String returns the DOLT_DIFF() call the table was created from
+func (dtf *DiffTableFunction) String() string {
+	return fmt.Sprintf("DOLT_DIFF('%s', '%s', '%s')", dtf.name, dtf.fromSpec, dtf.toSpec)
+}
+
+// Schema returns the sql.Schema of the table
+func (dtf *DiffTableFunction) Schema() sql.Schema {
+	return dtf.sqlSch
+}
+
+// Partitions returns the single partition of the table
+func (dtf *DiffTableFunction) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return NewSliceOfPartitionsItr([]sql.Partition{diffPartition{
+		to:       dtf.to,
+		from:     dtf.from,
+		toName:   dtf.toSpec,
+		fromName: dtf.fromSpec,
+		toDate:   dtf.toDate,
+		fromDate: dtf.fromDate,
+	}}), nil
+}
+
+// PartitionRows returns the diff rows of the partition given
+func (dtf *DiffTableFunction) PartitionRows(ctx *sql.Context, part sql.Partition) (sql.RowIter, error) {
+	dp := part.(diffPartition)
+	return dp.getRowIter(ctx, dtf.ddb, dtf.ss, dtf.joiner)
+}
@@ -0,0 +1,95 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+const (
+	workingSpec = "working"
+	stagedSpec  = "staged"
+)
+
+// CommitAuthorizer checks that the session may read the commit a commit spec given to a diff table resolved to.
+type CommitAuthorizer interface {
+	CheckCommitRead(ctx *sql.Context, spec string, cm *doltdb.Commit) error
+}
+
+// RootResolver resolves the commit specs given to the diff tables to the root values they refer to. Besides anything
+// parsed by doltdb.NewCommitSpec, the specs WORKING and STAGED refer to the working and staged roots.
+type RootResolver struct {
+	ddb     *doltdb.DoltDB
+	rsr     env.RepoStateReader
+	working *doltdb.RootValue
+	auth    CommitAuthorizer
+}
+
+// NewRootResolver creates a RootResolver. HEAD is resolved relative to the current branch of the RepoStateReader
+// given, and every commit resolved must be allowed by the CommitAuthorizer given.
+func NewRootResolver(ddb *doltdb.DoltDB, rsr env.RepoStateReader, working *doltdb.RootValue, auth CommitAuthorizer) RootResolver {
+	return RootResolver{ddb: ddb, rsr: rsr, working: working, auth: auth}
+}
+
+// Resolve returns the root value the commit spec given refers to, along with the date of its commit. The date is nil
+// for the working and staged roots.
+func (rr RootResolver) Resolve(ctx *sql.Context, spec string) (*doltdb.RootValue, *types.Timestamp, error) {
+	switch strings.ToLower(spec) {
+	case workingSpec:
+		return rr.working, nil, nil
+	case stagedSpec:
+		root, err := env.StagedRoot(ctx, rr.ddb, rr.rsr)
+		return root, nil, err
+	}
+
+	cs, err := doltdb.NewCommitSpec(spec)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cm, err := rr.ddb.Resolve(ctx, cs, rr.rsr.CWBHeadRef())
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = rr.auth.CheckCommitRead(ctx, spec, cm)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root, err := cm.GetRootValue()
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	meta, err := cm.GetCommitMeta()
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	t := meta.Time()
+	return root, (*types.Timestamp)(&t), nil
+}
@@ -0,0 +1,147 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlfmt"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+)
+
+var _ sql.DeletableTable = SchemaConflictsTable{}
+
+// SchemaConflictsTable is a sql.Table implementation that implements a system table which shows the tables whose
+// schemas could not be merged. Deleting a row resolves the schema conflict of that table by keeping our schema and rows.
+// DOLT_RESOLVE_SCHEMA_CONFLICTS('--theirs', <table>) resolves it by taking their table instead.
+type SchemaConflictsTable struct {
+	root *doltdb.RootValue
+	rs   RootSetter
+}
+
+// NewSchemaConflictsTable creates a SchemaConflictsTable
+func NewSchemaConflictsTable(_ *sql.Context, root *doltdb.RootValue, rs RootSetter) sql.Table {
+	return SchemaConflictsTable{root: root, rs: rs}
+}
+
+// Name is a sql.Table interface function which returns the name of the table which is defined by the constant
+// SchemaConflictsTableName
+func (dt SchemaConflictsTable) Name() string {
+	return doltdb.SchemaConflictsTableName
+}
+
+// String is a sql.Table interface function which returns the name of the table which is defined by the constant
+// SchemaConflictsTableName
+func (dt SchemaConflictsTable) String() string {
+	return doltdb.SchemaConflictsTableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the schema conflicts system table.
+func (dt SchemaConflictsTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "table_name", Type: sql.Text, Source: doltdb.SchemaConflictsTableName, PrimaryKey: true},
+		{Name: "base_schema", Type: sql.LongText, Source: doltdb.SchemaConflictsTableName, PrimaryKey: false},
+		{Name: "our_schema", Type: sql.LongText, Source: doltdb.SchemaConflictsTableName, PrimaryKey: false},
+		{Name: "their_schema", Type: sql.LongText, Source: doltdb.SchemaConflictsTableName, PrimaryKey: false},
+		{Name: "description", Type: sql.LongText, Source: doltdb.SchemaConflictsTableName, PrimaryKey: false},
+	}
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data.
+func (dt SchemaConflictsTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return sqlutil.NewSinglePartitionIter(), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (dt SchemaConflictsTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	tblNames, err := dt.root.TablesWithSchemaConflicts(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []sql.Row
+	for _, tblName := range tblNames {
+		tbl, _, err := dt.root.GetTable(ctx, tblName)
+
+		if err != nil {
+			return nil, err
+		}
+
+		baseSch, ourSch, theirSch, desc, err := tbl.GetSchemaConflict(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		rows = append(rows, sql.NewRow(
+			tblName,
+			sqlfmt.CreateTableStmt(tblName, baseSch),
+			sqlfmt.CreateTableStmt(tblName, ourSch),
+			sqlfmt.CreateTableStmt(tblName, theirSch),
+			desc,
+		))
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// Deleter returns a RowDeleter which marks the schema conflicts of the tables deleted as resolved.
+func (dt SchemaConflictsTable) Deleter(*sql.Context) sql.RowDeleter {
+	return &schemaConflictDeleter{dt: dt}
+}
+
+var _ sql.RowDeleter = &schemaConflictDeleter{}
+
+type schemaConflictDeleter struct {
+	dt       SchemaConflictsTable
+	tblNames []string
+}
+
+// Delete deletes the given row. Delete will be called once for each row to process for the delete operation, which
+// may involve many rows. After all rows have been processed, Close is called.
+func (cd *schemaConflictDeleter) Delete(_ *sql.Context, r sql.Row) error {
+	cd.tblNames = append(cd.tblNames, r[0].(string))
+	return nil
+}
+
+// Close finalizes the delete operation, persisting the result.
+func (cd *schemaConflictDeleter) Close(ctx *sql.Context) error {
+	root := cd.dt.root
+	for _, tblName := range cd.tblNames {
+		tbl, ok, err := root.GetTable(ctx, tblName)
+
+		if err != nil {
+			return err
+		} else if !ok {
+			continue
+		}
+
+		tbl, err = tbl.ClearSchemaConflict()
+
+		if err != nil {
+			return err
+		}
+
+		root, err = root.PutTable(ctx, tblName, tbl)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return cd.dt.rs.SetRoot(ctx, root)
+}
@@ -0,0 +1,268 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"context"
+	"sort"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+)
+
+const workingCommitHash = "WORKING"
+
+var _ sql.Table = (*UnscopedDiffTable)(nil)
+
+// UnscopedDiffTable is a sql.Table implementation of a system table which shows, for every commit in the history of
+// the current head, the tables whose data or schema changed in that commit compared to its first parent. Uncommitted
+// changes in the working set are listed with the commit hash WORKING.
+type UnscopedDiffTable struct {
+	ddb         *doltdb.DoltDB
+	head        *doltdb.Commit
+	workingRoot *doltdb.RootValue
+}
+
+// NewUnscopedDiffTable creates an UnscopedDiffTable
+func NewUnscopedDiffTable(_ *sql.Context, ddb *doltdb.DoltDB, head *doltdb.Commit, workingRoot *doltdb.RootValue) sql.Table {
+	return &UnscopedDiffTable{ddb: ddb, head: head, workingRoot: workingRoot}
+}
+
+// Name is a sql.Table interface function which returns the name of the table which is defined by the constant
+// DiffTableName
+func (dt *UnscopedDiffTable) Name() string {
+	return doltdb.DiffTableName
+}
+
+// String is a sql.Table interface function which returns the name of the table which is defined by the constant
+// DiffTableName
+func (dt *UnscopedDiffTable) String() string {
+	return doltdb.DiffTableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the dolt_diff system table.
+func (dt *UnscopedDiffTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "commit_hash", Type: sql.Text, Source: doltdb.DiffTableName, PrimaryKey: true},
+		{Name: "table_name", Type: sql.Text, Source: doltdb.DiffTableName, PrimaryKey: true},
+		{Name: "committer", Type: sql.Text, Source: doltdb.DiffTableName, PrimaryKey: false, Nullable: true},
+		{Name: "email", Type: sql.Text, Source: doltdb.DiffTableName, PrimaryKey: false, Nullable: true},
+		{Name: "date", Type: sql.Datetime, Source: doltdb.DiffTableName, PrimaryKey: false, Nullable: true},
+		{Name: "message", Type: sql.Text, Source: doltdb.DiffTableName, PrimaryKey: false, Nullable: true},
+		{Name: "data_change", Type: sql.Boolean, Source: doltdb.DiffTableName, PrimaryKey: false},
+		{Name: "schema_change", Type: sql.Boolean, Source: doltdb.DiffTableName, PrimaryKey: false},
+		{Name: "rows_added", Type: sql.Uint64, Source: doltdb.DiffTableName, PrimaryKey: false},
+		{Name: "rows_deleted", Type: sql.Uint64, Source: doltdb.DiffTableName, PrimaryKey: false},
+		{Name: "rows_modified", Type: sql.Uint64, Source: doltdb.DiffTableName, PrimaryKey: false},
+	}
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data. Currently the data is
+// unpartitioned.
+func (dt *UnscopedDiffTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return sqlutil.NewSinglePartitionIter(), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (dt *UnscopedDiffTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	return newUnscopedDiffRowItr(ctx, dt.ddb, dt.head, dt.workingRoot)
+}
+
+// unscopedDiffRowItr is a sql.RowIter which walks the commit graph and returns a row for every table changed by each
+// commit. The rows of a commit are computed when the iterator reaches it.
+type unscopedDiffRowItr struct {
+	ctx   *sql.Context
+	ddb   *doltdb.DoltDB
+	cmItr doltdb.CommitItr
+	rows  []sql.Row
+}
+
+func newUnscopedDiffRowItr(ctx *sql.Context, ddb *doltdb.DoltDB, head *doltdb.Commit, workingRoot *doltdb.RootValue) (*unscopedDiffRowItr, error) {
+	headRoot, err := head.GetRootValue()
+
+	if err != nil {
+		return nil, err
+	}
+
+	itr := &unscopedDiffRowItr{ctx: ctx, ddb: ddb, cmItr: doltdb.CommitItrForRoots(ddb, head)}
+	itr.rows, err = itr.rowsForDeltas(headRoot, workingRoot, func(tblName string) sql.Row {
+		return sql.NewRow(workingCommitHash, tblName, nil, nil, nil, nil)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return itr, nil
+}
+
+// Next retrieves the next row. It will return io.EOF if it's the last row.
+func (itr *unscopedDiffRowItr) Next() (sql.Row, error) {
+	for len(itr.rows) == 0 {
+		err := itr.loadNextCommit()
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	r := itr.rows[0]
+	itr.rows = itr.rows[1:]
+
+	return r, nil
+}
+
+// loadNextCommit fills the row buffer with the changes made by the next commit of the history. The initial commit of
+// a history has nothing to compare to and is skipped.
+func (itr *unscopedDiffRowItr) loadNextCommit() error {
+	h, cm, err := itr.cmItr.Next(itr.ctx)
+
+	if err != nil {
+		return err
+	}
+
+	numParents, err := cm.NumParents()
+
+	if err != nil {
+		return err
+	} else if numParents == 0 {
+		return nil
+	}
+
+	parent, err := itr.ddb.ResolveParent(itr.ctx, cm, 0)
+
+	if err != nil {
+		return err
+	}
+
+	parentRoot, err := parent.GetRootValue()
+
+	if err != nil {
+		return err
+	}
+
+	root, err := cm.GetRootValue()
+
+	if err != nil {
+		return err
+	}
+
+	meta, err := cm.GetCommitMeta()
+
+	if err != nil {
+		return err
+	}
+
+	itr.rows, err = itr.rowsForDeltas(parentRoot, root, func(tblName string) sql.Row {
+		return sql.NewRow(h.String(), tblName, meta.Name, meta.Email, meta.Time(), meta.Description)
+	})
+
+	return err
+}
+
+// rowsForDeltas returns a row for each table changed between fromRoot and toRoot. newRow supplies the commit columns
+// of each row, and the change columns are appended to it.
+func (itr *unscopedDiffRowItr) rowsForDeltas(fromRoot, toRoot *doltdb.RootValue, newRow func(tblName string) sql.Row) ([]sql.Row, error) {
+	deltas, err := diff.GetTableDeltas(itr.ctx, fromRoot, toRoot)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(deltas, func(i, j int) bool {
+		return deltas[i].CurName() < deltas[j].CurName()
+	})
+
+	rows := make([]sql.Row, 0, len(deltas))
+	for _, td := range deltas {
+		schemaChange, err := isSchemaChange(td)
+
+		if err != nil {
+			return nil, err
+		}
+
+		fromRows, toRows, err := td.GetMaps(itr.ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		dataChange := !fromRows.Equals(toRows)
+
+		var summary diff.DiffSummaryProgress
+		if dataChange {
+			summary, err = summarizeRowChanges(itr.ctx, td)
+
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		r := newRow(td.CurName())
+		r = append(r, dataChange, schemaChange, summary.Adds, summary.Removes, summary.Changes)
+		rows = append(rows, r)
+	}
+
+	return rows, nil
+}
+
+// Close closes the iterator.
+func (itr *unscopedDiffRowItr) Close() error {
+	return nil
+}
+
+// isSchemaChange returns whether the table of the delta given was created, dropped, renamed, or had its schema or
+// foreign keys altered.
+func isSchemaChange(td diff.TableDelta) (bool, error) {
+	if td.IsAdd() || td.IsDrop() || td.IsRename() || td.HasFKChanges() {
+		return true, nil
+	}
+
+	sameSchema, err := td.FromTable.HasTheSameSchema(td.ToTable)
+
+	if err != nil {
+		return false, err
+	}
+
+	return !sameSchema, nil
+}
+
+// summarizeRowChanges returns the total number of rows added, removed and modified in the table of the delta given.
+func summarizeRowChanges(ctx context.Context, td diff.TableDelta) (diff.DiffSummaryProgress, error) {
+	ch := make(chan diff.DiffSummaryProgress)
+	eg, egCtx := errgroup.WithContext(ctx)
+
+	eg.Go(func() error {
+		defer close(ch)
+		return diff.SummaryForTableDelta(egCtx, ch, td)
+	})
+
+	var acc diff.DiffSummaryProgress
+	for p := range ch {
+		acc.Adds += p.Adds
+		acc.Removes += p.Removes
+		acc.Changes += p.Changes
+	}
+
+	if err := eg.Wait(); err != nil {
+		return diff.DiffSummaryProgress{}, err
+	}
+
+	return acc, nil
+}
@@ -0,0 +1,140 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+)
+
+// emptySystemTableSchemas are the schemas of the writeable system tables which are created on their first insert
+var emptySystemTableSchemas = map[string]schema.Schema{
+	doltdb.MergeStrategiesTableName:  merge.MergeStrategiesSchema,
+	doltdb.BranchProtectionTableName: actions.BranchProtectionSchema,
+	doltdb.HooksTableName:            actions.HooksSchema,
+}
+
+var _ sql.InsertableTable = emptySystemTable{}
+
+// emptySystemTable is a writeable system table, such as `dolt_merge_strategies`, which does not exist in a database
+// yet. It has no rows, and creates the table on the first insert.
+type emptySystemTable struct {
+	db     Database
+	name   string
+	sch    schema.Schema
+	sqlSch sql.Schema
+}
+
+func newEmptySystemTable(db Database, name string, sch schema.Schema) (sql.Table, error) {
+	sqlSch, err := sqlutil.FromDoltSchema(name, sch)
+	if err != nil {
+		return nil, err
+	}
+
+	return emptySystemTable{db, name, sch, sqlSch}, nil
+}
+
+// Name returns the name of the table
+func (t emptySystemTable) Name() string {
+	return t.name
+}
+
+// String returns a string identifying the table
+func (t emptySystemTable) String() string {
+	return t.name
+}
+
+// Schema returns the sql.Schema of the table
+func (t emptySystemTable) Schema() sql.Schema {
+	return t.sqlSch
+}
+
+// Partitions returns a PartitionIter which can be used to get all the data partitions
+func (t emptySystemTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return sqlutil.NewSinglePartitionIter(), nil
+}
+
+// PartitionRows returns a RowIter for the given partition
+func (t emptySystemTable) PartitionRows(*sql.Context, sql.Partition) (sql.RowIter, error) {
+	return sql.RowsToRowIter(), nil
+}
+
+// Inserter returns a RowInserter which creates the table before inserting the first row
+func (t emptySystemTable) Inserter(*sql.Context) sql.RowInserter {
+	return &emptySystemTableInserter{table: t}
+}
+
+type emptySystemTableInserter struct {
+	table    emptySystemTable
+	inserter sql.RowInserter
+}
+
+// Insert inserts the row given, creating the table if needed
+func (ei *emptySystemTableInserter) Insert(ctx *sql.Context, r sql.Row) error {
+	if ei.inserter == nil {
+		db := ei.table.db
+
+		// batched edits of other tables must be written before the root is replaced by the one with the new table
+		err := db.Flush(ctx)
+		if err != nil {
+			return err
+		}
+
+		root, err := db.GetRoot(ctx)
+		if err != nil {
+			return err
+		}
+
+		err = db.createDoltTable(ctx, ei.table.name, root, ei.table.sch)
+		if err != nil {
+			return err
+		}
+
+		root, err = db.GetRoot(ctx)
+		if err != nil {
+			return err
+		}
+
+		tbl, found, err := db.GetTableInsensitiveWithRoot(ctx, root, ei.table.name)
+		if err != nil {
+			return err
+		}
+
+		wtbl, ok := tbl.(*WritableDoltTable)
+		if !found || !ok {
+			return fmt.Errorf("could not create the `%s` table", ei.table.name)
+		}
+
+		ei.inserter = wtbl.Inserter(ctx)
+	}
+
+	return ei.inserter.Insert(ctx, r)
+}
+
+// Close finalizes the insert operation, persisting the result.
+func (ei *emptySystemTableInserter) Close(ctx *sql.Context) error {
+	if ei.inserter == nil {
+		return nil
+	}
+
+	return ei.inserter.Close(ctx)
+}
@@ -240,3 +240,26 @@ func TestColumnDefaults(t *testing.T) {
 func TestTriggers(t *testing.T) {
 	enginetest.TestTriggers(t, newDoltHarness(t))
 }
+
+func TestDoltDiffTableFunction(t *testing.T) {
+	enginetest.TestScript(t, newDoltHarness(t), enginetest.ScriptTest{
+		Name: "DOLT_DIFF between HEAD and WORKING",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk INT PRIMARY KEY, c INT)",
+			"INSERT INTO t VALUES (1, 10), (2, 20)",
+		},
+		Assertions: []enginetest.ScriptTestAssertion{
+			{
+				Query: "SELECT to_pk, to_c, from_pk, diff_type FROM `DOLT_DIFF('t', 'HEAD', 'WORKING')` ORDER BY to_pk",
+				Expected: []sql.Row{
+					{int32(1), int32(10), nil, "added"},
+					{int32(2), int32(20), nil, "added"},
+				},
+			},
+			{
+				Query:    "SELECT COUNT(*) FROM `dolt_diff('t', 'WORKING', 'WORKING')` d WHERE d.diff_type = 'modified'",
+				Expected: []sql.Row{{int64(0)}},
+			},
+		},
+	})
+}
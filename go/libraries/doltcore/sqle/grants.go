@@ -0,0 +1,70 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/auth"
+	"gopkg.in/src-d/go-errors.v1"
+)
+
+// GrantWildcard matches the name of any database or branch in a Grant.
+const GrantWildcard = "*"
+
+// ErrPermissionDenied is returned when a session reads or writes a branch of a database it wasn't granted access to.
+var ErrPermissionDenied = errors.NewKind("user '%s' does not have %s permission on branch '%s' of database '%s'")
+
+// ErrCommitPermissionDenied is returned when a session reads a commit, given by its hash or by a ref which isn't a
+// branch, which isn't part of any branch of the database it may read.
+var ErrCommitPermissionDenied = errors.NewKind("user '%s' does not have %s permission on a branch containing commit '%s' of database '%s'")
+
+// Grant gives permissions on a database, or on a single branch of a database.
+type Grant struct {
+	// Database is the name of the database, or GrantWildcard for every database.
+	Database string
+	// Branch is the name of the branch, or GrantWildcard for every branch. An empty Branch is the same as
+	// GrantWildcard.
+	Branch      string
+	Permissions auth.Permission
+}
+
+func (g Grant) matches(dbName, branch string) bool {
+	if g.Database != GrantWildcard && !strings.EqualFold(g.Database, dbName) {
+		return false
+	}
+
+	return g.Branch == "" || g.Branch == GrantWildcard || g.Branch == branch
+}
+
+// Grants are all the grants of a user. A nil Grants places no restrictions, while an empty one gives no permissions.
+type Grants []Grant
+
+// Allowed returns whether the grants give the permission given on a branch of a database. Permissions given by
+// different grants add up.
+func (g Grants) Allowed(dbName, branch string, perm auth.Permission) bool {
+	if g == nil {
+		return true
+	}
+
+	var granted auth.Permission
+	for _, grant := range g {
+		if grant.matches(dbName, branch) {
+			granted |= grant.Permissions
+		}
+	}
+
+	return granted&perm == perm
+}
@@ -0,0 +1,91 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/auth"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+)
+
+func TestGrantsAllowed(t *testing.T) {
+	grants := Grants{
+		{Database: "db1", Branch: "master", Permissions: auth.ReadPerm},
+		{Database: "DB1", Branch: "feature", Permissions: auth.AllPermissions},
+		{Database: GrantWildcard, Branch: "shared", Permissions: auth.ReadPerm},
+		{Database: "db2", Permissions: auth.ReadPerm},
+		{Database: "db2", Branch: GrantWildcard, Permissions: auth.WritePerm},
+	}
+
+	tests := []struct {
+		dbName   string
+		branch   string
+		perm     auth.Permission
+		expected bool
+	}{
+		{"db1", "master", auth.ReadPerm, true},
+		{"db1", "master", auth.WritePerm, false},
+		{"db1", "feature", auth.AllPermissions, true},
+		{"db1", "other", auth.ReadPerm, false},
+		{"db3", "shared", auth.ReadPerm, true},
+		{"db3", "master", auth.ReadPerm, false},
+		{"db2", "any", auth.AllPermissions, true},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, grants.Allowed(test.dbName, test.branch, test.perm), "%s %s %s", test.dbName, test.branch, test.perm)
+	}
+
+	assert.True(t, Grants(nil).Allowed("db1", "master", auth.AllPermissions))
+	assert.False(t, Grants{}.Allowed("db1", "master", auth.ReadPerm))
+}
+
+func TestSessionGrants(t *testing.T) {
+	ctx := NewTestSQLCtx(context.Background())
+	dEnv := dtestutils.CreateTestEnv()
+	db := NewDatabase("dolt", dEnv.DbData())
+	dSess := DSessFromSess(ctx.Session)
+	err := dSess.AddDB(ctx, db)
+	require.NoError(t, err)
+	ctx.SetCurrentDatabase(db.Name())
+
+	err = db.CreateTable(ctx, "test", sql.Schema{{Name: "pk", Type: sql.Int64, Source: "test", PrimaryKey: true}})
+	require.NoError(t, err)
+
+	dSess.SetGrants(Grants{{Database: "dolt", Branch: "master", Permissions: auth.ReadPerm}})
+
+	_, found, err := db.GetTableInsensitive(ctx, "test")
+	require.NoError(t, err)
+	assert.True(t, found)
+
+	err = db.DropTable(ctx, "test")
+	require.Error(t, err)
+	assert.True(t, ErrPermissionDenied.Is(err))
+
+	dSess.SetGrants(Grants{{Database: "dolt", Branch: "feature", Permissions: auth.AllPermissions}})
+
+	_, _, err = db.GetTableInsensitive(ctx, "test")
+	require.Error(t, err)
+	assert.True(t, ErrPermissionDenied.Is(err))
+
+	_, err = db.GetTableNames(ctx)
+	assert.True(t, ErrPermissionDenied.Is(err))
+}
@@ -0,0 +1,84 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"io"
+
+	sqle "github.com/dolthub/go-mysql-server"
+	"github.com/dolthub/go-mysql-server/auth"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/analyzer"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+)
+
+// NewHookQueryFunc returns an actions.HookQueryFunc which runs the queries of hooks in a new session, against a root
+// value of the database given. Hooks are read only: the session is only granted the read permission, so queries which
+// would change the root value, the branches, or the repo state of the database fail.
+func NewHookQueryFunc(name string, dbData env.DbData) actions.HookQueryFunc {
+	return func(ctx context.Context, root *doltdb.RootValue, query string) (int, error) {
+		db := NewDatabase(name, dbData)
+		c := sql.NewCatalog()
+		engine := sqle.New(c, analyzer.NewDefault(c), &sqle.Config{Auth: auth.NewNativeSingle("", "", auth.ReadPerm)})
+		engine.AddDatabase(db)
+
+		sqlCtx := sql.NewContext(
+			ctx,
+			sql.WithSession(DefaultDoltSession()),
+			sql.WithIndexRegistry(sql.NewIndexRegistry()),
+			sql.WithViewRegistry(sql.NewViewRegistry()),
+		).WithCurrentDB(name)
+
+		err := DSessFromSess(sqlCtx.Session).AddDB(sqlCtx, db)
+		if err != nil {
+			return 0, err
+		}
+
+		err = db.SetRoot(sqlCtx, root)
+		if err != nil {
+			return 0, err
+		}
+
+		err = RegisterSchemaFragments(sqlCtx, db, root)
+		if err != nil {
+			return 0, err
+		}
+
+		DSessFromSess(sqlCtx.Session).SetGrants(Grants{{Database: GrantWildcard, Permissions: auth.ReadPerm}})
+
+		_, iter, err := engine.Query(sqlCtx, query)
+		if err != nil {
+			return 0, err
+		}
+
+		defer iter.Close()
+
+		n := 0
+		for {
+			_, err = iter.Next()
+			if err == io.EOF {
+				return n, nil
+			} else if err != nil {
+				return 0, err
+			}
+
+			n++
+		}
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+)
+
+func TestHookQueryFuncIsReadOnly(t *testing.T) {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateEnvWithSeedData(t)
+	root, err := dEnv.WorkingRoot(ctx)
+	require.NoError(t, err)
+	workingHash := dEnv.RepoState.WorkingHash()
+
+	queryFunc := NewHookQueryFunc("dolt", dEnv.DbData())
+
+	n, err := queryFunc(ctx, root, "SELECT * FROM people")
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+
+	writes := []string{
+		"INSERT INTO people (id, name, age, is_married, title) VALUES ('00000000-0000-0000-0000-000000000010', 'Jane Janeson', 40, true, 'Boss')",
+		"DELETE FROM people",
+		"CREATE TABLE other (pk BIGINT PRIMARY KEY)",
+		"DROP TABLE people",
+		"DELETE FROM dolt_branches WHERE name = 'master'",
+	}
+
+	for _, query := range writes {
+		t.Run(query, func(t *testing.T) {
+			_, err := queryFunc(ctx, root, query)
+			require.Error(t, err)
+		})
+	}
+
+	assert.Equal(t, workingHash, dEnv.RepoState.WorkingHash())
+	ok, err := dEnv.DoltDB.HasRef(ctx, ref.NewBranchRef("master"))
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	tbl, ok, err := root.GetTable(ctx, "people")
+	require.NoError(t, err)
+	require.True(t, ok)
+	rows, err := tbl.GetRowData(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), rows.Len())
+}
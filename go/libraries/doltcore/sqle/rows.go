@@ -36,7 +36,7 @@ func newRowIterator(tbl *DoltTable, ctx *sql.Context, partition *doltTablePartit
 	if partition == nil {
 		iter, err = table.NewBufferedTableReader(ctx, tbl.table)
 	} else {
-		iter, err = table.NewBufferedTableReaderForPartition(ctx, tbl.table, partition.start, partition.end)
+		iter, err = table.NewTableReaderForKeyRange(ctx, tbl.table, partition.start, partition.end)
 	}
 
 	if err != nil {
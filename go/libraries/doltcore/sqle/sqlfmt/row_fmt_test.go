@@ -34,6 +34,12 @@ const expectedAddColSql = "ALTER TABLE `table_name` ADD `c0` BIGINT NOT NULL;"
 const expectedDropColSql = "ALTER TABLE `table_name` DROP `first_name`;"
 const expectedRenameColSql = "ALTER TABLE `table_name` RENAME COLUMN `id` TO `pk`;"
 const expectedRenameTableSql = "RENAME TABLE `table_name` TO `new_table_name`;"
+const expectedCreateTableSql = "CREATE TABLE `table_name` (\n" +
+	"  `id` BIGINT NOT NULL,\n" +
+	"  `name` LONGTEXT DEFAULT \"x\",\n" +
+	"  PRIMARY KEY (`id`),\n" +
+	"  INDEX `name_idx` (`name`)\n" +
+	");"
 
 type test struct {
 	name           string
@@ -79,6 +85,21 @@ func TestRenameTableStmt(t *testing.T) {
 	assert.Equal(t, expectedRenameTableSql, stmt)
 }
 
+func TestCreateTableStmt(t *testing.T) {
+	idCol := schema.NewColumn("id", 1, types.IntKind, true, schema.NotNullConstraint{})
+	nameCol, err := schema.NewColumnWithTypeInfo("name", 2, typeinfo.StringDefaultType, false, `"x"`, false, "")
+	require.NoError(t, err)
+	colColl, err := schema.NewColCollection(idCol, nameCol)
+	require.NoError(t, err)
+	sch := schema.MustSchemaFromCols(colColl)
+	_, err = sch.Indexes().AddIndexByColNames("name_idx", []string{"name"}, schema.IndexProperties{IsUserDefined: true})
+	require.NoError(t, err)
+
+	stmt := CreateTableStmt("table_name", sch)
+
+	assert.Equal(t, expectedCreateTableSql, stmt)
+}
+
 func TestRowAsInsertStmt(t *testing.T) {
 	id := uuid.MustParse("00000000-0000-0000-0000-000000000000")
 	tableName := "people"
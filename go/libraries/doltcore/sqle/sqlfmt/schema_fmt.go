@@ -91,6 +91,39 @@ func FmtIndex(index schema.Index) string {
 	return sb.String()
 }
 
+func FmtCheck(check schema.Check) string {
+	return fmt.Sprintf("CONSTRAINT %s CHECK (%s)", QuoteIdentifier(check.Name), check.Expression)
+}
+
+// CreateTableStmt returns a CREATE TABLE statement for a table with the schema given, including its indexes and
+// CHECK constraints.
+func CreateTableStmt(tableName string, sch schema.Schema) string {
+	var defs []string
+	_ = sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		defs = append(defs, FmtCol(2, 0, 0, col))
+		return false, nil
+	})
+
+	var pks []string
+	_ = sch.GetPKCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		pks = append(pks, QuoteIdentifier(col.Name))
+		return false, nil
+	})
+	if len(pks) > 0 {
+		defs = append(defs, fmt.Sprintf("  PRIMARY KEY (%s)", strings.Join(pks, ",")))
+	}
+
+	for _, index := range sch.Indexes().AllIndexes() {
+		defs = append(defs, "  "+FmtIndex(index))
+	}
+
+	for _, check := range sch.Checks().AllChecks() {
+		defs = append(defs, "  "+FmtCheck(check))
+	}
+
+	return fmt.Sprintf("CREATE TABLE %s (\n%s\n);", QuoteIdentifier(tableName), strings.Join(defs, ",\n"))
+}
+
 func FmtForeignKey(fk doltdb.ForeignKey, sch, parentSch schema.Schema) string {
 	sb := strings.Builder{}
 	sb.WriteString("CONSTRAINT ")
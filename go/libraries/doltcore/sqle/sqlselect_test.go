@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/plan"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -727,6 +728,18 @@ var BasicSelectTests = []SelectTest{
 			&sql.Column{Name: "message", Type: sql.Text},
 		},
 	},
+	{
+		Name:         "select from unscoped diff system table",
+		Query:        "select commit_hash, table_name, data_change, schema_change, rows_added from dolt_diff where table_name = 'people'",
+		ExpectedRows: []sql.Row{{"WORKING", "people", true, true, uint64(6)}},
+		ExpectedSqlSchema: sql.Schema{
+			&sql.Column{Name: "commit_hash", Type: sql.Text},
+			&sql.Column{Name: "table_name", Type: sql.Text},
+			&sql.Column{Name: "data_change", Type: sql.Boolean},
+			&sql.Column{Name: "schema_change", Type: sql.Boolean},
+			&sql.Column{Name: "rows_added", Type: sql.Uint64},
+		},
+	},
 	{
 		Name:         "select * from conflicts system table",
 		Query:        "select * from dolt_conflicts",
@@ -798,6 +811,14 @@ var SelectDiffTests = []SelectTest{
 		),
 		ExpectedSqlSchema: sqlDiffSchema,
 	},
+	{
+		Name:  "select from commit diff system table with commit specs",
+		Query: "select to_id, to_first_name, to_last_name, to_addr, from_id, from_first_name, from_last_name, from_addr, diff_type from dolt_commit_diff_test_table where from_commit = 'HEAD' and to_commit = 'WORKING'",
+		ExpectedRows: ToSqlRows(DiffSchema,
+			mustRow(row.New(types.Format_7_18, DiffSchema, row.TaggedValues{0: types.Int(6), 1: types.String("Katie"), 2: types.String("McCulloch"), 14: types.String("added")})),
+		),
+		ExpectedSqlSchema: sqlDiffSchema,
+	},
 	// TODO: fix dependencies to hashof function can be registered and used here, also create branches when generating the history so that different from and to commits can be tested.
 	/*{
 		Name:  "select from diff system table with from and to commit and test insensitive name",
@@ -1442,6 +1463,75 @@ func TestAsOfQueries(t *testing.T) {
 	}
 }
 
+func TestDiffTableFunction(t *testing.T) {
+	tests := []struct {
+		name     string
+		fromRef  string
+		toRef    string
+		expected []sql.Row
+	}{
+		{
+			name:     "head to working",
+			fromRef:  "HEAD",
+			toRef:    "WORKING",
+			expected: []sql.Row{{int64(6), "Katie", "McCulloch", nil, nil, nil, nil, nil, "added"}},
+		},
+		{
+			name:     "staged to working is case insensitive",
+			fromRef:  "staged",
+			toRef:    "working",
+			expected: []sql.Row{{int64(6), "Katie", "McCulloch", nil, nil, nil, nil, nil, "added"}},
+		},
+		{
+			name:    "branch to parent of head",
+			fromRef: "seed",
+			toRef:   "HEAD~",
+			expected: []sql.Row{
+				{int64(0), "Aaron", "Son", "123 Fake St", int64(0), "Aaron", "Son", nil, "modified"},
+				{int64(1), "Brian", "Hendriks", "456 Bull Ln", int64(1), "Brian", "Hendriks", nil, "modified"},
+				{int64(2), "Tim", "Sehn", "789 Not Real Ct", int64(2), "Tim", "Sehn", nil, "modified"},
+				{int64(3), "Zach", "Musgrave", nil, nil, nil, nil, nil, "added"},
+				{int64(4), "Matt", "Jesuele", nil, nil, nil, nil, nil, "added"},
+			},
+		},
+		{
+			name:     "head to head",
+			fromRef:  "HEAD",
+			toRef:    "master",
+			expected: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			dEnv, root := createDiffQueryEnv(t, ctx, nil)
+
+			db := NewDatabase("dolt", dEnv.DbData())
+			_, sqlCtx, err := NewTestEngine(ctx, db, root)
+			require.NoError(t, err)
+
+			tbl, err := db.DiffTableFunction(sqlCtx, "test_table", test.fromRef, test.toRef)
+			require.NoError(t, err)
+
+			cols := []string{"to_id", "to_first_name", "to_last_name", "to_addr", "from_id", "from_first_name", "from_last_name", "from_addr", "diff_type"}
+			rows, err := sql.NodeToRows(sqlCtx, plan.NewResolvedTable(tbl))
+			require.NoError(t, err)
+
+			var actual []sql.Row
+			for _, r := range rows {
+				var projected sql.Row
+				for _, col := range cols {
+					projected = append(projected, r[tbl.Schema().IndexOf(col, doltdb.DiffTableName)])
+				}
+				actual = append(actual, projected)
+			}
+
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
 func TestJoins(t *testing.T) {
 	for _, tt := range JoinTests {
 		t.Run(tt.Name, func(t *testing.T) {
@@ -1568,14 +1658,39 @@ func testSelectDiffQuery(t *testing.T, test SelectTest) {
 	validateTest(t, test)
 
 	ctx := context.Background()
+	dEnv, root := createDiffQueryEnv(t, ctx, test.AdditionalSetup)
+
+	actualRows, sch, err := executeSelect(ctx, dEnv, root, test.Query)
+	if len(test.ExpectedErr) > 0 {
+		require.Error(t, err)
+		return
+	} else {
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, test.ExpectedRows, actualRows)
+
+	var sqlSchema sql.Schema
+	if test.ExpectedSqlSchema != nil {
+		sqlSchema = test.ExpectedSqlSchema
+	} else {
+		sqlSchema = mustSqlSchema(test.ExpectedSchema)
+	}
+
+	assertSchemasEqual(t, sqlSchema, sch)
+}
+
+// createDiffQueryEnv creates an environment with the history used by the diff tests, with master checked out and
+// the working root update applied.
+func createDiffQueryEnv(t *testing.T, ctx context.Context, additionalSetup SetupFn) (*env.DoltEnv, *doltdb.RootValue) {
 	tcc := &testCommitClock{}
 	doltdb.CommitNowFunc = tcc.Now
 	doltdb.CommitLoc = time.UTC
 
 	dEnv := dtestutils.CreateTestEnv()
 	envtestutils.InitializeWithHistory(t, ctx, dEnv, CreateHistory(ctx, dEnv, t)...)
-	if test.AdditionalSetup != nil {
-		test.AdditionalSetup(t, dEnv)
+	if additionalSetup != nil {
+		additionalSetup(t, dEnv)
 	}
 
 	cs, err := doltdb.NewCommitSpec("master")
@@ -1601,24 +1716,7 @@ func testSelectDiffQuery(t *testing.T, test SelectTest) {
 	err = dEnv.UpdateWorkingRoot(ctx, root)
 	require.NoError(t, err)
 
-	actualRows, sch, err := executeSelect(ctx, dEnv, root, test.Query)
-	if len(test.ExpectedErr) > 0 {
-		require.Error(t, err)
-		return
-	} else {
-		require.NoError(t, err)
-	}
-
-	assert.Equal(t, test.ExpectedRows, actualRows)
-
-	var sqlSchema sql.Schema
-	if test.ExpectedSqlSchema != nil {
-		sqlSchema = test.ExpectedSqlSchema
-	} else {
-		sqlSchema = mustSqlSchema(test.ExpectedSchema)
-	}
-
-	assertSchemasEqual(t, sqlSchema, sch)
+	return dEnv, root
 }
 
 func validateTest(t *testing.T, test SelectTest) {
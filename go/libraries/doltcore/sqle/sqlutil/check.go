@@ -0,0 +1,234 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlutil
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/expression/function"
+	"github.com/dolthub/go-mysql-server/sql/parse"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/checks"
+)
+
+// ErrCheckViolation is returned when a row violates a CHECK constraint.
+type ErrCheckViolation struct {
+	Check schema.Check
+}
+
+func (e ErrCheckViolation) Error() string {
+	return fmt.Sprintf("check constraint `%s` is violated: %s", e.Check.Name, e.Check.Expression)
+}
+
+// IsCheckViolation returns whether the given error is a CHECK constraint violation.
+func IsCheckViolation(err error) bool {
+	_, ok := err.(ErrCheckViolation)
+	return ok
+}
+
+// nonDeterministicFunctions are the built-in functions which CHECK constraints can't use, as their results depend on
+// more than their arguments. unix_timestamp is only non-deterministic when it's called without arguments.
+var nonDeterministicFunctions = map[string]bool{
+	"connection_id":     true,
+	"curdate":           true,
+	"current_date":      true,
+	"current_time":      true,
+	"current_timestamp": true,
+	"current_user":      true,
+	"curtime":           true,
+	"now":               true,
+	"rand":              true,
+	"sleep":             true,
+	"unix_timestamp":    true,
+	"user":              true,
+	"utc_timestamp":     true,
+}
+
+var checkFunctions = func() sql.FunctionRegistry {
+	registry := sql.NewFunctionRegistry()
+	registry.MustRegister(function.Defaults...)
+	return registry
+}()
+
+// CheckEvaluator evaluates the CHECK constraints of a schema against rows of that schema.
+type CheckEvaluator struct {
+	sch    schema.Schema
+	checks []schema.Check
+	exprs  []sql.Expression
+	sqlCtx *sql.Context
+}
+
+var _ checks.EvaluatorFactory = NewCheckEvaluator
+
+// NewCheckEvaluator parses the expressions of the CHECK constraints of the given schema, resolving their column
+// references against the columns of the schema and their function calls against the built-in functions.
+func NewCheckEvaluator(ctx context.Context, sch schema.Schema) (checks.Evaluator, error) {
+	sqlCtx, ok := ctx.(*sql.Context)
+	if !ok {
+		sqlCtx = sql.NewContext(ctx)
+	}
+
+	allChecks := sch.Checks().AllChecks()
+	exprs := make([]sql.Expression, len(allChecks))
+	for i, check := range allChecks {
+		expr, err := ParseCheckExpression(sqlCtx, sch, check)
+		if err != nil {
+			return nil, err
+		}
+		exprs[i] = expr
+	}
+
+	return &CheckEvaluator{sch, allChecks, exprs, sqlCtx}, nil
+}
+
+// ParseCheckExpression parses the expression of a CHECK constraint and resolves it against the given schema.
+// Expressions that reference tables, subqueries, or anything besides the columns of the schema are rejected.
+func ParseCheckExpression(ctx *sql.Context, sch schema.Schema, check schema.Check) (sql.Expression, error) {
+	defVal, err := parse.StringToColumnDefaultValue(ctx, "("+check.Expression+")")
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression for CHECK constraint `%s`: %v", check.Name, err)
+	}
+
+	allCols := sch.GetAllCols()
+	expr, err := expression.TransformUp(defVal.Expression, func(e sql.Expression) (sql.Expression, error) {
+		switch e := e.(type) {
+		case *expression.UnresolvedColumn:
+			if e.Table() != "" {
+				return nil, fmt.Errorf("CHECK constraint `%s` cannot reference the table `%s`", check.Name, e.Table())
+			}
+			col, ok := allCols.GetByNameCaseInsensitive(e.Name())
+			if !ok {
+				return nil, fmt.Errorf("CHECK constraint `%s` references the unknown column `%s`", check.Name, e.Name())
+			}
+			idx := 0
+			for allCols.Tags[idx] != col.Tag {
+				idx++
+			}
+			return expression.NewGetField(idx, col.TypeInfo.ToSqlType(), col.Name, col.IsNullable()), nil
+		case *expression.UnresolvedFunction:
+			if e.IsAggregate {
+				return nil, fmt.Errorf("CHECK constraint `%s` cannot use the aggregate function %s", check.Name, e.Name())
+			}
+			name := strings.ToLower(e.Name())
+			if nonDeterministicFunctions[name] && (name != "unix_timestamp" || len(e.Arguments) == 0) {
+				return nil, fmt.Errorf("CHECK constraint `%s` cannot use the non-deterministic function %s", check.Name, e.Name())
+			}
+			fn, err := checkFunctions.Function(name)
+			if err != nil {
+				return nil, fmt.Errorf("CHECK constraint `%s` uses an unknown function: %v", check.Name, err)
+			}
+			return fn.Call(e.Arguments...)
+		default:
+			return e, nil
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !expr.Resolved() {
+		return nil, fmt.Errorf("CHECK constraint `%s` may only reference the columns of its table", check.Name)
+	}
+	return expr, nil
+}
+
+// CheckColumns returns the names of the columns referenced by the expression of a CHECK constraint.
+func CheckColumns(ctx context.Context, sch schema.Schema, check schema.Check) ([]string, error) {
+	sqlCtx, ok := ctx.(*sql.Context)
+	if !ok {
+		sqlCtx = sql.NewContext(ctx)
+	}
+
+	expr, err := ParseCheckExpression(sqlCtx, sch, check)
+	if err != nil {
+		return nil, err
+	}
+
+	var cols []string
+	sql.Inspect(expr, func(e sql.Expression) bool {
+		if gf, ok := e.(*expression.GetField); ok {
+			cols = append(cols, gf.Name())
+		}
+		return true
+	})
+	return cols, nil
+}
+
+// Count returns the number of CHECK constraints that this evaluator evaluates.
+func (ce *CheckEvaluator) Count() int {
+	return len(ce.checks)
+}
+
+// ValidateRow returns an ErrCheckViolation for the first CHECK constraint that the given row violates.
+func (ce *CheckEvaluator) ValidateRow(ctx context.Context, r row.Row) error {
+	violated, err := ce.violations(ctx, r, true)
+	if err != nil {
+		return err
+	}
+	if len(violated) > 0 {
+		return ErrCheckViolation{violated[0]}
+	}
+	return nil
+}
+
+// Violations returns every CHECK constraint that the given row violates.
+func (ce *CheckEvaluator) Violations(ctx context.Context, r row.Row) ([]schema.Check, error) {
+	return ce.violations(ctx, r, false)
+}
+
+func (ce *CheckEvaluator) violations(ctx context.Context, r row.Row, firstOnly bool) ([]schema.Check, error) {
+	if len(ce.checks) == 0 {
+		return nil, nil
+	}
+
+	sqlCtx, ok := ctx.(*sql.Context)
+	if !ok {
+		sqlCtx = ce.sqlCtx
+	}
+
+	sqlRow, err := DoltRowToSqlRow(r, ce.sch)
+	if err != nil {
+		return nil, err
+	}
+
+	var violated []schema.Check
+	for i, expr := range ce.exprs {
+		res, err := expr.Eval(sqlCtx, sqlRow)
+		if err != nil {
+			return nil, err
+		}
+		if res == nil {
+			continue
+		}
+		satisfied, err := sql.ConvertToBool(res)
+		if err != nil {
+			return nil, err
+		}
+		if !satisfied {
+			violated = append(violated, ce.checks[i])
+			if firstOnly {
+				break
+			}
+		}
+	}
+
+	return violated, nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlutil
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func checkTestSchema(t *testing.T, checks ...schema.Check) schema.Schema {
+	colColl, err := schema.NewColCollection(
+		schema.NewColumn("pk", 0, types.IntKind, true, schema.NotNullConstraint{}),
+		schema.NewColumn("price", 1, types.IntKind, false),
+		schema.NewColumn("name", 2, types.StringKind, false),
+	)
+	require.NoError(t, err)
+	sch, err := schema.SchemaFromCols(colColl)
+	require.NoError(t, err)
+	for _, check := range checks {
+		_, err = sch.Checks().AddCheck(check.Name, check.Expression)
+		require.NoError(t, err)
+	}
+	return sch
+}
+
+func TestCheckEvaluator(t *testing.T) {
+	ctx := context.Background()
+	chkPrice := schema.Check{Name: "chk_price", Expression: "price > 0"}
+	chkName := schema.Check{Name: "chk_name", Expression: "LENGTH(name) < 5 AND PK <> 0"}
+	sch := checkTestSchema(t, chkPrice, chkName)
+
+	checks, err := NewCheckEvaluator(ctx, sch)
+	require.NoError(t, err)
+	assert.Equal(t, 2, checks.Count())
+
+	newRow := func(vals row.TaggedValues) row.Row {
+		r, err := row.New(types.Format_Default, sch, vals)
+		require.NoError(t, err)
+		return r
+	}
+
+	tests := []struct {
+		name     string
+		row      row.Row
+		violated []schema.Check
+	}{
+		{"satisfied", newRow(row.TaggedValues{0: types.Int(1), 1: types.Int(5), 2: types.String("abc")}), nil},
+		{"null satisfies", newRow(row.TaggedValues{0: types.Int(1)}), nil},
+		{"price", newRow(row.TaggedValues{0: types.Int(1), 1: types.Int(-5), 2: types.String("abc")}), []schema.Check{chkPrice}},
+		{"both", newRow(row.TaggedValues{0: types.Int(0), 1: types.Int(0)}), []schema.Check{chkPrice, chkName}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			violated, err := checks.Violations(ctx, test.row)
+			require.NoError(t, err)
+			assert.Equal(t, test.violated, violated)
+
+			err = checks.ValidateRow(ctx, test.row)
+			if len(test.violated) == 0 {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, IsCheckViolation(err))
+				assert.Equal(t, ErrCheckViolation{test.violated[0]}, err)
+			}
+		})
+	}
+}
+
+func TestParseCheckExpression(t *testing.T) {
+	sch := checkTestSchema(t)
+	sqlCtx := sql.NewEmptyContext()
+
+	tests := []struct {
+		expression string
+		cols       []string
+		expectErr  bool
+	}{
+		{"price > 0", []string{"price"}, false},
+		{"UPPER(name) <> 'X' OR price IS NULL", []string{"name", "price"}, false},
+		{"missing > 0", nil, true},
+		{"t.price > 0", nil, true},
+		{"SUM(price) > 0", nil, true},
+		{"nosuchfunc(price)", nil, true},
+		{"price >", nil, true},
+		{"price > RAND()", nil, true},
+		{"now() IS NOT NULL", nil, true},
+		{"price < UNIX_TIMESTAMP()", nil, true},
+		{"price < UNIX_TIMESTAMP('2020-01-01')", []string{"price"}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expression, func(t *testing.T) {
+			check := schema.Check{Name: "chk", Expression: test.expression}
+			_, err := ParseCheckExpression(sqlCtx, sch, check)
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			cols, err := CheckColumns(sqlCtx, sch, check)
+			require.NoError(t, err)
+			assert.Equal(t, test.cols, cols)
+		})
+	}
+}
@@ -90,7 +90,7 @@ func ToDoltSchema(ctx context.Context, root *doltdb.RootValue, tableName string,
 	var kinds []types.NomsKind
 	for _, col := range sqlSchema {
 		names = append(names, col.Name)
-		ti, err := typeinfo.FromSqlType(col.Type)
+		ti, err := toDoltTypeInfo(col)
 		if err != nil {
 			return nil, err
 		}
@@ -132,7 +132,7 @@ func ToDoltCol(tag uint64, col *sql.Column) (schema.Column, error) {
 	if !col.Nullable {
 		constraints = append(constraints, schema.NotNullConstraint{})
 	}
-	typeInfo, err := typeinfo.FromSqlType(col.Type)
+	typeInfo, err := toDoltTypeInfo(col)
 	if err != nil {
 		return schema.Column{}, err
 	}
@@ -140,6 +140,19 @@ func ToDoltCol(tag uint64, col *sql.Column) (schema.Column, error) {
 	return schema.NewColumnWithTypeInfo(col.Name, tag, typeInfo, col.PrimaryKey, col.Default.String(), col.AutoIncrement, col.Comment, constraints...)
 }
 
+// toDoltTypeInfo returns the TypeInfo used to store the values of the given column. The columns of primary keys, and of
+// the dolt system tables, whose fixed schemas store TEXT values as strings, are stored as keys.
+func toDoltTypeInfo(col *sql.Column) (typeinfo.TypeInfo, error) {
+	typeInfo, err := typeinfo.FromSqlType(col.Type)
+	if err != nil {
+		return nil, err
+	}
+	if col.PrimaryKey || doltdb.HasDoltPrefix(col.Source) {
+		return typeinfo.KeyType(typeInfo), nil
+	}
+	return typeInfo, nil
+}
+
 func GetColNamesFromSqlSchema(sqlSch sql.Schema) []string {
 	colNames := make([]string, len(sqlSch))
 
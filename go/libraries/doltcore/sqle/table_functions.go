@@ -0,0 +1,89 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+)
+
+// The SQL parser used by the engine has no syntax for table functions, so Dolt's table functions are called by quoting
+// the call as a table name, such as `dolt_diff('t', 'HEAD~1', 'WORKING')`. The Database resolves a table name which is
+// a table function call to the table returned by the function, the same way it resolves its system tables.
+
+const DiffTableFunctionName = "dolt_diff"
+
+var tableFunctionArgCounts = map[string]int{
+	DiffTableFunctionName: 3,
+}
+
+// scanStringArgs scans the parenthesized list of string literals following the name of a table function, and returns
+// the arguments along with the offset of the end of the list.
+func scanStringArgs(tokenizer *sqlparser.Tokenizer) ([]string, int, bool) {
+	if tok, _ := tokenizer.Scan(); tok != '(' {
+		return nil, 0, false
+	}
+
+	var args []string
+	for {
+		tok, val := tokenizer.Scan()
+		if tok != sqlparser.STRING {
+			return nil, 0, false
+		}
+		args = append(args, string(val))
+
+		tok, _ = tokenizer.Scan()
+		switch tok {
+		case ',':
+			continue
+		case ')':
+			return args, tokenizer.Position - 1, true
+		default:
+			return nil, 0, false
+		}
+	}
+}
+
+// parseTableFunctionName returns the name of the table function and its arguments for a table name which is a table
+// function call. Returns false if the table name is not a table function call.
+func parseTableFunctionName(tblName string) (string, []string, bool) {
+	paren := strings.IndexByte(tblName, '(')
+	if paren < 0 || !strings.HasSuffix(tblName, ")") {
+		return "", nil, false
+	}
+
+	name := strings.ToLower(strings.TrimSpace(tblName[:paren]))
+	if _, ok := tableFunctionArgCounts[name]; !ok {
+		return "", nil, false
+	}
+
+	args, end, ok := scanStringArgs(sqlparser.NewStringTokenizer(tblName[paren:]))
+	if !ok || end != len(tblName)-paren {
+		return "", nil, false
+	}
+
+	return name, args, true
+}
+
+// tableFunction returns the table of the table function |name| called with |args|.
+func (db Database) tableFunction(ctx *sql.Context, name string, args []string) (sql.Table, error) {
+	if len(args) != tableFunctionArgCounts[name] {
+		return nil, sql.ErrInvalidArgumentNumber.New(strings.ToUpper(name), tableFunctionArgCounts[name], len(args))
+	}
+
+	return db.DiffTableFunction(ctx, args[0], args[1], args[2])
+}
@@ -0,0 +1,43 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"testing"
+
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTableFunctionName(t *testing.T) {
+	stmt, err := sqlparser.Parse("SELECT * FROM `DOLT_DIFF('it\\'s', 'a``b', \"c\\\\d\")`")
+	require.NoError(t, err)
+
+	tblName := stmt.(*sqlparser.Select).From[0].(*sqlparser.AliasedTableExpr).Expr.(sqlparser.TableName).Name.String()
+	name, args, ok := parseTableFunctionName(tblName)
+	require.True(t, ok)
+	assert.Equal(t, DiffTableFunctionName, name)
+	assert.Equal(t, []string{"it's", "a`b", `c\d`}, args)
+
+	_, _, ok = parseTableFunctionName("dolt_diff('t', 'a', 'b') x")
+	assert.False(t, ok)
+	_, _, ok = parseTableFunctionName("dolt_diff_t")
+	assert.False(t, ok)
+	_, _, ok = parseTableFunctionName("dolt_diff")
+	assert.False(t, ok)
+	_, _, ok = parseTableFunctionName("dolt_diff(t, 'a', 'b')")
+	assert.False(t, ok)
+}
@@ -20,7 +20,6 @@ import (
 	"io"
 	"os"
 	"runtime"
-	"strconv"
 	"strings"
 	"sync"
 
@@ -206,7 +205,8 @@ func (t *DoltTable) sqlSchema() sql.Schema {
 	return sqlSch
 }
 
-// Returns the partitions for this table.
+// Returns the partitions for this table. The rows are split into key ranges at the chunk boundaries of the row data's
+// prolly tree so that partitions can be read in parallel without sharing chunks.
 func (t *DoltTable) Partitions(ctx *sql.Context) (sql.PartitionIter, error) {
 	rowData, err := t.table.GetRowData(ctx)
 
@@ -214,29 +214,24 @@ func (t *DoltTable) Partitions(ctx *sql.Context) (sql.PartitionIter, error) {
 		return nil, err
 	}
 
-	numElements := rowData.Len()
-
-	if numElements == 0 {
-		return newDoltTablePartitionIter(rowData, doltTablePartition{0, 1}), nil
-	}
-
 	maxPartitions := uint64(partitionMultiplier * runtime.NumCPU())
-	numPartitions := (numElements / MinRowsPerPartition) + 1
+	numPartitions := (rowData.Len() / MinRowsPerPartition) + 1
 
 	if numPartitions > maxPartitions {
 		numPartitions = maxPartitions
 	}
 
-	if schema.IsKeyless(t.sch) {
-		numPartitions = 1
+	splitKeys, err := rowData.SplitKeys(ctx, numPartitions)
+
+	if err != nil {
+		return nil, err
 	}
 
-	partitions := make([]doltTablePartition, numPartitions)
-	itemsPerPartition := numElements / numPartitions
-	for i := uint64(0); i < numPartitions-1; i++ {
-		partitions[i] = doltTablePartition{i * itemsPerPartition, (i + 1) * itemsPerPartition}
+	partitions := make([]doltTablePartition, len(splitKeys)+1)
+	for i, key := range splitKeys {
+		partitions[i].end = key
+		partitions[i+1].start = key
 	}
-	partitions[numPartitions-1] = doltTablePartition{(numPartitions - 1) * itemsPerPartition, numElements}
 
 	return newDoltTablePartitionIter(rowData, partitions...), nil
 }
@@ -469,15 +464,24 @@ func (itr *doltTablePartitionIter) Next() (sql.Partition, error) {
 var _ sql.Partition = (*doltTablePartition)(nil)
 
 type doltTablePartition struct {
-	// start is the first index of this partition (inclusive)
-	start uint64
-	// all elements in the partition will be less than end (exclusive)
-	end uint64
+	// all keys in the partition will be greater than start (exclusive), or start is nil if the partition begins with
+	// the first row
+	start types.Value
+	// end is the last key of this partition (inclusive), or nil if the partition ends with the last row
+	end types.Value
 }
 
 // Key returns the key for this partition, which must uniquely identity the partition.
 func (p doltTablePartition) Key() []byte {
-	return []byte(strconv.FormatUint(p.start, 10) + " >= i < " + strconv.FormatUint(p.end, 10))
+	if p.start == nil {
+		return []byte("first")
+	}
+
+	h, err := p.start.Hash(types.Format_Default)
+	if err != nil {
+		panic(err)
+	}
+	return h[:]
 }
 
 // AlterableDoltTable allows altering the schema of the table. It implements sql.AlterableTable.
@@ -629,6 +633,12 @@ func (t *AlterableDoltTable) ModifyColumn(ctx *sql.Context, columnName string, c
 		return err
 	}
 
+	// a column whose SQL type is unchanged keeps storing its values the same way, such as TEXT columns of primary keys
+	// or those created before TEXT values were stored as blobs
+	if existingCol.TypeInfo.ToSqlType().String() == col.TypeInfo.ToSqlType().String() {
+		col.TypeInfo, col.Kind = existingCol.TypeInfo, existingCol.Kind
+	}
+
 	fkCollection, err := root.GetForeignKeyCollection(ctx)
 	if err != nil {
 		return err
@@ -930,7 +940,7 @@ func (t *AlterableDoltTable) CreateForeignKey(
 	if err != nil {
 		return err
 	}
-	err = table.ForeignKeyIsSatisfied(ctx, foreignKey, tableIndexData, refTableIndexData, tableIndex, refTableIndex)
+	err = table.ForeignKeyIsSatisfied(ctx, t.table.ValueReadWriter(), foreignKey, tableIndexData, refTableIndexData, tableIndex, refTableIndex)
 	if err != nil {
 		return err
 	}
@@ -1062,6 +1072,7 @@ func createIndexForTable(
 
 	// get the real column names as CREATE INDEX columns are case-insensitive
 	var realColNames []string
+	var realColTags []uint64
 	allTableCols := sch.GetAllCols()
 	for _, indexCol := range columns {
 		tableCol, ok := allTableCols.GetByNameCaseInsensitive(indexCol.Name)
@@ -1069,6 +1080,18 @@ func createIndexForTable(
 			return nil, fmt.Errorf("column `%s` does not exist for the table", indexCol.Name)
 		}
 		realColNames = append(realColNames, tableCol.Name)
+		realColTags = append(realColTags, tableCol.Tag)
+	}
+
+	// TEXT values are stored as blobs, which are not ordered by their contents, so indexed TEXT columns store their
+	// values as strings instead
+	table, err = alterschema.StoreColumnsAsKeys(ctx, table, realColTags)
+	if err != nil {
+		return nil, err
+	}
+	sch, err = table.GetSchema(ctx)
+	if err != nil {
+		return nil, err
 	}
 
 	if indexName == "" {
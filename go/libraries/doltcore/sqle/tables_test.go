@@ -15,12 +15,93 @@
 package sqle
 
 import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
 	"testing"
 
+	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
 )
 
 func TestMinRowsPerPartitionInTests(t *testing.T) {
 	// If this fails then the method for determining if we are running in a test doesn't work all the time.
 	assert.Equal(t, uint64(2), MinRowsPerPartition)
 }
+
+func TestPartitions(t *testing.T) {
+	const numRows = 20000
+
+	values := make([]string, numRows)
+	for i := range values {
+		values[i] = fmt.Sprintf("(%d, %d)", i, i%7)
+	}
+
+	dEnv := dtestutils.CreateTestEnv()
+	root, err := dEnv.WorkingRoot(context.Background())
+	require.NoError(t, err)
+	root, err = ExecuteSql(dEnv, root, fmt.Sprintf(`CREATE TABLE keyed (pk BIGINT PRIMARY KEY, c1 BIGINT);
+CREATE TABLE keyless (c0 BIGINT, c1 BIGINT);
+INSERT INTO keyed VALUES %s;
+INSERT INTO keyless VALUES %s;
+INSERT INTO keyless VALUES (1, 1), (1, 1);`, strings.Join(values, ", "), strings.Join(values, ", ")))
+	require.NoError(t, err)
+
+	for _, tblName := range []string{"keyed", "keyless"} {
+		t.Run(tblName, func(t *testing.T) {
+			ctx := NewTestSQLCtx(context.Background())
+			tbl, _, err := root.GetTable(ctx, tblName)
+			require.NoError(t, err)
+			sch, err := tbl.GetSchema(ctx)
+			require.NoError(t, err)
+			rowData, err := tbl.GetRowData(ctx)
+			require.NoError(t, err)
+			dt := NewDoltTable(tblName, sch, tbl, nil)
+
+			partIter, err := dt.Partitions(ctx)
+			require.NoError(t, err)
+
+			// every row is read from exactly one partition
+			numPartitions := 0
+			partitionKeys := make(map[string]bool)
+			seen := make(map[string]int)
+			for {
+				part, err := partIter.Next()
+				if err == io.EOF {
+					break
+				}
+				require.NoError(t, err)
+				numPartitions++
+				partitionKeys[string(part.Key())] = true
+
+				rowIter, err := dt.PartitionRows(ctx, part)
+				require.NoError(t, err)
+				rows, err := sql.RowIterToRows(rowIter)
+				require.NoError(t, err)
+				assert.NotEmpty(t, rows)
+				for _, r := range rows {
+					seen[fmt.Sprint(r)]++
+				}
+			}
+
+			assert.True(t, numPartitions > 1)
+			assert.Len(t, partitionKeys, numPartitions)
+
+			count := 0
+			for _, n := range seen {
+				count += n
+			}
+			assert.Equal(t, int(rowData.Len()), len(seen))
+			if tblName == "keyed" {
+				assert.Equal(t, numRows, count)
+			} else {
+				assert.Equal(t, numRows+2, count)
+				assert.Equal(t, 3, seen[fmt.Sprint(sql.NewRow(int64(1), int64(1)))])
+			}
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/row"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/checks"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
@@ -31,6 +32,7 @@ type sessionedTableEditor struct {
 	tableEditor       TableEditor
 	referencedTables  []doltdb.ForeignKey // The tables that we reference to ensure an insert or update is valid
 	referencingTables []doltdb.ForeignKey // The tables that reference us to ensure their inserts and updates are valid
+	checks            checks.Evaluator
 }
 
 var _ TableEditor = &sessionedTableEditor{}
@@ -305,8 +307,15 @@ func (ste *sessionedTableEditor) updateRow(ctx context.Context, dOldRow row.Row,
 	return ste.tableEditor.UpdateRow(ctx, dOldRow, dNewRow)
 }
 
-// validateForInsert returns whether the given row is able to be inserted into the target table.
+// validateForInsert returns whether the given row is able to be inserted into the target table. CHECK constraints are
+// always enforced, even when foreign key checks are disabled.
 func (ste *sessionedTableEditor) validateForInsert(ctx context.Context, dRow row.Row) error {
+	if ste.checks != nil {
+		err := ste.checks.ValidateRow(ctx, dRow)
+		if err != nil {
+			return err
+		}
+	}
 	if ste.tableEditSession.Props.ForeignKeyChecksDisabled {
 		return nil
 	}
@@ -16,12 +16,14 @@ package editor
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/row"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/checks"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
@@ -38,8 +40,15 @@ type TableEditSession struct {
 // TableEditSessionProps are properties that define different functionality for the TableEditSession.
 type TableEditSessionProps struct {
 	ForeignKeyChecksDisabled bool // If true, then ALL foreign key checks AND updates (through CASCADE, etc.) are skipped
+	// Checks creates the evaluators of the CHECK constraints of the tables being edited. Sessions without one can only
+	// edit tables which have no CHECK constraints.
+	Checks checks.EvaluatorFactory
 }
 
+// ErrNoCheckEvaluator is returned when a TableEditSession without a checks.EvaluatorFactory edits a table with CHECK
+// constraints.
+var ErrNoCheckEvaluator = errors.New("CHECK constraints can not be enforced by this edit session")
+
 // CreateTableEditSession creates and returns a TableEditSession. Inserting a nil root is not an error, as there are
 // locations that do not have a root at the time of this call. However, a root must be set through SetRoot before any
 // table editors are returned.
@@ -99,8 +108,8 @@ func (tes *TableEditSession) UpdateRoot(ctx context.Context, updatingFunc func(c
 	return tes.setRoot(ctx, newRoot)
 }
 
-// ValidateForeignKeys ensures that all open table editors conform to their foreign key constraints. This does not
-// consider any tables that do not have open editors.
+// ValidateForeignKeys ensures that all open table editors conform to their foreign key and CHECK constraints. This does
+// not consider any tables that do not have open editors.
 func (tes *TableEditSession) ValidateForeignKeys(ctx context.Context) error {
 	tes.writeMutex.Lock()
 	defer tes.writeMutex.Unlock()
@@ -116,7 +125,7 @@ func (tes *TableEditSession) ValidateForeignKeys(ctx context.Context) error {
 		// Otherwise, to preserve this edit session would create a much larger (and more difficult to understand) block
 		// of code. The primary perf hit comes from foreign keys that reference tables that declare foreign keys of
 		// their own, which is not common, so the average perf hit is relatively minimal.
-		validationTes := CreateTableEditSession(tes.root, TableEditSessionProps{})
+		validationTes := CreateTableEditSession(tes.root, TableEditSessionProps{Checks: tes.Props.Checks})
 		for tableName, _ := range tes.tables {
 			_, err = validationTes.getTableEditor(ctx, tableName, nil)
 			if err != nil {
@@ -243,6 +252,10 @@ func (tes *TableEditSession) getTableEditor(ctx context.Context, tableName strin
 		return nil, err
 	}
 	localTableEditor.tableEditor = tableEditor
+	localTableEditor.checks, err = tes.newCheckEvaluator(ctx, tableSch)
+	if err != nil {
+		return nil, err
+	}
 	if tes.Props.ForeignKeyChecksDisabled {
 		return localTableEditor, nil
 	}
@@ -260,6 +273,17 @@ func (tes *TableEditSession) getTableEditor(ctx context.Context, tableName strin
 	return localTableEditor, nil
 }
 
+// newCheckEvaluator returns the evaluator of the CHECK constraints of a table, or nil if the table has none.
+func (tes *TableEditSession) newCheckEvaluator(ctx context.Context, sch schema.Schema) (checks.Evaluator, error) {
+	if sch.Checks().Count() == 0 {
+		return nil, nil
+	} else if tes.Props.Checks == nil {
+		return nil, ErrNoCheckEvaluator
+	}
+
+	return tes.Props.Checks(ctx, sch)
+}
+
 // loadForeignKeys loads all tables mentioned in foreign keys for the given editor
 func (tes *TableEditSession) loadForeignKeys(ctx context.Context, localTableEditor *sessionedTableEditor) error {
 	// these are the tables that reference us, so we need to update them
@@ -315,6 +339,10 @@ func (tes *TableEditSession) setRoot(ctx context.Context, root *doltdb.RootValue
 			return err
 		}
 		localTableEditor.tableEditor = newTableEditor
+		localTableEditor.checks, err = tes.newCheckEvaluator(ctx, tSch)
+		if err != nil {
+			return err
+		}
 		localTableEditor.referencedTables, localTableEditor.referencingTables = fkCollection.KeysForTable(tableName)
 		err = tes.loadForeignKeys(ctx, localTableEditor)
 		if err != nil {
@@ -19,11 +19,13 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/row"
 	"github.com/dolthub/dolt/go/libraries/doltcore/rowconv"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/checks"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/typed/noms"
 	"github.com/dolthub/dolt/go/store/types"
 )
@@ -118,7 +120,7 @@ func ReadAllRows(ctx context.Context, rd TableReader, contOnBadRow bool) ([]row.
 
 // ForeignKeyIsSatisfied ensures that the foreign key is valid by comparing the index data from the given table
 // against the index data from the referenced table.
-func ForeignKeyIsSatisfied(ctx context.Context, fk doltdb.ForeignKey, childIdx, parentIdx types.Map, childDef, parentDef schema.Index) error {
+func ForeignKeyIsSatisfied(ctx context.Context, vrw types.ValueReadWriter, fk doltdb.ForeignKey, childIdx, parentIdx types.Map, childDef, parentDef schema.Index) error {
 	if fk.ReferencedTableIndex != parentDef.Name() {
 		return fmt.Errorf("cannot validate data as wrong referenced index was given: expected `%s` but received `%s`",
 			fk.ReferencedTableIndex, parentDef.Name())
@@ -135,7 +137,7 @@ func ForeignKeyIsSatisfied(ctx context.Context, fk doltdb.ForeignKey, childIdx,
 		return err
 	}
 
-	rc, err := rowconv.NewRowConverter(fm)
+	rc, err := rowconv.NewRowConverter(ctx, vrw, fm)
 	if err != nil {
 		return err
 	}
@@ -186,3 +188,57 @@ func ForeignKeyIsSatisfied(ctx context.Context, fk doltdb.ForeignKey, childIdx,
 
 	return nil
 }
+
+// ChecksAreSatisfied ensures that every row of the given table satisfies the table's CHECK constraints, which are
+// evaluated by the evaluator returned by newChecks, returning an error that describes each violated constraint.
+func ChecksAreSatisfied(ctx context.Context, tblName string, tbl *doltdb.Table, newChecks checks.EvaluatorFactory) error {
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return err
+	}
+	if sch.Checks().Count() == 0 {
+		return nil
+	}
+
+	evaluator, err := newChecks(ctx, sch)
+	if err != nil {
+		return err
+	}
+
+	rdr, err := NewDoltTableReader(ctx, tbl)
+	if err != nil {
+		return err
+	}
+	defer rdr.Close(ctx)
+
+	violations := make(map[string]int)
+	for {
+		r, err := rdr.ReadRow(ctx)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		violated, err := evaluator.Violations(ctx, r)
+		if err != nil {
+			return err
+		}
+		for _, check := range violated {
+			violations[check.Name]++
+		}
+	}
+
+	var errs []string
+	for _, check := range sch.Checks().AllChecks() {
+		if n, ok := violations[check.Name]; ok {
+			errs = append(errs, fmt.Sprintf("check constraint `%s` on `%s` is violated by %d row(s): %s", check.Name, tblName, n, check.Expression))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "\n"))
+	}
+
+	return nil
+}
@@ -104,24 +104,6 @@ func newKeylessTableReader(ctx context.Context, tbl *doltdb.Table, sch schema.Sc
 	}, nil
 }
 
-// TODO: this is broken! (for partition boundaries that hit rows with cardinality > 1)
-func newKeylessTableReaderForPartition(ctx context.Context, tbl *doltdb.Table, sch schema.Schema, start, end uint64) (SqlTableReader, error) {
-	rows, err := tbl.GetRowData(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	iter, err := rows.BufferedIteratorAt(ctx, start)
-	if err != nil {
-		return nil, err
-	}
-
-	return &keylessTableReader{
-		iter: iter,
-		sch:  sch,
-	}, nil
-}
-
 func newKeylessTableReaderFrom(ctx context.Context, tbl *doltdb.Table, sch schema.Schema, val types.Value) (SqlTableReader, error) {
 	rows, err := tbl.GetRowData(ctx)
 	if err != nil {
@@ -175,7 +175,7 @@ func encodeKeylessSqlRows(nbf *types.NomsBinFormat, sch schema.Schema, r sql.Row
 		v := r[idx]
 		if v != nil {
 			vals[2*idx+2] = types.Uint(tag)
-			vals[2*idx+3], err = col.TypeInfo.ConvertValueToNomsValue(v)
+			vals[2*idx+3], err = col.TypeInfo.ConvertValueToNomsValue(context.Background(), nil, v)
 		}
 		idx++
 
@@ -109,40 +109,3 @@ func newPkTableReaderFrom(ctx context.Context, tbl *doltdb.Table, sch schema.Sch
 		sch:  sch,
 	}, nil
 }
-
-type partitionTableReader struct {
-	SqlTableReader
-	remaining uint64
-}
-
-var _ SqlTableReader = &partitionTableReader{}
-
-func newPkTableReaderForPartition(ctx context.Context, tbl *doltdb.Table, sch schema.Schema, start, end uint64) (SqlTableReader, error) {
-	rows, err := tbl.GetRowData(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	iter, err := rows.BufferedIteratorAt(ctx, start)
-	if err != nil {
-		return nil, err
-	}
-
-	return &partitionTableReader{
-		SqlTableReader: pkTableReader{
-			iter: iter,
-			sch:  sch,
-		},
-		remaining: end - start,
-	}, nil
-}
-
-// ReadSqlRow implements the SqlTableReader interface.
-func (rdr *partitionTableReader) ReadSqlRow(ctx context.Context) (sql.Row, error) {
-	if rdr.remaining == 0 {
-		return nil, io.EOF
-	}
-	rdr.remaining -= 1
-
-	return rdr.SqlTableReader.ReadSqlRow(ctx)
-}
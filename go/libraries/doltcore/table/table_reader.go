@@ -16,7 +16,6 @@ package table
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/dolthub/go-mysql-server/sql"
 
@@ -97,22 +96,37 @@ func NewBufferedTableReader(ctx context.Context, tbl *doltdb.Table) (SqlTableRea
 	return newPkTableReader(ctx, tbl, sch, true)
 }
 
-// NewBufferedTableReaderForPartition creates a SqlTableReader that reads the rows of |tbl| with indexes
-// in the half-open interval [start, end).
-func NewBufferedTableReaderForPartition(ctx context.Context, tbl *doltdb.Table, start, end uint64) (SqlTableReader, error) {
-	if start > end {
-		return nil, fmt.Errorf("invalid partition table reader, start (%d) > end (%d)", start, end)
+// NewTableReaderForKeyRange creates a SqlTableReader that reads the rows of |tbl| whose types.Map keys are in the
+// half-open interval (start, end]. A nil |start| reads from the first record, and a nil |end| reads to the last.
+func NewTableReaderForKeyRange(ctx context.Context, tbl *doltdb.Table, start, end types.Value) (SqlTableReader, error) {
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	sch, err := tbl.GetSchema(ctx)
+	rows, err := tbl.GetRowData(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	var iter types.MapIterator
+	if start == nil {
+		iter, err = rows.BufferedIterator(ctx)
+	} else {
+		iter, err = rows.IteratorFrom(ctx, start)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if start != nil || end != nil {
+		iter = &keyRangeIterator{MapIterator: iter, start: start, end: end, nbf: rows.Format()}
+	}
+
 	if schema.IsKeyless(sch) {
-		return newKeylessTableReaderForPartition(ctx, tbl, sch, start, end)
+		return &keylessTableReader{iter: iter, sch: sch}, nil
 	}
-	return newPkTableReaderForPartition(ctx, tbl, sch, start, end)
+	return pkTableReader{iter: iter, sch: sch}, nil
 }
 
 // NewTableReaderFrom creates a SqlTableReader that reads the rows of |tbl| beginning at the record
@@ -128,3 +142,39 @@ func NewTableReaderFrom(ctx context.Context, tbl *doltdb.Table, val types.Value)
 	}
 	return newPkTableReaderFrom(ctx, tbl, sch, val)
 }
+
+// keyRangeIterator is a types.MapIterator that skips |start| if it is the first key, and stops after the last key that
+// is not greater than |end|. A nil |end| does not stop the iterator.
+type keyRangeIterator struct {
+	types.MapIterator
+	start types.Value
+	end   types.Value
+	nbf   *types.NomsBinFormat
+}
+
+// Next implements the types.MapIterator interface.
+func (itr *keyRangeIterator) Next(ctx context.Context) (types.Value, types.Value, error) {
+	key, val, err := itr.MapIterator.Next(ctx)
+	if err != nil || key == nil {
+		return key, val, err
+	}
+
+	if itr.start != nil {
+		isStart := key.Equals(itr.start)
+		itr.start = nil
+		if isStart {
+			return itr.Next(ctx)
+		}
+	}
+
+	if itr.end != nil {
+		isLess, err := itr.end.Less(itr.nbf, key)
+		if err != nil {
+			return nil, nil, err
+		} else if isLess {
+			return nil, nil, nil
+		}
+	}
+
+	return key, val, nil
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/chunks"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
@@ -76,7 +77,7 @@ func TestReader(t *testing.T) {
 	sch, err := schema.SchemaFromCols(colColl)
 	require.NoError(t, err)
 
-	reader, err := OpenJSONReader(types.Format_LD_1, "file.json", fs, sch)
+	reader, err := OpenJSONReader(types.NewValueStore((&chunks.MemoryStorage{}).NewView()), "file.json", fs, sch)
 	require.NoError(t, err)
 
 	verifySchema, err := reader.VerifySchema(sch)
@@ -156,7 +157,7 @@ func TestReaderBadJson(t *testing.T) {
 	sch, err := schema.SchemaFromCols(colColl)
 	require.NoError(t, err)
 
-	reader, err := OpenJSONReader(types.Format_LD_1, "file.json", fs, sch)
+	reader, err := OpenJSONReader(types.NewValueStore((&chunks.MemoryStorage{}).NewView()), "file.json", fs, sch)
 	require.NoError(t, err)
 
 	err = nil
@@ -177,7 +178,7 @@ func newRow(sch schema.Schema, id int, first, last string) row.Row {
 		2: types.String(last),
 	}
 
-	r, err := row.New(types.Format_LD_1, sch, vals)
+	r, err := row.New(types.Format_7_18, sch, vals)
 
 	if err != nil {
 		panic(err)
@@ -84,7 +84,9 @@ func (jsonw *JSONWriter) WriteRow(ctx context.Context, r row.Row) error {
 		}
 
 		switch col.TypeInfo.GetTypeIdentifier() {
-		case typeinfo.DatetimeTypeIdentifier,
+		case typeinfo.BlobBinaryTypeIdentifier,
+			typeinfo.BlobStringTypeIdentifier,
+			typeinfo.DatetimeTypeIdentifier,
 			typeinfo.DecimalTypeIdentifier,
 			typeinfo.EnumTypeIdentifier,
 			typeinfo.InlineBlobTypeIdentifier,
@@ -100,6 +102,14 @@ func (jsonw *JSONWriter) WriteRow(ctx context.Context, r row.Row) error {
 			}
 			val = types.String(*v)
 
+		case typeinfo.JSONTypeIdentifier:
+			v, err := col.TypeInfo.FormatValue(val)
+			if err != nil {
+				return true, err
+			}
+			colValMap[col.Name] = json.RawMessage(*v)
+			return false, nil
+
 		case typeinfo.BitTypeIdentifier,
 			typeinfo.BoolTypeIdentifier,
 			typeinfo.VarStringTypeIdentifier,
@@ -19,6 +19,7 @@ import (
 	"context"
 	"errors"
 	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
@@ -116,6 +117,12 @@ func (csvw *CSVWriter) WriteRow(ctx context.Context, r row.Row) error {
 		var v string
 		if val.Kind() == types.StringKind {
 			v = string(val.(types.String))
+		} else if val.Kind() == types.BlobKind {
+			data, err := ioutil.ReadAll(val.(types.Blob).Reader(ctx))
+			if err != nil {
+				return false, err
+			}
+			v = string(data)
 		} else {
 			v, err = types.EncodedValue(ctx, val)
 			if err != nil {
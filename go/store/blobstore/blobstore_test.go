@@ -85,6 +85,7 @@ func newBlobStoreTests() []BlobstoreTest {
 	var tests []BlobstoreTest
 	tests = append(tests, BlobstoreTest{"inmem", NewInMemoryBlobstore(), 10, 20})
 	tests = appendLocalTest(tests)
+	tests = appendS3Test(tests)
 	tests = appendGCSTest(tests)
 
 	return tests
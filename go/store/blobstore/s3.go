@@ -0,0 +1,394 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+const (
+	s3NoSuchKeyCode = "NoSuchKey"
+	s3NotFoundCode  = "NotFound"
+
+	// s3NullVersion is the version id of objects written to buckets without versioning
+	s3NullVersion = "null"
+
+	s3LockSuffix  = ".lock"
+	s3LockTimeout = time.Minute
+
+	// s3PartSize is the size of the parts of a multipart upload. Blobs which fit in a single part are written with a
+	// single put. 5MB is the smallest part size S3 accepts.
+	s3PartSize = 5 * 1024 * 1024
+)
+
+// ErrS3VersioningDisabled is returned by S3Blobstore.CheckAndPut when the bucket does not have versioning enabled.
+var ErrS3VersioningDisabled = errors.New("versioning must be enabled on the s3 bucket")
+
+// S3API is the subset of the s3 client api used by S3Blobstore. It is satisfied by *s3.S3.
+type S3API interface {
+	HeadObjectWithContext(ctx aws.Context, input *s3.HeadObjectInput, opts ...request.Option) (*s3.HeadObjectOutput, error)
+	GetObjectWithContext(ctx aws.Context, input *s3.GetObjectInput, opts ...request.Option) (*s3.GetObjectOutput, error)
+	PutObjectWithContext(ctx aws.Context, input *s3.PutObjectInput, opts ...request.Option) (*s3.PutObjectOutput, error)
+	DeleteObjectWithContext(ctx aws.Context, input *s3.DeleteObjectInput, opts ...request.Option) (*s3.DeleteObjectOutput, error)
+	ListObjectVersionsWithContext(ctx aws.Context, input *s3.ListObjectVersionsInput, opts ...request.Option) (*s3.ListObjectVersionsOutput, error)
+	CreateMultipartUploadWithContext(ctx aws.Context, input *s3.CreateMultipartUploadInput, opts ...request.Option) (*s3.CreateMultipartUploadOutput, error)
+	UploadPartWithContext(ctx aws.Context, input *s3.UploadPartInput, opts ...request.Option) (*s3.UploadPartOutput, error)
+	CompleteMultipartUploadWithContext(ctx aws.Context, input *s3.CompleteMultipartUploadInput, opts ...request.Option) (*s3.CompleteMultipartUploadOutput, error)
+	AbortMultipartUploadWithContext(ctx aws.Context, input *s3.AbortMultipartUploadInput, opts ...request.Option) (*s3.AbortMultipartUploadOutput, error)
+}
+
+// S3Blobstore provides an implementation of the Blobstore interface for S3 and S3 compatible object stores, such as
+// MinIO. The version of a blob is the version id of its object, and CheckAndPut requires the bucket to have object
+// versioning enabled.
+type S3Blobstore struct {
+	s3         S3API
+	bucketName string
+	prefix     string
+	partSize   int
+}
+
+// NewS3Blobstore creates a new instance of an S3Blobstore
+func NewS3Blobstore(s3 S3API, bucketName, prefix string) *S3Blobstore {
+	for len(prefix) > 0 && prefix[0] == '/' {
+		prefix = prefix[1:]
+	}
+
+	return &S3Blobstore{s3, bucketName, prefix, s3PartSize}
+}
+
+func (bs *S3Blobstore) absKey(key string) string {
+	return path.Join(bs.prefix, key)
+}
+
+func isS3NotFoundErr(err error) bool {
+	if awsErr, ok := err.(awserr.Error); ok {
+		return awsErr.Code() == s3NoSuchKeyCode || awsErr.Code() == s3NotFoundCode
+	}
+
+	return false
+}
+
+// Exists returns true if a blob exists for the given key, and false if it does not.
+func (bs *S3Blobstore) Exists(ctx context.Context, key string) (bool, error) {
+	_, err := bs.s3.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(bs.bucketName),
+		Key:    aws.String(bs.absKey(key)),
+	})
+
+	if isS3NotFoundErr(err) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// Get retrieves an io.reader for the portion of a blob specified by br along with
+// its version
+func (bs *S3Blobstore) Get(ctx context.Context, key string, br BlobRange) (io.ReadCloser, string, error) {
+	absKey := bs.absKey(key)
+	input := &s3.GetObjectInput{
+		Bucket: aws.String(bs.bucketName),
+		Key:    aws.String(absKey),
+	}
+
+	if br.offset < 0 && br.length != 0 {
+		// http ranges can't express a length from the end of the object, so the size of the current version is needed
+		head, err := bs.s3.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+			Bucket: aws.String(bs.bucketName),
+			Key:    aws.String(absKey),
+		})
+
+		if isS3NotFoundErr(err) {
+			return nil, "", NotFound{"s3://" + path.Join(bs.bucketName, absKey)}
+		} else if err != nil {
+			return nil, "", err
+		}
+
+		br = br.positiveRange(aws.Int64Value(head.ContentLength))
+		input.VersionId = head.VersionId
+	}
+
+	if !br.isAllRange() {
+		input.Range = aws.String(s3RangeHeader(br))
+	}
+
+	out, err := bs.s3.GetObjectWithContext(ctx, input)
+
+	if isS3NotFoundErr(err) {
+		return nil, "", NotFound{"s3://" + path.Join(bs.bucketName, absKey)}
+	} else if err != nil {
+		return nil, "", err
+	}
+
+	return out.Body, aws.StringValue(out.VersionId), nil
+}
+
+func s3RangeHeader(br BlobRange) string {
+	if br.offset < 0 {
+		return fmt.Sprintf("bytes=%d", br.offset)
+	} else if br.length == 0 {
+		return fmt.Sprintf("bytes=%d-", br.offset)
+	}
+
+	return fmt.Sprintf("bytes=%d-%d", br.offset, br.offset+br.length-1)
+}
+
+// put writes the data read from reader to a key. Only a single part of the data is held in memory at a time, as data
+// larger than a part is written with a multipart upload.
+func (bs *S3Blobstore) put(ctx context.Context, key string, reader io.Reader) (string, error) {
+	part := make([]byte, bs.partSize)
+	n, err := io.ReadFull(reader, part)
+
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		out, err := bs.s3.PutObjectWithContext(ctx, &s3.PutObjectInput{
+			Bucket: aws.String(bs.bucketName),
+			Key:    aws.String(bs.absKey(key)),
+			Body:   bytes.NewReader(part[:n]),
+		})
+
+		if err != nil {
+			return "", err
+		}
+
+		return aws.StringValue(out.VersionId), nil
+	} else if err != nil {
+		return "", err
+	}
+
+	return bs.multipartPut(ctx, key, part, reader)
+}
+
+// multipartPut writes a blob with a multipart upload, the first part of which has been read already. The upload is
+// aborted if any part of it fails.
+func (bs *S3Blobstore) multipartPut(ctx context.Context, key string, first []byte, reader io.Reader) (string, error) {
+	absKey := bs.absKey(key)
+	upload, err := bs.s3.CreateMultipartUploadWithContext(ctx, &s3.CreateMultipartUploadInput{
+		Bucket: aws.String(bs.bucketName),
+		Key:    aws.String(absKey),
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	ver, err := bs.uploadParts(ctx, absKey, upload.UploadId, first, reader)
+
+	if err != nil {
+		// the error of the upload is more useful than any error aborting it
+		_, _ = bs.s3.AbortMultipartUploadWithContext(ctx, &s3.AbortMultipartUploadInput{
+			Bucket:   aws.String(bs.bucketName),
+			Key:      aws.String(absKey),
+			UploadId: upload.UploadId,
+		})
+
+		return "", err
+	}
+
+	return ver, nil
+}
+
+func (bs *S3Blobstore) uploadParts(ctx context.Context, absKey string, uploadId *string, part []byte, reader io.Reader) (string, error) {
+	var completed []*s3.CompletedPart
+	for partNum := int64(1); len(part) > 0; partNum++ {
+		out, err := bs.s3.UploadPartWithContext(ctx, &s3.UploadPartInput{
+			Bucket:     aws.String(bs.bucketName),
+			Key:        aws.String(absKey),
+			UploadId:   uploadId,
+			PartNumber: aws.Int64(partNum),
+			Body:       bytes.NewReader(part),
+		})
+
+		if err != nil {
+			return "", err
+		}
+
+		completed = append(completed, &s3.CompletedPart{ETag: out.ETag, PartNumber: aws.Int64(partNum)})
+
+		n, err := io.ReadFull(reader, part[:cap(part)])
+
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return "", err
+		}
+
+		part = part[:n]
+	}
+
+	out, err := bs.s3.CompleteMultipartUploadWithContext(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(bs.bucketName),
+		Key:             aws.String(absKey),
+		UploadId:        uploadId,
+		MultipartUpload: &s3.CompletedMultipartUpload{Parts: completed},
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	return aws.StringValue(out.VersionId), nil
+}
+
+// Put sets the blob and the version for a key
+func (bs *S3Blobstore) Put(ctx context.Context, key string, reader io.Reader) (string, error) {
+	return bs.put(ctx, key, reader)
+}
+
+// CheckAndPut will check the current version of a blob against an expectedVersion, and if the
+// versions match it will update the data and version associated with the key.
+//
+// S3 has no conditional writes, so writers serialize through a lock object stored next to the blob. Each writer
+// writes a version of the lock object, and the oldest live version of the lock holds it. Writers which don't hold
+// the lock fail with a CheckAndPutError, the same as if the version had changed. This requires the bucket to have
+// versioning enabled.
+func (bs *S3Blobstore) CheckAndPut(ctx context.Context, expectedVersion, key string, reader io.Reader) (string, error) {
+	lockVer, ok, err := bs.lock(ctx, key)
+
+	if err != nil {
+		return "", err
+	} else if !ok {
+		return "", CheckAndPutError{key, expectedVersion, "unknown (locked by a concurrent writer)"}
+	}
+
+	// failing to release the lock is not an error for the write, as the lock expires after s3LockTimeout
+	defer bs.deleteVersion(ctx, key+s3LockSuffix, lockVer)
+
+	currVer, err := bs.currentVersion(ctx, key)
+
+	if err != nil {
+		return "", err
+	}
+
+	if currVer != expectedVersion {
+		return "", CheckAndPutError{key, expectedVersion, currVer}
+	}
+
+	return bs.put(ctx, key, reader)
+}
+
+// lock tries to acquire the lock of a key, returning the version of the lock object to delete to release it, and
+// false if another writer holds the lock.
+func (bs *S3Blobstore) lock(ctx context.Context, key string) (string, bool, error) {
+	lockKey := key + s3LockSuffix
+	ver, err := bs.put(ctx, lockKey, bytes.NewReader(nil))
+
+	if err != nil {
+		return "", false, err
+	}
+
+	if ver == "" || ver == s3NullVersion {
+		return "", false, ErrS3VersioningDisabled
+	}
+
+	holder, err := bs.lockHolder(ctx, lockKey, ver)
+
+	if err != nil || holder != ver {
+		bs.deleteVersion(ctx, lockKey, ver)
+		return "", false, err
+	}
+
+	return ver, true, nil
+}
+
+// lockHolder returns the oldest version of a lock object which was written less than s3LockTimeout before the
+// version given, the lock of which is being acquired.
+func (bs *S3Blobstore) lockHolder(ctx context.Context, lockKey, ver string) (string, error) {
+	absKey := bs.absKey(lockKey)
+	input := &s3.ListObjectVersionsInput{
+		Bucket: aws.String(bs.bucketName),
+		Prefix: aws.String(absKey),
+	}
+
+	// versions are listed from newest to oldest
+	var versions []*s3.ObjectVersion
+	for {
+		out, err := bs.s3.ListObjectVersionsWithContext(ctx, input)
+
+		if err != nil {
+			return "", err
+		}
+
+		for _, v := range out.Versions {
+			if aws.StringValue(v.Key) == absKey {
+				versions = append(versions, v)
+			}
+		}
+
+		if !aws.BoolValue(out.IsTruncated) {
+			break
+		}
+
+		input.KeyMarker = out.NextKeyMarker
+		input.VersionIdMarker = out.NextVersionIdMarker
+	}
+
+	found := false
+	var lockTime time.Time
+	for i, v := range versions {
+		if aws.StringValue(v.VersionId) == ver {
+			found = true
+			lockTime = aws.TimeValue(v.LastModified)
+			versions = versions[i:]
+			break
+		}
+	}
+
+	if !found {
+		return "", fmt.Errorf("version %s of %s not found", ver, absKey)
+	}
+
+	for i := len(versions) - 1; i >= 0; i-- {
+		if lockTime.Sub(aws.TimeValue(versions[i].LastModified)) < s3LockTimeout {
+			return aws.StringValue(versions[i].VersionId), nil
+		}
+	}
+
+	return ver, nil
+}
+
+// currentVersion returns the version of a key, or "" if it doesn't exist.
+func (bs *S3Blobstore) currentVersion(ctx context.Context, key string) (string, error) {
+	out, err := bs.s3.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(bs.bucketName),
+		Key:    aws.String(bs.absKey(key)),
+	})
+
+	if isS3NotFoundErr(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+
+	return aws.StringValue(out.VersionId), nil
+}
+
+func (bs *S3Blobstore) deleteVersion(ctx context.Context, key, ver string) error {
+	_, err := bs.s3.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket:    aws.String(bs.bucketName),
+		Key:       aws.String(bs.absKey(key)),
+		VersionId: aws.String(ver),
+	})
+
+	return err
+}
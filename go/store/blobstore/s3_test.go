@@ -0,0 +1,324 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeS3Version struct {
+	id           string
+	data         []byte
+	lastModified time.Time
+}
+
+// fakeS3 is an in memory implementation of the S3API of a single bucket, which behaves like S3 or MinIO with
+// versioning enabled, or disabled if unversioned is set. Each call waits for latency before it is processed, so that
+// concurrent calls interleave.
+type fakeS3 struct {
+	mu          sync.Mutex
+	latency     time.Duration
+	unversioned bool
+	nextVer     int
+	objects     map[string][]fakeS3Version  // key -> versions from oldest to newest
+	uploads     map[string]map[int64][]byte // upload id -> part number -> data
+	nextUpload  int
+}
+
+func newFakeS3() *fakeS3 {
+	return &fakeS3{objects: make(map[string][]fakeS3Version), uploads: make(map[string]map[int64][]byte)}
+}
+
+func (m *fakeS3) latest(key string) (fakeS3Version, bool) {
+	versions := m.objects[key]
+	if len(versions) == 0 {
+		return fakeS3Version{}, false
+	}
+
+	return versions[len(versions)-1], true
+}
+
+func (m *fakeS3) HeadObjectWithContext(ctx aws.Context, input *s3.HeadObjectInput, opts ...request.Option) (*s3.HeadObjectOutput, error) {
+	time.Sleep(m.latency)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	v, ok := m.latest(aws.StringValue(input.Key))
+	if !ok {
+		return nil, awserr.New(s3NotFoundCode, "not found", nil)
+	}
+
+	return &s3.HeadObjectOutput{ContentLength: aws.Int64(int64(len(v.data))), VersionId: aws.String(v.id)}, nil
+}
+
+func (m *fakeS3) GetObjectWithContext(ctx aws.Context, input *s3.GetObjectInput, opts ...request.Option) (*s3.GetObjectOutput, error) {
+	time.Sleep(m.latency)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := aws.StringValue(input.Key)
+	v, ok := m.latest(key)
+	if input.VersionId != nil {
+		ok = false
+		for _, curr := range m.objects[key] {
+			if curr.id == *input.VersionId {
+				v, ok = curr, true
+			}
+		}
+	}
+
+	if !ok {
+		return nil, awserr.New(s3NoSuchKeyCode, "no such key", nil)
+	}
+
+	data := v.data
+	if input.Range != nil {
+		rng := strings.TrimPrefix(*input.Range, "bytes=")
+		dash := strings.LastIndex(rng, "-")
+		if dash == 0 {
+			n, _ := strconv.Atoi(rng[1:])
+			data = data[len(data)-n:]
+		} else {
+			start, _ := strconv.Atoi(rng[:dash])
+			end := len(data) - 1
+			if dash < len(rng)-1 {
+				end, _ = strconv.Atoi(rng[dash+1:])
+			}
+			data = data[start : end+1]
+		}
+	}
+
+	return &s3.GetObjectOutput{Body: ioutil.NopCloser(bytes.NewReader(data)), VersionId: aws.String(v.id)}, nil
+}
+
+func (m *fakeS3) PutObjectWithContext(ctx aws.Context, input *s3.PutObjectInput, opts ...request.Option) (*s3.PutObjectOutput, error) {
+	time.Sleep(m.latency)
+	data, err := ioutil.ReadAll(input.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return &s3.PutObjectOutput{VersionId: aws.String(m.addVersion(aws.StringValue(input.Key), data))}, nil
+}
+
+// addVersion writes a new version of a key and returns its id.
+func (m *fakeS3) addVersion(key string, data []byte) string {
+	v := fakeS3Version{s3NullVersion, data, time.Now()}
+	if m.unversioned {
+		m.objects[key] = []fakeS3Version{v}
+	} else {
+		m.nextVer++
+		v.id = strconv.Itoa(m.nextVer)
+		m.objects[key] = append(m.objects[key], v)
+	}
+
+	return v.id
+}
+
+func (m *fakeS3) CreateMultipartUploadWithContext(ctx aws.Context, input *s3.CreateMultipartUploadInput, opts ...request.Option) (*s3.CreateMultipartUploadOutput, error) {
+	time.Sleep(m.latency)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.nextUpload++
+	id := strconv.Itoa(m.nextUpload)
+	m.uploads[id] = make(map[int64][]byte)
+
+	return &s3.CreateMultipartUploadOutput{UploadId: aws.String(id)}, nil
+}
+
+func (m *fakeS3) UploadPartWithContext(ctx aws.Context, input *s3.UploadPartInput, opts ...request.Option) (*s3.UploadPartOutput, error) {
+	time.Sleep(m.latency)
+	data, err := ioutil.ReadAll(input.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	parts, ok := m.uploads[aws.StringValue(input.UploadId)]
+	if !ok {
+		return nil, awserr.New("NoSuchUpload", "no such upload", nil)
+	}
+
+	parts[aws.Int64Value(input.PartNumber)] = data
+	return &s3.UploadPartOutput{ETag: aws.String(strconv.FormatInt(aws.Int64Value(input.PartNumber), 10))}, nil
+}
+
+func (m *fakeS3) CompleteMultipartUploadWithContext(ctx aws.Context, input *s3.CompleteMultipartUploadInput, opts ...request.Option) (*s3.CompleteMultipartUploadOutput, error) {
+	time.Sleep(m.latency)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	id := aws.StringValue(input.UploadId)
+	parts, ok := m.uploads[id]
+	if !ok {
+		return nil, awserr.New("NoSuchUpload", "no such upload", nil)
+	}
+
+	var data []byte
+	for _, part := range input.MultipartUpload.Parts {
+		partData, ok := parts[aws.Int64Value(part.PartNumber)]
+		if !ok {
+			return nil, awserr.New("InvalidPart", "invalid part", nil)
+		}
+
+		data = append(data, partData...)
+	}
+
+	delete(m.uploads, id)
+	return &s3.CompleteMultipartUploadOutput{VersionId: aws.String(m.addVersion(aws.StringValue(input.Key), data))}, nil
+}
+
+func (m *fakeS3) AbortMultipartUploadWithContext(ctx aws.Context, input *s3.AbortMultipartUploadInput, opts ...request.Option) (*s3.AbortMultipartUploadOutput, error) {
+	time.Sleep(m.latency)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.uploads, aws.StringValue(input.UploadId))
+	return &s3.AbortMultipartUploadOutput{}, nil
+}
+
+func (m *fakeS3) DeleteObjectWithContext(ctx aws.Context, input *s3.DeleteObjectInput, opts ...request.Option) (*s3.DeleteObjectOutput, error) {
+	time.Sleep(m.latency)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := aws.StringValue(input.Key)
+	var remaining []fakeS3Version
+	for _, v := range m.objects[key] {
+		if input.VersionId != nil && v.id != *input.VersionId {
+			remaining = append(remaining, v)
+		}
+	}
+
+	m.objects[key] = remaining
+	return &s3.DeleteObjectOutput{}, nil
+}
+
+func (m *fakeS3) ListObjectVersionsWithContext(ctx aws.Context, input *s3.ListObjectVersionsInput, opts ...request.Option) (*s3.ListObjectVersionsOutput, error) {
+	time.Sleep(m.latency)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	out := &s3.ListObjectVersionsOutput{IsTruncated: aws.Bool(false)}
+	for key, versions := range m.objects {
+		if !strings.HasPrefix(key, aws.StringValue(input.Prefix)) {
+			continue
+		}
+
+		for i := len(versions) - 1; i >= 0; i-- {
+			out.Versions = append(out.Versions, &s3.ObjectVersion{
+				Key:          aws.String(key),
+				VersionId:    aws.String(versions[i].id),
+				LastModified: aws.Time(versions[i].lastModified),
+			})
+		}
+	}
+
+	return out, nil
+}
+
+func appendS3Test(tests []BlobstoreTest) []BlobstoreTest {
+	fake := newFakeS3()
+	fake.latency = 100 * time.Microsecond
+	return append(tests, BlobstoreTest{"s3", NewS3Blobstore(fake, "bucket", "/prefix"), 10, 20})
+}
+
+func TestS3CheckAndPutLocking(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeS3()
+	bs := NewS3Blobstore(fake, "bucket", "db")
+
+	ver, err := CheckAndPutBytes(ctx, bs, "", key, []byte("first"))
+	require.NoError(t, err)
+	assert.Empty(t, fake.objects["db/"+key+s3LockSuffix], "the lock should be released")
+
+	// a concurrent writer holds the lock
+	_, err = PutBytes(ctx, bs, key+s3LockSuffix, nil)
+	require.NoError(t, err)
+	_, err = CheckAndPutBytes(ctx, bs, ver, key, []byte("second"))
+	assert.True(t, IsCheckAndPutError(err))
+
+	// the lock expires
+	locks := fake.objects["db/"+key+s3LockSuffix]
+	require.Len(t, locks, 1)
+	locks[0].lastModified = locks[0].lastModified.Add(-2 * s3LockTimeout)
+	newVer, err := CheckAndPutBytes(ctx, bs, ver, key, []byte("second"))
+	require.NoError(t, err)
+
+	data, currVer, err := GetBytes(ctx, bs, key, AllRange)
+	require.NoError(t, err)
+	assert.Equal(t, "second", string(data))
+	assert.Equal(t, newVer, currVer)
+
+	_, err = CheckAndPutBytes(ctx, bs, ver, key, []byte("third"))
+	assert.True(t, IsCheckAndPutError(err))
+}
+
+func TestS3VersioningDisabled(t *testing.T) {
+	fake := newFakeS3()
+	fake.unversioned = true
+	bs := NewS3Blobstore(fake, "bucket", "")
+
+	_, err := CheckAndPutBytes(context.Background(), bs, "", key, []byte("data"))
+	assert.Equal(t, ErrS3VersioningDisabled, err)
+}
+
+func TestS3MultipartPut(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeS3()
+	bs := NewS3Blobstore(fake, "bucket", "db")
+	bs.partSize = 4
+
+	for _, data := range []string{"", "abc", "abcd", "abcdefghij", "abcdefghijkl"} {
+		ver, err := PutBytes(ctx, bs, key, []byte(data))
+		require.NoError(t, err)
+
+		read, currVer, err := GetBytes(ctx, bs, key, AllRange)
+		require.NoError(t, err)
+		assert.Equal(t, data, string(read))
+		assert.Equal(t, ver, currVer)
+	}
+
+	assert.Empty(t, fake.uploads)
+
+	// a failed read aborts the upload
+	rd := io.MultiReader(strings.NewReader("abcdefghij"), iotest.TimeoutReader(strings.NewReader("x")))
+	_, err := bs.Put(ctx, "other", rd)
+	assert.Error(t, err)
+	assert.Empty(t, fake.uploads)
+	assert.Empty(t, fake.objects["db/other"])
+}
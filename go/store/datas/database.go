@@ -24,11 +24,13 @@ package datas
 
 import (
 	"context"
+	"errors"
 	"io"
 
 	"github.com/dolthub/dolt/go/store/nbs"
 
 	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
@@ -158,6 +160,33 @@ func NewDatabase(cs chunks.ChunkStore) Database {
 	return newDatabase(cs)
 }
 
+// NewDatabaseWithFallback returns a Database over the chunks of the local Database db, which reads the chunks missing
+// from db from the Database returned by openFallback. openFallback is called the first time a chunk is missing. As the
+// values referenced by the values written to the returned Database may only exist in the fallback, it doesn't check
+// that they are present when it commits.
+func NewDatabaseWithFallback(db Database, openFallback func(ctx context.Context) (Database, error)) (Database, error) {
+	local, ok := db.chunkStore().(*nbs.NBSMetricWrapper)
+
+	if !ok {
+		return nil, errors.New("reading missing chunks from a fallback requires a local database")
+	}
+
+	cs := nbs.NewFallbackStore(local, func(ctx context.Context) (chunks.ChunkStore, error) {
+		fallback, err := openFallback(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return fallback.chunkStore(), nil
+	})
+
+	fdb := newDatabase(cs)
+	fdb.SetEnforceCompleteness(false)
+
+	return fdb, nil
+}
+
 // GarbageCollector provides a method to
 // remove unreferenced data from a store.
 type GarbageCollector interface {
@@ -179,6 +208,12 @@ func CanUsePuller(db Database) bool {
 	return false
 }
 
+// HasChunk returns true if the chunk with the hash given is stored in the chunk store of a Database. Unlike reading the
+// value of the hash, it doesn't consult the fallback of a Database created by NewDatabaseWithFallback.
+func HasChunk(ctx context.Context, db Database, h hash.Hash) (bool, error) {
+	return db.chunkStore().Has(ctx, h)
+}
+
 func GetCSStatSummaryForDB(db Database) string {
 	cs := db.chunkStore()
 	return cs.StatsSummary()
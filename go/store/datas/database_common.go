@@ -216,13 +216,9 @@ func (db *database) doSetHead(ctx context.Context, ds Dataset, newHeadRef types.
 		return err
 	}
 
-	refSt, err := db.WriteValue(ctx, newSt) // will be orphaned if the tryCommitChunks() below fails
-
-	if err != nil {
-		return err
-	}
-
-	ref, err := types.ToRefOfValue(refSt, db.Format())
+	// The new head was read above, so it doesn't need to be written again. Rewriting it would require the values it
+	// references to be present as well, which is not the case for the commits at the boundary of a shallow clone.
+	ref, err := types.ToRefOfValue(newHeadRef, db.Format())
 
 	if err != nil {
 		return err
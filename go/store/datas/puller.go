@@ -262,6 +262,14 @@ func (p *Puller) processCompletedTables(ctx context.Context, ae *atomicerr.Atomi
 	}
 }
 
+// Exclude prevents the chunks given, and the chunks only reachable through them, from being pulled. It is used to pull
+// a commit without the history behind a set of its ancestors.
+func (p *Puller) Exclude(hashes hash.HashSet) {
+	for h := range hashes {
+		p.downloaded.Insert(h)
+	}
+}
+
 // Pull executes the sync operation
 func (p *Puller) Pull(ctx context.Context) error {
 	twDetails := &TreeWalkEventDetails{TreeLevel: -1}
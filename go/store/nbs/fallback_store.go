@@ -0,0 +1,173 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"sync"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// FallbackStore is a ChunkStore implementation that wraps a local NBSMetricWrapper, and reads the chunks missing from
+// it from a fallback ChunkStore. It is used by sparse clones, which only fetch some of the chunks of a remote, to read
+// the rest of the remote's chunks when they are accessed. Has and HasMany only report the chunks of the local store,
+// so that pulls into the store still fetch the chunks it is missing. Chunks read from the fallback are not persisted.
+type FallbackStore struct {
+	*NBSMetricWrapper
+
+	mu           sync.Mutex
+	openFallback func(ctx context.Context) (chunks.ChunkStore, error)
+	fallback     chunks.ChunkStore
+}
+
+// NewFallbackStore returns a new FallbackStore. openFallback is called the first time a chunk is missing from the local
+// store, so that the fallback is only opened when it is needed.
+func NewFallbackStore(local *NBSMetricWrapper, openFallback func(ctx context.Context) (chunks.ChunkStore, error)) *FallbackStore {
+	return &FallbackStore{NBSMetricWrapper: local, openFallback: openFallback}
+}
+
+var _ TableFileStore = &FallbackStore{}
+var _ chunks.ChunkStoreGarbageCollector = &FallbackStore{}
+
+func (fs *FallbackStore) getFallback(ctx context.Context) (chunks.ChunkStore, error) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	if fs.fallback == nil {
+		fallback, err := fs.openFallback(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		fs.fallback = fallback
+	}
+
+	return fs.fallback, nil
+}
+
+// Get the Chunk for the value of the hash in the store. If the hash is absent from the local store it is read from the
+// fallback, and if it is absent from both chunks.EmptyChunk is returned.
+func (fs *FallbackStore) Get(ctx context.Context, h hash.Hash) (chunks.Chunk, error) {
+	c, err := fs.NBSMetricWrapper.Get(ctx, h)
+
+	if err != nil || !c.IsEmpty() {
+		return c, err
+	}
+
+	fallback, err := fs.getFallback(ctx)
+
+	if err != nil {
+		return chunks.EmptyChunk, err
+	}
+
+	return fallback.Get(ctx, h)
+}
+
+// GetMany gets the Chunks with |hashes| from the local store, and the ones missing from it from the fallback.
+func (fs *FallbackStore) GetMany(ctx context.Context, hashes hash.HashSet, found func(*chunks.Chunk)) error {
+	missing, err := fs.getLocal(hashes, func(foundLocal func(hash.Hash)) error {
+		return fs.NBSMetricWrapper.GetMany(ctx, hashes, func(c *chunks.Chunk) {
+			foundLocal(c.Hash())
+			found(c)
+		})
+	})
+
+	if err != nil || len(missing) == 0 {
+		return err
+	}
+
+	fallback, err := fs.getFallback(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	return fallback.GetMany(ctx, missing, found)
+}
+
+// GetManyCompressed gets the compressed Chunks with |hashes| from the local store, and the ones missing from it from
+// the fallback. The chunks of a fallback which can't read compressed chunks are read with GetMany and compressed.
+func (fs *FallbackStore) GetManyCompressed(ctx context.Context, hashes hash.HashSet, found func(CompressedChunk)) error {
+	missing, err := fs.getLocal(hashes, func(foundLocal func(hash.Hash)) error {
+		return fs.NBSMetricWrapper.GetManyCompressed(ctx, hashes, func(c CompressedChunk) {
+			foundLocal(c.H)
+			found(c)
+		})
+	})
+
+	if err != nil || len(missing) == 0 {
+		return err
+	}
+
+	fallback, err := fs.getFallback(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	if cmpCS, ok := fallback.(interface {
+		GetManyCompressed(ctx context.Context, hashes hash.HashSet, found func(CompressedChunk)) error
+	}); ok {
+		return cmpCS.GetManyCompressed(ctx, missing, found)
+	}
+
+	return fallback.GetMany(ctx, missing, func(c *chunks.Chunk) {
+		found(ChunkToCompressedChunk(*c))
+	})
+}
+
+// getLocal calls get, which reads chunks from the local store and reports the hashes found through the callback it is
+// given, and returns the hashes which were not found.
+func (fs *FallbackStore) getLocal(hashes hash.HashSet, get func(foundLocal func(hash.Hash)) error) (hash.HashSet, error) {
+	var mu sync.Mutex
+	foundLocal := make(hash.HashSet, len(hashes))
+	err := get(func(h hash.Hash) {
+		mu.Lock()
+		defer mu.Unlock()
+		foundLocal.Insert(h)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	missing := make(hash.HashSet)
+	for h := range hashes {
+		if !foundLocal.Has(h) {
+			missing.Insert(h)
+		}
+	}
+
+	return missing, nil
+}
+
+// Close closes the local store, and the fallback if it was opened.
+func (fs *FallbackStore) Close() error {
+	err := fs.NBSMetricWrapper.Close()
+
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	if fs.fallback != nil {
+		if fallbackErr := fs.fallback.Close(); err == nil {
+			err = fallbackErr
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,95 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+func TestFallbackStore(t *testing.T) {
+	ctx := context.Background()
+	st, _ := makeTestLocalStore(t, defaultMaxTables)
+
+	localChunk := chunks.NewChunk([]byte("local"))
+	err := st.Put(ctx, localChunk)
+	require.NoError(t, err)
+
+	remoteChunk := chunks.NewChunk([]byte("remote"))
+	remote := (&chunks.MemoryStorage{}).NewView()
+	err = remote.Put(ctx, remoteChunk)
+	require.NoError(t, err)
+
+	opened := 0
+	fs := NewFallbackStore(NewNBSMetricWrapper(st), func(ctx context.Context) (chunks.ChunkStore, error) {
+		opened++
+		return remote, nil
+	})
+
+	c, err := fs.Get(ctx, localChunk.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, localChunk.Data(), c.Data())
+	assert.Equal(t, 0, opened, "the fallback should only be opened when a chunk is missing")
+
+	c, err = fs.Get(ctx, remoteChunk.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, remoteChunk.Data(), c.Data())
+
+	missing := chunks.NewChunk([]byte("missing"))
+	c, err = fs.Get(ctx, missing.Hash())
+	require.NoError(t, err)
+	assert.True(t, c.IsEmpty())
+
+	var mu sync.Mutex
+	found := make(hash.HashSet)
+	err = fs.GetMany(ctx, hash.NewHashSet(localChunk.Hash(), remoteChunk.Hash(), missing.Hash()), func(c *chunks.Chunk) {
+		mu.Lock()
+		defer mu.Unlock()
+		found.Insert(c.Hash())
+	})
+	require.NoError(t, err)
+	assert.Equal(t, hash.NewHashSet(localChunk.Hash(), remoteChunk.Hash()), found)
+
+	// the fallback can't read compressed chunks, so its chunks are compressed after they are read
+	compressed := make(map[hash.Hash][]byte)
+	err = fs.GetManyCompressed(ctx, hash.NewHashSet(localChunk.Hash(), remoteChunk.Hash(), missing.Hash()), func(cc CompressedChunk) {
+		c, err := cc.ToChunk()
+		assert.NoError(t, err)
+
+		mu.Lock()
+		defer mu.Unlock()
+		compressed[cc.H] = c.Data()
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[hash.Hash][]byte{localChunk.Hash(): localChunk.Data(), remoteChunk.Hash(): remoteChunk.Data()}, compressed)
+
+	// Has only reports the chunks of the local store
+	ok, err := fs.Has(ctx, localChunk.Hash())
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = fs.Has(ctx, remoteChunk.Hash())
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.Equal(t, 1, opened)
+	require.NoError(t, fs.Close())
+}
@@ -198,6 +198,30 @@ func (b Blob) Concat(ctx context.Context, other Blob) (Blob, error) {
 	return newBlob(seq), nil
 }
 
+// Diff streams the byte ranges that differ between last and this Blob to the changes channel. Only the chunks of the
+// two Blobs which differ are visited.
+func (b Blob) Diff(ctx context.Context, last Blob, changes chan<- Splice) error {
+	return b.DiffWithLimit(ctx, last, changes, DEFAULT_MAX_SPLICE_MATRIX_SIZE)
+}
+
+// DiffWithLimit streams the byte ranges that differ between last and this Blob to the changes channel. The
+// maxSpliceMatrixSize determines how big of an edit distance matrix we are willing to compute for a pair of changed
+// chunks versus just reporting that every byte within them changed.
+func (b Blob) DiffWithLimit(ctx context.Context, last Blob, changes chan<- Splice, maxSpliceMatrixSize uint64) error {
+	if b.Equals(last) {
+		return nil
+	}
+	bLen, lastLen := b.Len(), last.Len()
+	if bLen == 0 {
+		return sendSpliceChange(ctx, changes, Splice{0, lastLen, 0, 0}) // everything removed
+	}
+	if lastLen == 0 {
+		return sendSpliceChange(ctx, changes, Splice{0, 0, bLen, 0}) // everything added
+	}
+
+	return indexedSequenceDiff(ctx, last.sequence, 0, b.sequence, 0, changes, maxSpliceMatrixSize)
+}
+
 func (b Blob) newChunker(ctx context.Context, cur *sequenceCursor, vrw ValueReadWriter) (*sequenceChunker, error) {
 	return newSequenceChunker(ctx, cur, 0, vrw, makeBlobLeafChunkFn(vrw), newIndexedMetaSequenceChunkFn(BlobKind, vrw), hashValueByte)
 }
@@ -334,3 +334,47 @@ func TestStreamingParallelBlob(t *testing.T) {
 	assert.NoError(err)
 	assert.True(bytes.Equal(buff, outBuff.Bytes()))
 }
+
+func accumulateBlobDiffSplices(b1, b2 Blob) (diff []Splice, err error) {
+	diffChan := make(chan Splice)
+	go func() {
+		err = b1.Diff(context.Background(), b2, diffChan)
+		close(diffChan)
+	}()
+	for splice := range diffChan {
+		diff = append(diff, splice)
+	}
+	return diff, err
+}
+
+func TestBlobDiff(t *testing.T) {
+	assert := assert.New(t)
+
+	vs := newTestValueStore()
+	buff := randomBuff(20 /* 1MB */)
+	b1, err := NewBlob(context.Background(), vs, bytes.NewReader(buff))
+	assert.NoError(err)
+
+	changed := make([]byte, 0, len(buff)+5)
+	changed = append(changed, buff[:500000]...)
+	changed = append(changed, buff[500000]+1)
+	changed = append(changed, buff[500001:700000]...)
+	changed = append(changed, []byte("hello")...)
+	changed = append(changed, buff[700000:]...)
+	b2, err := NewBlob(context.Background(), vs, bytes.NewReader(changed))
+	assert.NoError(err)
+
+	diff, err := accumulateBlobDiffSplices(b1, b1)
+	assert.NoError(err)
+	assert.Empty(diff)
+
+	diff, err = accumulateBlobDiffSplices(b2, b1)
+	assert.NoError(err)
+	assert.Equal([]Splice{{500000, 1, 1, 500000}, {700000, 0, 5, 700000}}, diff)
+
+	empty, err := NewEmptyBlob(vs)
+	assert.NoError(err)
+	diff, err = accumulateBlobDiffSplices(empty, b1)
+	assert.NoError(err)
+	assert.Equal([]Splice{{0, uint64(len(buff)), 0, 0}}, diff)
+}
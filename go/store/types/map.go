@@ -456,6 +456,100 @@ func (m Map) IterFrom(ctx context.Context, start Value, cb mapIterCallback) erro
 	})
 }
 
+// splitKeysChunksPerRange is the number of chunks SplitKeys tries to choose between for each range, which bounds how
+// unbalanced the ranges can be when the chunks of a level are of different sizes.
+const splitKeysChunksPerRange = 8
+
+// SplitKeys returns up to |n|-1 keys which divide the map into at most |n| key ranges of similar size. Each key is the
+// last key of a range, and every range ends at a chunk boundary of the map's prolly tree, so no leaf chunk is read by
+// more than one range. The keys are those of the meta tuples, so only meta sequences are loaded to find them. Maps that
+// fit in a single leaf chunk, or whose keys are not ordered by value, are not split.
+func (m Map) SplitKeys(ctx context.Context, n uint64) ([]Value, error) {
+	if n < 2 || m.orderedSequence.isLeaf() {
+		return nil, nil
+	}
+
+	// descend until a level of the tree has enough chunks to choose n balanced ranges from, stopping above the leaves
+	level := []sequence{m.orderedSequence}
+	for {
+		count := 0
+		for _, seq := range level {
+			count += seq.seqLen()
+		}
+
+		if uint64(count) >= n*splitKeysChunksPerRange || level[0].treeLevel() == 1 {
+			break
+		}
+
+		var next []sequence
+		for _, seq := range level {
+			children, err := seq.(metaSequence).getChildren(ctx, 0, uint64(seq.seqLen()))
+
+			if err != nil {
+				return nil, err
+			}
+
+			next = append(next, children...)
+		}
+
+		level = next
+	}
+
+	// the indexes at which each chunk of the level begins, other than the first, and the last keys of the chunks before
+	var boundaries []uint64
+	var boundaryKeys []Value
+	idx := uint64(0)
+	for _, seq := range level {
+		ms := seq.(metaSequence)
+		for i := 0; i < ms.seqLen(); i++ {
+			numLeaves, err := ms.getNumLeavesAt(i)
+
+			if err != nil {
+				return nil, err
+			}
+
+			key, err := ms.getKey(i)
+
+			if err != nil {
+				return nil, err
+			}
+
+			// keys ordered by their hashes can't bound ranges of keys
+			if !key.isOrderedByValue {
+				return nil, nil
+			}
+
+			idx += numLeaves
+			boundaries = append(boundaries, idx)
+			boundaryKeys = append(boundaryKeys, key.v)
+		}
+	}
+	boundaries = boundaries[:len(boundaries)-1]
+
+	// pick the boundary nearest to each of the evenly spaced indexes that would split the map into n equal ranges
+	var keys []Value
+	prev := uint64(0)
+	for i, k := 0, uint64(1); k < n && i < len(boundaries); k++ {
+		target := k * m.Len() / n
+		for i+1 < len(boundaries) && boundaries[i+1] <= target {
+			i++
+		}
+
+		if boundaries[i] <= target && i+1 < len(boundaries) && boundaries[i+1]-target < target-boundaries[i] {
+			i++
+		}
+
+		if boundaries[i] == prev {
+			continue
+		}
+
+		keys = append(keys, boundaryKeys[i])
+		prev = boundaries[i]
+	}
+
+	return keys, nil
+}
+
 func (m Map) Edit() *MapEditor {
 	return NewMapEditor(m)
 }
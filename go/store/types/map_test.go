@@ -584,6 +584,66 @@ func TestMapInfiniteChunkBug(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestMapSplitKeys(t *testing.T) {
+	smallTestChunks()
+	defer normalProductionChunks()
+
+	ctx := context.Background()
+	vrw := newTestValueStore()
+
+	m, err := NewMap(ctx, vrw, Float(1), String("one"))
+	assert.NoError(t, err)
+	keys, err := m.SplitKeys(ctx, 4)
+	assert.NoError(t, err)
+	assert.Empty(t, keys)
+
+	me := m.Edit()
+	for i := 0; i < 10000; i++ {
+		me.Set(Float(i), Float(i))
+	}
+	m, err = me.Map(ctx)
+	assert.NoError(t, err)
+
+	keys, err = m.SplitKeys(ctx, 1)
+	assert.NoError(t, err)
+	assert.Empty(t, keys)
+
+	keys, err = m.SplitKeys(ctx, 4)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, keys)
+	assert.True(t, len(keys) <= 3)
+
+	// every split key is a key of the map, and ends a range holding at least one entry
+	rangeLens := make([]uint64, len(keys)+1)
+	ri := 0
+	err = m.IterAll(ctx, func(k, v Value) error {
+		for ri < len(keys) {
+			isLess, err := keys[ri].Less(vrw.Format(), k)
+			if err != nil {
+				return err
+			} else if !isLess {
+				break
+			}
+			ri++
+		}
+		rangeLens[ri]++
+		return nil
+	})
+	assert.NoError(t, err)
+
+	count := uint64(0)
+	for i, rangeLen := range rangeLens {
+		assert.NotZero(t, rangeLen)
+		count += rangeLen
+		if i < len(keys) {
+			has, err := m.Has(ctx, keys[i])
+			assert.NoError(t, err)
+			assert.True(t, has)
+		}
+	}
+	assert.Equal(t, m.Len(), count)
+}
+
 func TestNewMap(t *testing.T) {
 	assert := assert.New(t)
 	vrw := newTestValueStore()
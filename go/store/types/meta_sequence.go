@@ -493,6 +493,13 @@ func (ms metaSequence) getCompositeChildSequence(ctx context.Context, start uint
 		}
 
 		return newSetLeafSequence(ms.vrw, valueItems...)
+	case BlobKind:
+		var data []byte
+		for _, seq := range output {
+			data = append(data, seq.(blobLeafSequence).data()...)
+		}
+
+		return newBlobLeafSequence(ms.vrw, data)
 	}
 
 	panic("unreachable")
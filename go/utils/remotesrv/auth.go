@@ -0,0 +1,361 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ed25519"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gopkg.in/square/go-jose.v2/jwt"
+	"gopkg.in/yaml.v2"
+
+	remotesapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/remotesapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/creds"
+)
+
+const (
+	// anyUser in the read or write list of a repo grants access to every client, including clients without credentials
+	anyUser = "*"
+
+	credsSubjectPrefix = "doltClientCredentials/"
+
+	signedURLTTL        = 15 * time.Minute
+	expiresQueryParam   = "expires"
+	signatureQueryParam = "signature"
+)
+
+// UserYAMLConfig is a user of the server, identified by the public keys of the credentials created with
+// `dolt creds new`.
+type UserYAMLConfig struct {
+	Name       string   `yaml:"name"`
+	Email      string   `yaml:"email"`
+	PublicKeys []string `yaml:"public_keys"`
+}
+
+// RepoYAMLConfig grants access to the repos matching a name, which is either org/repo, org/* or *.
+type RepoYAMLConfig struct {
+	Name  string   `yaml:"name"`
+	Read  []string `yaml:"read"`
+	Write []string `yaml:"write"`
+}
+
+// AuthYAMLConfig is the file given to the -auth-config parameter.
+type AuthYAMLConfig struct {
+	Users []UserYAMLConfig `yaml:"users"`
+	Repos []RepoYAMLConfig `yaml:"repos"`
+}
+
+type user struct {
+	name  string
+	email string
+	key   ed25519.PublicKey
+}
+
+type repoACL struct {
+	read  map[string]bool
+	write map[string]bool
+}
+
+// RepoAuth authenticates the clients of the server and checks their access to repos. A nil *RepoAuth allows every
+// client to read and write every repo.
+type RepoAuth struct {
+	usersByKID map[string]user
+	acls       map[string]repoACL
+	urlKey     []byte
+}
+
+// LoadRepoAuth reads the auth config file at the path given.
+func LoadRepoAuth(path string) (*RepoAuth, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg AuthYAMLConfig
+	err = yaml.UnmarshalStrict(data, &cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	return NewRepoAuth(cfg)
+}
+
+// NewRepoAuth validates an auth config and returns the RepoAuth which enforces it.
+func NewRepoAuth(cfg AuthYAMLConfig) (*RepoAuth, error) {
+	ra := &RepoAuth{
+		usersByKID: make(map[string]user),
+		acls:       make(map[string]repoACL),
+		urlKey:     make([]byte, 32),
+	}
+
+	_, err := rand.Read(ra.urlKey)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool)
+	for _, u := range cfg.Users {
+		if u.Name == "" || u.Name == anyUser {
+			return nil, fmt.Errorf("invalid user name '%s'", u.Name)
+		} else if names[u.Name] {
+			return nil, fmt.Errorf("user '%s' is declared more than once", u.Name)
+		}
+
+		names[u.Name] = true
+
+		for _, pubKeyStr := range u.PublicKeys {
+			pub, err := creds.B32CredsEncoding.DecodeString(pubKeyStr)
+			if err != nil || len(pub) != ed25519.PublicKeySize {
+				return nil, fmt.Errorf("invalid public key '%s' for user '%s'", pubKeyStr, u.Name)
+			}
+
+			ra.usersByKID[creds.PubKeyToKIDStr(pub)] = user{u.Name, u.Email, ed25519.PublicKey(pub)}
+		}
+	}
+
+	for _, r := range cfg.Repos {
+		if !isValidRepoPattern(r.Name) {
+			return nil, fmt.Errorf("invalid repo name '%s'. expected org/repo, org/* or *", r.Name)
+		} else if _, ok := ra.acls[r.Name]; ok {
+			return nil, fmt.Errorf("repo '%s' is declared more than once", r.Name)
+		}
+
+		acl := repoACL{make(map[string]bool), make(map[string]bool)}
+		for _, lists := range []struct {
+			names []string
+			set   map[string]bool
+		}{{r.Read, acl.read}, {r.Write, acl.write}} {
+			for _, name := range lists.names {
+				if name != anyUser && !names[name] {
+					return nil, fmt.Errorf("unknown user '%s' in the access lists of repo '%s'", name, r.Name)
+				}
+
+				lists.set[name] = true
+			}
+		}
+
+		ra.acls[r.Name] = acl
+	}
+
+	return ra, nil
+}
+
+func isValidRepoPattern(name string) bool {
+	if name == anyUser {
+		return true
+	}
+
+	tokens := strings.Split(name, "/")
+	return len(tokens) == 2 && isValidPathName(tokens[0]) && (tokens[1] == anyUser || isValidPathName(tokens[1]))
+}
+
+// isValidPathName returns whether an org or repo name can be used as a directory name under the root of the server.
+func isValidPathName(name string) bool {
+	return name != "" && name != "." && name != ".." && !strings.ContainsAny(name, `/\*`)
+}
+
+// Authenticate returns the user whose credentials signed the bearer token of a request, or nil if the request has no
+// credentials. Requests with invalid credentials fail with codes.Unauthenticated.
+func (ra *RepoAuth) Authenticate(ctx context.Context) (*user, error) {
+	if ra == nil {
+		return nil, nil
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+
+	authHeaders := md.Get("authorization")
+	if len(authHeaders) == 0 {
+		return nil, nil
+	}
+
+	const bearerPrefix = "Bearer "
+	if !strings.HasPrefix(authHeaders[0], bearerPrefix) {
+		return nil, status.Error(codes.Unauthenticated, "authorization is not a bearer token")
+	}
+
+	tok, err := jwt.ParseSigned(authHeaders[0][len(bearerPrefix):])
+	if err != nil || len(tok.Headers) != 1 {
+		return nil, status.Error(codes.Unauthenticated, "invalid bearer token")
+	}
+
+	kid := tok.Headers[0].KeyID
+	u, ok := ra.usersByKID[kid]
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unknown credentials "+kid)
+	}
+
+	var claims jwt.Claims
+	err = tok.Claims(u.key, &claims)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid signature for credentials "+kid)
+	}
+
+	err = claims.Validate(jwt.Expected{
+		Audience: jwt.Audience{creds.JWTAudience},
+		Subject:  credsSubjectPrefix + kid,
+		Time:     time.Now(),
+	})
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, fmt.Sprintf("invalid claims for credentials %s: %v", kid, err))
+	}
+
+	return &u, nil
+}
+
+// Authorize returns an error if the client of a request can't read, or write when write is true, the repo given.
+func (ra *RepoAuth) Authorize(ctx context.Context, repoId *remotesapi.RepoId, write bool) error {
+	if repoId == nil || !isValidPathName(repoId.Org) || !isValidPathName(repoId.RepoName) {
+		return status.Error(codes.InvalidArgument, "invalid repo id")
+	}
+
+	if ra == nil {
+		return nil
+	}
+
+	u, err := ra.Authenticate(ctx)
+	if err != nil {
+		return err
+	}
+
+	acl := ra.aclFor(repoId.Org, repoId.RepoName)
+
+	access := "read"
+	allowed := acl.write[anyUser] || (u != nil && acl.write[u.name])
+	if !write {
+		allowed = allowed || acl.read[anyUser] || (u != nil && acl.read[u.name])
+	} else {
+		access = "write"
+	}
+
+	if allowed {
+		return nil
+	} else if u == nil {
+		return status.Error(codes.Unauthenticated, fmt.Sprintf("credentials are required to %s %s/%s", access, repoId.Org, repoId.RepoName))
+	}
+
+	return status.Error(codes.PermissionDenied, fmt.Sprintf("user '%s' can not %s %s/%s", u.name, access, repoId.Org, repoId.RepoName))
+}
+
+// aclFor returns the access lists of the most specific repo declaration matching a repo.
+func (ra *RepoAuth) aclFor(org, repo string) repoACL {
+	for _, name := range []string{org + "/" + repo, org + "/" + anyUser, anyUser} {
+		if acl, ok := ra.acls[name]; ok {
+			return acl
+		}
+	}
+
+	return repoACL{}
+}
+
+// SignURL adds an expiration time and a signature to the query of a url handed out by the grpc server, so that the
+// http server only serves the files of the repos the client was authorized to access, and only for the http method the
+// url was handed out for.
+func (ra *RepoAuth) SignURL(method, urlStr string) string {
+	if ra == nil {
+		return urlStr
+	}
+
+	expires := strconv.FormatInt(time.Now().Add(signedURLTTL).Unix(), 10)
+	u, err := url.Parse(urlStr)
+	if err != nil {
+		return urlStr
+	}
+
+	q := u.Query()
+	q.Set(expiresQueryParam, expires)
+	q.Set(signatureQueryParam, ra.urlSignature(method, u.Path, expires))
+	u.RawQuery = q.Encode()
+
+	return u.String()
+}
+
+// VerifyURL returns whether the url of a request to the http server was signed with SignURL for the method of the
+// request and has not expired.
+func (ra *RepoAuth) VerifyURL(method string, u *url.URL) bool {
+	if ra == nil {
+		return true
+	}
+
+	q := u.Query()
+	expires := q.Get(expiresQueryParam)
+	expiresUnix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > expiresUnix {
+		return false
+	}
+
+	sig := ra.urlSignature(method, u.Path, expires)
+	return hmac.Equal([]byte(sig), []byte(q.Get(signatureQueryParam)))
+}
+
+func (ra *RepoAuth) urlSignature(method, path, expires string) string {
+	// the http server writes table files for both POST and PUT requests, so an upload url is good for either
+	if method == http.MethodPost {
+		method = http.MethodPut
+	}
+
+	mac := hmac.New(sha256.New, ra.urlKey)
+	mac.Write([]byte(method))
+	mac.Write([]byte{0})
+	mac.Write([]byte(path))
+	mac.Write([]byte{0})
+	mac.Write([]byte(expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// CredentialsServer implements the WhoAmI endpoint used by `dolt creds check` and `dolt login`.
+type CredentialsServer struct {
+	auth *RepoAuth
+	remotesapi.UnimplementedCredentialsServiceServer
+}
+
+func NewCredentialsServer(auth *RepoAuth) *CredentialsServer {
+	return &CredentialsServer{auth: auth}
+}
+
+func (cs *CredentialsServer) WhoAmI(ctx context.Context, req *remotesapi.WhoAmIRequest) (*remotesapi.WhoAmIResponse, error) {
+	logger := getReqLogger("GRPC", "WhoAmI")
+	defer func() { logger("finished") }()
+
+	if cs.auth == nil {
+		return nil, status.Error(codes.Unimplemented, "authentication is not enabled on this server")
+	}
+
+	u, err := cs.auth.Authenticate(ctx)
+	if err != nil {
+		return nil, err
+	} else if u == nil {
+		return nil, status.Error(codes.Unauthenticated, "no credentials")
+	}
+
+	logger("authenticated " + u.name)
+	return &remotesapi.WhoAmIResponse{Username: u.name, DisplayName: u.name, EmailAddress: u.email}, nil
+}
@@ -0,0 +1,177 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ed25519"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+
+	remotesapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/remotesapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/creds"
+)
+
+func ctxWithCreds(t *testing.T, dc creds.DoltCreds) context.Context {
+	md, err := dc.GetRequestMetadata(context.Background())
+	require.NoError(t, err)
+	return metadata.NewIncomingContext(context.Background(), metadata.New(md))
+}
+
+// ctxWithToken returns a context with a bearer token signed by the credentials given for the audience given.
+func ctxWithToken(t *testing.T, dc creds.DoltCreds, aud string) context.Context {
+	kid := dc.KeyIDBase32Str()
+	key := jose.SigningKey{Algorithm: jose.EdDSA, Key: ed25519.PrivateKey(dc.PrivKey)}
+	opts := &jose.SignerOptions{ExtraHeaders: map[jose.HeaderKey]interface{}{creds.JWTKIDHeader: kid}}
+	signer, err := jose.NewSigner(key, opts)
+	require.NoError(t, err)
+
+	tok, err := jwt.Signed(signer).Claims(jwt.Claims{
+		Audience: []string{aud},
+		Subject:  credsSubjectPrefix + kid,
+		Expiry:   jwt.NewNumericDate(time.Now().Add(30 * time.Second)),
+	}).CompactSerialize()
+	require.NoError(t, err)
+
+	md := metadata.New(map[string]string{"authorization": "Bearer " + tok})
+	return metadata.NewIncomingContext(context.Background(), md)
+}
+
+func TestRepoAuth(t *testing.T) {
+	alice, err := creds.GenerateCredentials()
+	require.NoError(t, err)
+	bob, err := creds.GenerateCredentials()
+	require.NoError(t, err)
+	eve, err := creds.GenerateCredentials()
+	require.NoError(t, err)
+
+	ra, err := NewRepoAuth(AuthYAMLConfig{
+		Users: []UserYAMLConfig{
+			{Name: "alice", PublicKeys: []string{alice.PubKeyBase32Str()}},
+			{Name: "bob", PublicKeys: []string{bob.PubKeyBase32Str()}},
+		},
+		Repos: []RepoYAMLConfig{
+			{Name: "team/*", Read: []string{"bob"}, Write: []string{"alice"}},
+			{Name: "team/public", Read: []string{"*"}, Write: []string{"alice"}},
+		},
+	})
+	require.NoError(t, err)
+
+	aliceCtx := ctxWithCreds(t, alice)
+	bobCtx := ctxWithCreds(t, bob)
+	eveCtx := ctxWithCreds(t, eve)
+	anonCtx := context.Background()
+
+	u, err := ra.Authenticate(aliceCtx)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", u.name)
+
+	u, err = ra.Authenticate(anonCtx)
+	require.NoError(t, err)
+	assert.Nil(t, u)
+
+	_, err = ra.Authenticate(eveCtx)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	u, err = ra.Authenticate(ctxWithToken(t, alice, creds.JWTAudience))
+	require.NoError(t, err)
+	assert.Equal(t, "alice", u.name)
+
+	_, err = ra.Authenticate(ctxWithToken(t, alice, "some-other-api"))
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	private := &remotesapi.RepoId{Org: "team", RepoName: "private"}
+	public := &remotesapi.RepoId{Org: "team", RepoName: "public"}
+	other := &remotesapi.RepoId{Org: "other", RepoName: "repo"}
+
+	tests := []struct {
+		ctx      context.Context
+		repoId   *remotesapi.RepoId
+		write    bool
+		expected codes.Code
+	}{
+		{aliceCtx, private, true, codes.OK},
+		{bobCtx, private, false, codes.OK},
+		{bobCtx, private, true, codes.PermissionDenied},
+		{anonCtx, private, false, codes.Unauthenticated},
+		{anonCtx, public, false, codes.OK},
+		{anonCtx, public, true, codes.Unauthenticated},
+		{eveCtx, public, false, codes.Unauthenticated},
+		{aliceCtx, other, false, codes.PermissionDenied},
+		{aliceCtx, &remotesapi.RepoId{Org: "..", RepoName: "private"}, false, codes.InvalidArgument},
+	}
+
+	for _, test := range tests {
+		err := ra.Authorize(test.ctx, test.repoId, test.write)
+		assert.Equal(t, test.expected, status.Code(err), "%s/%s write: %t", test.repoId.Org, test.repoId.RepoName, test.write)
+	}
+
+	var noAuth *RepoAuth
+	assert.NoError(t, noAuth.Authorize(anonCtx, private, true))
+}
+
+func TestNewRepoAuthErrors(t *testing.T) {
+	dc, err := creds.GenerateCredentials()
+	require.NoError(t, err)
+	users := []UserYAMLConfig{{Name: "alice", PublicKeys: []string{dc.PubKeyBase32Str()}}}
+
+	tests := []AuthYAMLConfig{
+		{Users: []UserYAMLConfig{{Name: "alice", PublicKeys: []string{"not a key"}}}},
+		{Users: append(users, users...)},
+		{Users: users, Repos: []RepoYAMLConfig{{Name: "team", Read: []string{"alice"}}}},
+		{Users: users, Repos: []RepoYAMLConfig{{Name: "team/*", Read: []string{"bob"}}}},
+		{Users: users, Repos: []RepoYAMLConfig{{Name: "*"}, {Name: "*"}}},
+	}
+
+	for _, test := range tests {
+		_, err := NewRepoAuth(test)
+		assert.Error(t, err)
+	}
+}
+
+func TestSignURL(t *testing.T) {
+	ra, err := NewRepoAuth(AuthYAMLConfig{})
+	require.NoError(t, err)
+
+	signed, err := url.Parse(ra.SignURL(http.MethodGet, "http://localhost:1234/team/repo/abc"))
+	require.NoError(t, err)
+	assert.True(t, ra.VerifyURL(http.MethodGet, signed))
+	assert.False(t, ra.VerifyURL(http.MethodPut, signed))
+	assert.False(t, ra.VerifyURL(http.MethodPost, signed))
+
+	unsigned, err := url.Parse("http://localhost:1234/team/repo/abc")
+	require.NoError(t, err)
+	assert.False(t, ra.VerifyURL(http.MethodGet, unsigned))
+
+	moved := *signed
+	moved.Path = "/team/other/abc"
+	assert.False(t, ra.VerifyURL(http.MethodGet, &moved))
+
+	upload, err := url.Parse(ra.SignURL(http.MethodPut, "http://localhost:1234/team/repo/abc"))
+	require.NoError(t, err)
+	assert.True(t, ra.VerifyURL(http.MethodPut, upload))
+	assert.True(t, ra.VerifyURL(http.MethodPost, upload))
+	assert.False(t, ra.VerifyURL(http.MethodGet, upload))
+}
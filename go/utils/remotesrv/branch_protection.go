@@ -0,0 +1,190 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// checkBranchProtection returns an actions.BranchProtectionViolation if moving the root of a chunk store from
+// |lastRoot| to |newRoot| updates, creates or deletes a branch in a way which breaks the rules protecting the branch.
+// The chunks of the new root must already be in the chunk store. The rules and the branches are read from |lastRoot|
+// rather than from the current root of the chunk store, so the check holds for the commit of the new root, which only
+// succeeds if the root of the chunk store is still |lastRoot|, and a push can't lift the rules it is checked against.
+//
+// The rules of an existing branch are the ones declared by its head before the push. A branch created by the push is
+// held to the rules declared for it by the head of any branch before the push, and only its new head is checked.
+func checkBranchProtection(ctx context.Context, cs chunks.ChunkStore, lastRoot, newRoot hash.Hash) error {
+	if lastRoot.IsEmpty() || newRoot.IsEmpty() {
+		return nil
+	}
+
+	ddb := doltdb.DoltDBFromCS(cs)
+	vrw := ddb.ValueReadWriter()
+
+	lastBranches, lastHeads, err := readBranchHeads(ctx, vrw, lastRoot)
+	if err != nil {
+		return err
+	}
+
+	newBranches, newHeads, err := readBranchHeads(ctx, vrw, newRoot)
+	if err != nil {
+		return err
+	}
+
+	lastHeadRoots := make(map[string]*doltdb.RootValue, len(lastBranches))
+	for _, branch := range lastBranches {
+		lastHeadRoots[branch], err = lastHeads[branch].GetRootValue()
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, branch := range lastBranches {
+		bp, err := actions.LoadBranchProtection(ctx, lastHeadRoots[branch], branch)
+		if err != nil {
+			return err
+		}
+
+		newHead, ok := newHeads[branch]
+		if !ok {
+			// a deleted branch could be created again without the rules of its head
+			if bp != nil && bp.NoForcePush {
+				return actions.BranchProtectionViolation{Branch: branch, Reason: "deleting the branch is not allowed"}
+			}
+
+			continue
+		}
+
+		err = bp.CheckUpdate(ctx, ddb, lastHeads[branch], newHead)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, branch := range newBranches {
+		if _, ok := lastHeads[branch]; ok {
+			continue
+		}
+
+		err = checkNewBranch(ctx, lastBranches, lastHeadRoots, branch, newHeads[branch])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkNewBranch returns an actions.BranchProtectionViolation if the head of a branch created by a push breaks the
+// rules declared for the branch by the head of any branch before the push.
+func checkNewBranch(ctx context.Context, lastBranches []string, lastHeadRoots map[string]*doltdb.RootValue, branch string, newHead *doltdb.Commit) error {
+	meta, err := newHead.GetCommitMeta()
+	if err != nil {
+		return err
+	}
+
+	numParents, err := newHead.NumParents()
+	if err != nil {
+		return err
+	}
+
+	root, err := newHead.GetRootValue()
+	if err != nil {
+		return err
+	}
+
+	for _, declaringBranch := range lastBranches {
+		bp, err := actions.LoadBranchProtection(ctx, lastHeadRoots[declaringBranch], branch)
+		if err != nil {
+			return err
+		}
+
+		err = bp.CheckCommit(ctx, root, meta.Description, numParents > 1)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readBranchHeads returns the names of the branches of a root of a chunk store, in order, along with their heads.
+func readBranchHeads(ctx context.Context, vrw types.ValueReadWriter, root hash.Hash) ([]string, map[string]*doltdb.Commit, error) {
+	datasets, err := readDatasets(ctx, vrw, root)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var branches []string
+	heads := make(map[string]*doltdb.Commit)
+	err = datasets.IterAll(ctx, func(k, v types.Value) error {
+		if !ref.IsRef(string(k.(types.String))) {
+			return nil
+		}
+
+		dref, err := ref.Parse(string(k.(types.String)))
+		if err != nil {
+			return err
+		} else if dref.GetType() != ref.BranchRefType {
+			return nil
+		}
+
+		head, err := readCommit(ctx, vrw, v)
+		if err != nil {
+			return err
+		}
+
+		branches = append(branches, dref.GetPath())
+		heads[dref.GetPath()] = head
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return branches, heads, nil
+}
+
+func readDatasets(ctx context.Context, vrw types.ValueReadWriter, root hash.Hash) (types.Map, error) {
+	val, err := vrw.ReadValue(ctx, root)
+	if err != nil {
+		return types.EmptyMap, err
+	}
+
+	datasets, ok := val.(types.Map)
+	if !ok {
+		return types.EmptyMap, errors.New("root is not a map of datasets")
+	}
+
+	return datasets, nil
+}
+
+func readCommit(ctx context.Context, vrw types.ValueReadWriter, val types.Value) (*doltdb.Commit, error) {
+	st, err := val.(types.Ref).TargetValue(ctx, vrw)
+	if err != nil {
+		return nil, err
+	}
+
+	return doltdb.NewCommit(vrw, st.(types.Struct)), nil
+}
@@ -0,0 +1,163 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/encoding"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// protectionTable returns a dolt_branch_protection table protecting the branches given by message patterns.
+func protectionTable(ctx context.Context, t *testing.T, ddb *doltdb.DoltDB, noForcePush bool, patterns map[string]string) *doltdb.Table {
+	vrw := ddb.ValueReadWriter()
+	sch := actions.BranchProtectionSchema
+
+	rowData, err := types.NewMap(ctx, vrw)
+	require.NoError(t, err)
+
+	ed := rowData.Edit()
+	for branch, pattern := range patterns {
+		r, err := row.New(ddb.Format(), sch, row.TaggedValues{
+			schema.BranchProtectionBranchTag:         types.String(branch),
+			schema.BranchProtectionNoForcePushTag:    types.Bool(noForcePush),
+			schema.BranchProtectionMessagePatternTag: types.String(pattern),
+		})
+		require.NoError(t, err)
+		ed.Set(r.NomsMapKey(sch), r.NomsMapValue(sch))
+	}
+
+	rowData, err = ed.Map(ctx)
+	require.NoError(t, err)
+
+	schVal, err := encoding.MarshalSchemaAsNomsValue(ctx, vrw, sch)
+	require.NoError(t, err)
+	empty, err := types.NewMap(ctx, vrw)
+	require.NoError(t, err)
+	tbl, err := doltdb.NewTable(ctx, vrw, schVal, rowData, empty)
+	require.NoError(t, err)
+
+	return tbl
+}
+
+// danglingCommit writes a commit of the root given on top of |parent|, without moving any branch.
+func danglingCommit(ctx context.Context, t *testing.T, ddb *doltdb.DoltDB, parent *doltdb.Commit, root *doltdb.RootValue, msg string) *doltdb.Commit {
+	h, err := ddb.WriteRootValue(ctx, root)
+	require.NoError(t, err)
+	meta, err := doltdb.NewCommitMeta("billy bob", "bigbillieb@fake.horse", msg)
+	require.NoError(t, err)
+	cm, err := ddb.CommitDanglingWithParentCommits(ctx, h, []*doltdb.Commit{parent}, meta)
+	require.NoError(t, err)
+	return cm
+}
+
+func TestCheckBranchProtection(t *testing.T) {
+	ctx := context.Background()
+	storage := &chunks.MemoryStorage{}
+	cs := storage.NewView()
+	ddb := doltdb.DoltDBFromCS(cs)
+	master := ref.NewBranchRef("master")
+	release := ref.NewBranchRef("release")
+
+	err := ddb.WriteEmptyRepo(ctx, "billy bob", "bigbillieb@fake.horse")
+	require.NoError(t, err)
+	initial, err := ddb.ResolveRef(ctx, master)
+	require.NoError(t, err)
+	initialRoot, err := initial.GetRootValue()
+	require.NoError(t, err)
+
+	rulesRoot, err := initialRoot.PutTable(ctx, doltdb.BranchProtectionTableName, protectionTable(ctx, t, ddb, true, map[string]string{
+		"master":  "^ISSUE-[0-9]+",
+		"release": "^RELEASE-[0-9]+",
+	}))
+	require.NoError(t, err)
+	protected := danglingCommit(ctx, t, ddb, initial, rulesRoot, "protect master")
+	err = ddb.SetHeadToCommit(ctx, master, protected)
+	require.NoError(t, err)
+
+	root := func() hash.Hash {
+		h, err := cs.Root(ctx)
+		require.NoError(t, err)
+		return h
+	}
+	lastRoot := root()
+
+	// moves a branch to the commit given, returning the root of the chunk store and restoring the branch
+	push := func(branch ref.BranchRef, cm *doltdb.Commit) hash.Hash {
+		err := ddb.SetHeadToCommit(ctx, branch, cm)
+		require.NoError(t, err)
+		newRoot := root()
+		if branch == master {
+			err = ddb.SetHeadToCommit(ctx, master, protected)
+		} else {
+			err = ddb.DeleteBranch(ctx, branch)
+		}
+		require.NoError(t, err)
+		return newRoot
+	}
+
+	t.Run("update", func(t *testing.T) {
+		ok := push(master, danglingCommit(ctx, t, ddb, protected, rulesRoot, "ISSUE-1 empty"))
+		assert.NoError(t, checkBranchProtection(ctx, cs, lastRoot, ok))
+
+		bad := push(master, danglingCommit(ctx, t, ddb, protected, rulesRoot, "empty"))
+		assert.True(t, actions.IsBranchProtectionViolation(checkBranchProtection(ctx, cs, lastRoot, bad)))
+	})
+
+	t.Run("removing the rules", func(t *testing.T) {
+		unprotectedRoot, err := rulesRoot.RemoveTables(ctx, doltdb.BranchProtectionTableName)
+		require.NoError(t, err)
+
+		// the rules of the head before the push apply to the commit dropping them
+		bad := push(master, danglingCommit(ctx, t, ddb, protected, unprotectedRoot, "drop the rules"))
+		assert.True(t, actions.IsBranchProtectionViolation(checkBranchProtection(ctx, cs, lastRoot, bad)))
+
+		ok := push(master, danglingCommit(ctx, t, ddb, protected, unprotectedRoot, "ISSUE-2 drop the rules"))
+		assert.NoError(t, checkBranchProtection(ctx, cs, lastRoot, ok))
+	})
+
+	t.Run("deleting a branch", func(t *testing.T) {
+		err := ddb.DeleteBranch(ctx, master)
+		require.NoError(t, err)
+		deleted := root()
+		err = ddb.NewBranchAtCommit(ctx, master, protected)
+		require.NoError(t, err)
+
+		assert.True(t, actions.IsBranchProtectionViolation(checkBranchProtection(ctx, cs, lastRoot, deleted)))
+	})
+
+	t.Run("creating a branch", func(t *testing.T) {
+		bad := push(release, danglingCommit(ctx, t, ddb, protected, rulesRoot, "branch off"))
+		assert.True(t, actions.IsBranchProtectionViolation(checkBranchProtection(ctx, cs, lastRoot, bad)))
+
+		ok := push(release, danglingCommit(ctx, t, ddb, protected, rulesRoot, "RELEASE-1 branch off"))
+		assert.NoError(t, checkBranchProtection(ctx, cs, lastRoot, ok))
+
+		unprotected := push(ref.NewBranchRef("feature"), danglingCommit(ctx, t, ddb, protected, rulesRoot, "branch off"))
+		assert.NoError(t, checkBranchProtection(ctx, cs, lastRoot, unprotected))
+	})
+}
@@ -16,6 +16,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"path/filepath"
 	"sync"
 
@@ -27,6 +28,8 @@ const (
 	defaultMemTableSize = 128 * 1024 * 1024
 )
 
+var ErrRepoNotFound = errors.New("repo not found")
+
 type DBCache struct {
 	mu  *sync.Mutex
 	dbs map[string]*nbs.NomsBlockStore
@@ -42,7 +45,9 @@ func NewLocalCSCache(filesys filesys.Filesys) *DBCache {
 	}
 }
 
-func (cache *DBCache) Get(org, repo, nbfVerStr string) (*nbs.NomsBlockStore, error) {
+// Get returns the chunk store of a repo. Repos which don't exist yet are created if create is true, and otherwise
+// ErrRepoNotFound is returned.
+func (cache *DBCache) Get(org, repo, nbfVerStr string, create bool) (*nbs.NomsBlockStore, error) {
 	cache.mu.Lock()
 	defer cache.mu.Unlock()
 
@@ -54,6 +59,10 @@ func (cache *DBCache) Get(org, repo, nbfVerStr string) (*nbs.NomsBlockStore, err
 
 	var newCS *nbs.NomsBlockStore
 	if cache.fs != nil {
+		if exists, isDir := cache.fs.Exists(id); !create && !(exists && isDir) {
+			return nil, ErrRepoNotFound
+		}
+
 		err := cache.fs.MkDirs(id)
 
 		if err != nil {
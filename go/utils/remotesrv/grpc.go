@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"path/filepath"
 	"sync/atomic"
@@ -26,6 +27,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	remotesapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/remotesapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/remotestorage"
 	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/nbs"
@@ -36,14 +38,16 @@ type RemoteChunkStore struct {
 	HttpHost string
 	csCache  *DBCache
 	bucket   string
+	auth     *RepoAuth
 	remotesapi.UnimplementedChunkStoreServiceServer
 }
 
-func NewHttpFSBackedChunkStore(httpHost string, csCache *DBCache) *RemoteChunkStore {
+func NewHttpFSBackedChunkStore(httpHost string, csCache *DBCache, auth *RepoAuth) *RemoteChunkStore {
 	return &RemoteChunkStore{
 		HttpHost: httpHost,
 		csCache:  csCache,
 		bucket:   "",
+		auth:     auth,
 	}
 }
 
@@ -51,10 +55,10 @@ func (rs *RemoteChunkStore) HasChunks(ctx context.Context, req *remotesapi.HasCh
 	logger := getReqLogger("GRPC", "HasChunks")
 	defer func() { logger("finished") }()
 
-	cs := rs.getStore(req.RepoId, "HasChunks")
+	cs, err := rs.getStore(ctx, req.RepoId, "HasChunks", false)
 
-	if cs == nil {
-		return nil, status.Error(codes.Internal, "Could not get chunkstore")
+	if err != nil {
+		return nil, err
 	}
 
 	logger(fmt.Sprintf("found repo %s/%s", req.RepoId.Org, req.RepoId.RepoName))
@@ -88,10 +92,10 @@ func (rs *RemoteChunkStore) GetDownloadLocations(ctx context.Context, req *remot
 	logger := getReqLogger("GRPC", "GetDownloadLocations")
 	defer func() { logger("finished") }()
 
-	cs := rs.getStore(req.RepoId, "GetDownloadLoctions")
+	cs, err := rs.getStore(ctx, req.RepoId, "GetDownloadLoctions", false)
 
-	if cs == nil {
-		return nil, status.Error(codes.Internal, "Could not get chunkstore")
+	if err != nil {
+		return nil, err
 	}
 
 	logger(fmt.Sprintf("found repo %s/%s", req.RepoId.Org, req.RepoId.RepoName))
@@ -128,7 +132,7 @@ func (rs *RemoteChunkStore) GetDownloadLocations(ctx context.Context, req *remot
 }
 
 func (rs *RemoteChunkStore) getDownloadUrl(logger func(string), org, repoName, fileId string) (string, error) {
-	return fmt.Sprintf("http://%s/%s/%s/%s", rs.HttpHost, org, repoName, fileId), nil
+	return rs.auth.SignURL(http.MethodGet, fmt.Sprintf("http://%s/%s/%s/%s", rs.HttpHost, org, repoName, fileId)), nil
 }
 
 func parseTableFileDetails(req *remotesapi.GetUploadLocsRequest) []*remotesapi.TableFileDetails {
@@ -154,10 +158,10 @@ func (rs *RemoteChunkStore) GetUploadLocations(ctx context.Context, req *remotes
 	logger := getReqLogger("GRPC", "GetUploadLocations")
 	defer func() { logger("finished") }()
 
-	cs := rs.getStore(req.RepoId, "GetWriteChunkUrls")
+	_, err := rs.getStore(ctx, req.RepoId, "GetWriteChunkUrls", true)
 
-	if cs == nil {
-		return nil, status.Error(codes.Internal, "Could not get chunkstore")
+	if err != nil {
+		return nil, err
 	}
 
 	logger(fmt.Sprintf("found repo %s/%s", req.RepoId.Org, req.RepoId.RepoName))
@@ -187,22 +191,22 @@ func (rs *RemoteChunkStore) GetUploadLocations(ctx context.Context, req *remotes
 func (rs *RemoteChunkStore) getUploadUrl(logger func(string), org, repoName string, tfd *remotesapi.TableFileDetails) (string, error) {
 	fileID := hash.New(tfd.Id).String()
 	expectedFiles[fileID] = tfd
-	return fmt.Sprintf("http://%s/%s/%s/%s", rs.HttpHost, org, repoName, fileID), nil
+	return rs.auth.SignURL(http.MethodPut, fmt.Sprintf("http://%s/%s/%s/%s", rs.HttpHost, org, repoName, fileID)), nil
 }
 
 func (rs *RemoteChunkStore) Rebase(ctx context.Context, req *remotesapi.RebaseRequest) (*remotesapi.RebaseResponse, error) {
 	logger := getReqLogger("GRPC", "Rebase")
 	defer func() { logger("finished") }()
 
-	cs := rs.getStore(req.RepoId, "Rebase")
+	cs, err := rs.getStore(ctx, req.RepoId, "Rebase", false)
 
-	if cs == nil {
-		return nil, status.Error(codes.Internal, "Could not get chunkstore")
+	if err != nil {
+		return nil, err
 	}
 
 	logger(fmt.Sprintf("found %s/%s", req.RepoId.Org, req.RepoId.RepoName))
 
-	err := cs.Rebase(ctx)
+	err = cs.Rebase(ctx)
 
 	if err != nil {
 		logger(fmt.Sprintf("error occurred during processing of Rebace rpc of %s/%s details: %v", req.RepoId.Org, req.RepoId.RepoName, err))
@@ -216,10 +220,10 @@ func (rs *RemoteChunkStore) Root(ctx context.Context, req *remotesapi.RootReques
 	logger := getReqLogger("GRPC", "Root")
 	defer func() { logger("finished") }()
 
-	cs := rs.getStore(req.RepoId, "Root")
+	cs, err := rs.getStore(ctx, req.RepoId, "Root", false)
 
-	if cs == nil {
-		return nil, status.Error(codes.Internal, "Could not get chunkstore")
+	if err != nil {
+		return nil, err
 	}
 
 	h, err := cs.Root(ctx)
@@ -236,10 +240,10 @@ func (rs *RemoteChunkStore) Commit(ctx context.Context, req *remotesapi.CommitRe
 	logger := getReqLogger("GRPC", "Commit")
 	defer func() { logger("finished") }()
 
-	cs := rs.getStore(req.RepoId, "Commit")
+	cs, err := rs.getStore(ctx, req.RepoId, "Commit", true)
 
-	if cs == nil {
-		return nil, status.Error(codes.Internal, "Could not get chunkstore")
+	if err != nil {
+		return nil, err
 	}
 
 	logger(fmt.Sprintf("found %s/%s", req.RepoId.Org, req.RepoId.RepoName))
@@ -250,7 +254,7 @@ func (rs *RemoteChunkStore) Commit(ctx context.Context, req *remotesapi.CommitRe
 		updates[hash.New(cti.Hash)] = cti.ChunkCount
 	}
 
-	_, err := cs.UpdateManifest(ctx, updates)
+	_, err = cs.UpdateManifest(ctx, updates)
 
 	if err != nil {
 		logger(fmt.Sprintf("error occurred updating the manifest: %s", err.Error()))
@@ -260,6 +264,16 @@ func (rs *RemoteChunkStore) Commit(ctx context.Context, req *remotesapi.CommitRe
 	currHash := hash.New(req.Current)
 	lastHash := hash.New(req.Last)
 
+	err = checkBranchProtection(ctx, cs, lastHash, currHash)
+
+	if actions.IsBranchProtectionViolation(err) {
+		logger(fmt.Sprintf("rejected commit of %s/%s: %v", req.RepoId.Org, req.RepoId.RepoName, err))
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	} else if err != nil {
+		logger(fmt.Sprintf("error occurred checking branch protection of %s/%s: %v", req.RepoId.Org, req.RepoId.RepoName, err))
+		return nil, status.Error(codes.Internal, "Failed to check branch protection")
+	}
+
 	var ok bool
 	ok, err = cs.Commit(ctx, currHash, lastHash)
 
@@ -276,9 +290,14 @@ func (rs *RemoteChunkStore) GetRepoMetadata(ctx context.Context, req *remotesapi
 	logger := getReqLogger("GRPC", "GetRepoMetadata")
 	defer func() { logger("finished") }()
 
-	cs := rs.getOrCreateStore(req.RepoId, "GetRepoMetadata", req.ClientRepoFormat.NbfVersion)
-	if cs == nil {
-		return nil, status.Error(codes.Internal, "Could not get chunkstore")
+	cs, err := rs.getOrCreateStore(ctx, req.RepoId, "GetRepoMetadata", req.ClientRepoFormat.NbfVersion, false)
+	if status.Code(err) == codes.NotFound && rs.auth.Authorize(ctx, req.RepoId, true) == nil {
+		// repos are created by the first push to them
+		cs, err = rs.getOrCreateStore(ctx, req.RepoId, "GetRepoMetadata", req.ClientRepoFormat.NbfVersion, true)
+	}
+
+	if err != nil {
+		return nil, err
 	}
 
 	_, tfs, err := cs.Sources(ctx)
@@ -310,10 +329,10 @@ func (rs *RemoteChunkStore) ListTableFiles(ctx context.Context, req *remotesapi.
 	logger := getReqLogger("GRPC", "ListTableFiles")
 	defer func() { logger("finished") }()
 
-	cs := rs.getStore(req.RepoId, "ListTableFiles")
+	cs, err := rs.getStore(ctx, req.RepoId, "ListTableFiles", false)
 
-	if cs == nil {
-		return nil, status.Error(codes.Internal, "Could not get chunkstore")
+	if err != nil {
+		return nil, err
 	}
 
 	logger(fmt.Sprintf("found repo %s/%s", req.RepoId.Org, req.RepoId.RepoName))
@@ -352,10 +371,10 @@ func (rs *RemoteChunkStore) AddTableFiles(ctx context.Context, req *remotesapi.A
 	logger := getReqLogger("GRPC", "Commit")
 	defer func() { logger("finished") }()
 
-	cs := rs.getStore(req.RepoId, "Commit")
+	cs, err := rs.getStore(ctx, req.RepoId, "Commit", true)
 
-	if cs == nil {
-		return nil, status.Error(codes.Internal, "Could not get chunkstore")
+	if err != nil {
+		return nil, err
 	}
 
 	logger(fmt.Sprintf("found %s/%s", req.RepoId.Org, req.RepoId.RepoName))
@@ -366,7 +385,7 @@ func (rs *RemoteChunkStore) AddTableFiles(ctx context.Context, req *remotesapi.A
 		updates[hash.New(cti.Hash)] = cti.ChunkCount
 	}
 
-	_, err := cs.UpdateManifest(ctx, updates)
+	_, err = cs.UpdateManifest(ctx, updates)
 
 	if err != nil {
 		logger(fmt.Sprintf("error occurred updating the manifest: %s", err.Error()))
@@ -376,21 +395,35 @@ func (rs *RemoteChunkStore) AddTableFiles(ctx context.Context, req *remotesapi.A
 	return &remotesapi.AddTableFilesResponse{Success: true}, nil
 }
 
-func (rs *RemoteChunkStore) getStore(repoId *remotesapi.RepoId, rpcName string) *nbs.NomsBlockStore {
-	return rs.getOrCreateStore(repoId, rpcName, types.Format_Default.VersionString())
+// getStore returns the chunk store of a repo after checking that the client of the request can read it, or write it
+// when write is true.
+func (rs *RemoteChunkStore) getStore(ctx context.Context, repoId *remotesapi.RepoId, rpcName string, write bool) (*nbs.NomsBlockStore, error) {
+	return rs.getOrCreateStore(ctx, repoId, rpcName, types.Format_Default.VersionString(), write)
 }
 
-func (rs *RemoteChunkStore) getOrCreateStore(repoId *remotesapi.RepoId, rpcName, nbfVerStr string) *nbs.NomsBlockStore {
+func (rs *RemoteChunkStore) getOrCreateStore(ctx context.Context, repoId *remotesapi.RepoId, rpcName, nbfVerStr string, write bool) (*nbs.NomsBlockStore, error) {
+	err := rs.auth.Authorize(ctx, repoId, write)
+
+	if err != nil {
+		log.Printf("%s request rejected: %v\n", rpcName, err)
+		return nil, err
+	}
+
 	org := repoId.Org
 	repoName := repoId.RepoName
 
-	cs, err := rs.csCache.Get(org, repoName, nbfVerStr)
+	// without authentication any request creates the repo it refers to
+	create := write || rs.auth == nil
+	cs, err := rs.csCache.Get(org, repoName, nbfVerStr, create)
 
-	if err != nil {
+	if err == ErrRepoNotFound {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("repo %s/%s not found", org, repoName))
+	} else if err != nil || cs == nil {
 		log.Printf("Failed to retrieve chunkstore for %s/%s\n", org, repoName)
+		return nil, status.Error(codes.Internal, "Could not get chunkstore")
 	}
 
-	return cs
+	return cs, nil
 }
 
 var requestId int32
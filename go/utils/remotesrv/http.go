@@ -35,6 +35,21 @@ import (
 
 var expectedFiles = make(map[string]*remotesapi.TableFileDetails)
 
+// NewHttpHandler returns the handler of the http file server. When authentication is enabled, only the urls signed by
+// the grpc server for the clients it authorized are served.
+func NewHttpHandler(auth *RepoAuth) http.Handler {
+	return http.HandlerFunc(func(respWr http.ResponseWriter, req *http.Request) {
+		if !auth.VerifyURL(req.Method, req.URL) {
+			logger := getReqLogger("HTTP_"+req.Method, req.URL.Path)
+			logger(fmt.Sprintf("response to: %v method: %v http response code: %v", req.URL.Path, req.Method, http.StatusForbidden))
+			respWr.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		ServeHTTP(respWr, req)
+	})
+}
+
 func ServeHTTP(respWr http.ResponseWriter, req *http.Request) {
 	logger := getReqLogger("HTTP_"+req.Method, req.RequestURI)
 	defer func() { logger("finished") }()
@@ -42,9 +57,10 @@ func ServeHTTP(respWr http.ResponseWriter, req *http.Request) {
 	path := strings.TrimLeft(req.URL.Path, "/")
 	tokens := strings.Split(path, "/")
 
-	if len(tokens) != 3 {
+	if len(tokens) != 3 || !isValidPathName(tokens[0]) || !isValidPathName(tokens[1]) {
 		logger(fmt.Sprintf("response to: %v method: %v http response code: %v", req.RequestURI, req.Method, http.StatusNotFound))
 		respWr.WriteHeader(http.StatusNotFound)
+		return
 	}
 
 	org := tokens[0]
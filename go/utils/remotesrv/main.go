@@ -35,8 +35,21 @@ func main() {
 	dirParam := flag.String("dir", "", "root directory that this command will run in.")
 	grpcPortParam := flag.Int("grpc-port", -1, "root directory that this command will run in.")
 	httpPortParam := flag.Int("http-port", -1, "root directory that this command will run in.")
+	authConfigParam := flag.String("auth-config", "", "yaml file declaring the users of the server and their access to repos.")
 	flag.Parse()
 
+	var auth *RepoAuth
+	if len(*authConfigParam) > 0 {
+		var err error
+		auth, err = LoadRepoAuth(*authConfigParam)
+
+		if err != nil {
+			log.Fatalln("failed to load auth config:", err.Error())
+		}
+	} else {
+		log.Println("'auth-config' parameter not provided. Any client can read and write any repo.")
+	}
+
 	if dirParam != nil && len(*dirParam) > 0 {
 		err := os.Chdir(*dirParam)
 
@@ -65,7 +78,7 @@ func main() {
 		log.Println("'grpc-port' parameter not provided. Using default port 50051")
 	}
 
-	stopChan, wg := startServer(httpHost, *httpPortParam, *grpcPortParam)
+	stopChan, wg := startServer(httpHost, *httpPortParam, *grpcPortParam, auth)
 	waitForSignal()
 
 	close(stopChan)
@@ -80,32 +93,32 @@ func waitForSignal() {
 	<-c
 }
 
-func startServer(httpHost string, httpPort, grpcPort int) (chan interface{}, *sync.WaitGroup) {
+func startServer(httpHost string, httpPort, grpcPort int, auth *RepoAuth) (chan interface{}, *sync.WaitGroup) {
 	wg := sync.WaitGroup{}
 	stopChan := make(chan interface{})
 
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		httpServer(httpPort, stopChan)
+		httpServer(httpPort, auth, stopChan)
 	}()
 
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		grpcServer(httpHost, grpcPort, stopChan)
+		grpcServer(httpHost, grpcPort, auth, stopChan)
 	}()
 
 	return stopChan, &wg
 }
 
-func grpcServer(httpHost string, grpcPort int, stopChan chan interface{}) {
+func grpcServer(httpHost string, grpcPort int, auth *RepoAuth, stopChan chan interface{}) {
 	defer func() {
 		log.Println("exiting grpc Server go routine")
 	}()
 
 	dbCache := NewLocalCSCache(filesys.LocalFS)
-	chnkSt := NewHttpFSBackedChunkStore(httpHost, dbCache)
+	chnkSt := NewHttpFSBackedChunkStore(httpHost, dbCache, auth)
 
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", grpcPort))
 	if err != nil {
@@ -115,6 +128,7 @@ func grpcServer(httpHost string, grpcPort int, stopChan chan interface{}) {
 	grpcServer := grpc.NewServer(grpc.MaxRecvMsgSize(128 * 1024 * 1024))
 	go func() {
 		remotesapi.RegisterChunkStoreServiceServer(grpcServer, chnkSt)
+		remotesapi.RegisterCredentialsServiceServer(grpcServer, NewCredentialsServer(auth))
 
 		log.Println("Starting grpc server on port", grpcPort)
 		err := grpcServer.Serve(lis)
@@ -125,14 +139,14 @@ func grpcServer(httpHost string, grpcPort int, stopChan chan interface{}) {
 	grpcServer.GracefulStop()
 }
 
-func httpServer(httpPort int, stopChan chan interface{}) {
+func httpServer(httpPort int, auth *RepoAuth, stopChan chan interface{}) {
 	defer func() {
 		log.Println("exiting http Server go routine")
 	}()
 
 	server := http.Server{
 		Addr:    fmt.Sprintf(":%d", httpPort),
-		Handler: http.HandlerFunc(ServeHTTP),
+		Handler: NewHttpHandler(auth),
 	}
 
 	go func() {